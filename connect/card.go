@@ -0,0 +1,191 @@
+package connect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// errNoCardButton means the card (or its inline Connect button) isn't on the page
+var errNoCardButton = errors.New("connect button not found on card")
+
+// ConnectFromSuggestionCard sends an invite straight from a "People you may know" card
+// The page must already show the suggestions grid. Grid invites go out without a note.
+func ConnectFromSuggestionCard(page *rod.Page, profileURL string, personName string, tracker *ConnectionTracker) error {
+	return connectFromCard(page, profileURL, personName, "", tracker)
+}
+
+// ConnectFromSearchCard sends an invite from the inline Connect button of a search result card,
+// saving a full profile visit. The page must show the search results containing the card.
+// Falls back to ConnectWithTracking (profile navigation) when the card has no Connect button,
+// and always uses it when RequireNote is set - some cards send without opening the note modal.
+func ConnectFromSearchCard(page *rod.Page, cardProfileURL string, note string, tracker *ConnectionTracker) error {
+	if tracker.RequireNote {
+		return ConnectWithTracking(page, cardProfileURL, "", note, tracker)
+	}
+	err := connectFromCard(page, cardProfileURL, "", note, tracker)
+	if errors.Is(err, errNoCardButton) {
+		fmt.Println("↪️ No inline Connect on the card - opening the profile instead")
+		return ConnectWithTracking(page, cardProfileURL, "", note, tracker)
+	}
+	return err
+}
+
+// connectFromCard clicks the Connect button on the card linking to profileURL,
+// adds the note when the invite modal allows it, and records the request
+// like ConnectWithTracking does
+func connectFromCard(page *rod.Page, profileURL string, personName string, note string, tracker *ConnectionTracker) error {
+	note, noteOmitted, err := tracker.prepareRequest(profileURL, note)
+	if err != nil {
+		return err
+	}
+
+	if tracker.DryRun {
+		fmt.Println("🧪 [DRY RUN] Would send connection request from card")
+		fmt.Printf("   📍 Profile: %s\n", profileURL)
+		fmt.Printf("   👤 Name: %s\n", personName)
+		if note != "" {
+			fmt.Printf("   📝 Note (%d chars): %s\n", utf8.RuneCountInString(note), note)
+		}
+		tracker.trackRequest(ConnectionRequest{
+			ProfileURL:  profileURL,
+			Name:        personName,
+			Note:        note,
+			SentAt:      time.Now(),
+			Status:      "sent",
+			NoteOmitted: noteOmitted,
+		})
+		return nil
+	}
+
+	if err := stealth.RequireApproval(stealth.ActionConnection, profileURL, note); err != nil {
+		return err
+	}
+
+	if err := clickCardConnect(page, profileURL); err != nil {
+		return err
+	}
+
+	stealth.SleepMillis(800, 1500)
+
+	detectionResult := stealth.QuickCheck(page)
+	if detectionResult.HasError {
+		stealth.PrintDetectionStatus(detectionResult)
+		return detectionResult.Error
+	}
+
+	if err := handleHowDoYouKnow(page); err != nil {
+		return err
+	}
+	if err := handleEmailRequired(page, tracker.emailFor(profileURL)); err != nil {
+		return err
+	}
+
+	// Some cards open the invite modal, others send right away
+	noteSkipped := false
+	sentNote := ""
+	if modal, _, _ := page.Has(`div[role="dialog"]`); modal {
+		if note != "" {
+			if utf8.RuneCountInString(note) > MaxNoteLength {
+				note = truncateNote(note)
+			}
+			err := clickAddNote(page)
+			if stealth.IsNoteLimit(err) {
+				if StopOnNoteLimit {
+					dismissModal(page)
+					return err
+				}
+				fmt.Println("⚠️ Personalized note limit reached - sending without note")
+				noteSkipped = true
+			} else if err != nil {
+				fmt.Println("⚠️ Could not add note, sending without note")
+			} else if err := typeNote(page, note); err != nil {
+				return fmt.Errorf("failed to type note: %w", err)
+			} else {
+				sentNote = note
+			}
+		}
+
+		if err := clickSendButton(page, false); err != nil {
+			return fmt.Errorf("failed to send request: %w", err)
+		}
+	}
+
+	fmt.Println("✅ Connection request sent from card!")
+	dismissPostSendPrompt(page)
+
+	tracker.trackRequest(ConnectionRequest{
+		ProfileURL:  profileURL,
+		Name:        personName,
+		Note:        sentNote,
+		SentAt:      time.Now(),
+		Status:      "sent",
+		NoteSkipped: noteSkipped,
+		NoteOmitted: noteOmitted,
+	})
+
+	return nil
+}
+
+// clickCardConnect finds the card linking to profileURL and clicks its Connect button
+func clickCardConnect(page *rod.Page, profileURL string) error {
+	// Card Connect buttons often send the invite immediately
+	if err := stealth.BlockWrite("card connect", profileURL); err != nil {
+		return err
+	}
+
+	loc := stealth.DetectLocale(page)
+	slug := strings.ToLower(strings.TrimSuffix(linkedinurl.Canonicalize(profileURL), "/"))
+	if i := strings.Index(slug, "/in/"); i >= 0 {
+		slug = slug[i:]
+	}
+
+	res, err := page.Eval(`(slug, loc) => {
+		for (const link of document.querySelectorAll('a[href*="/in/"]')) {
+			const href = link.href.split('?')[0].replace(/\/$/, '').toLowerCase();
+			if (!href.endsWith(slug)) continue;
+
+			// Walk up to the card that holds both the link and its buttons
+			let card = link;
+			for (let i = 0; i < 8 && card; i++) {
+				card = card.parentElement;
+				if (card && card.querySelector('button')) break;
+			}
+			if (!card) continue;
+
+			for (const btn of card.querySelectorAll('button')) {
+				const text = btn.innerText.trim().toLowerCase();
+				const label = (btn.getAttribute('aria-label') || '').toLowerCase();
+				if (loc.pending.includes(text) || loc.message.includes(text)) {
+					return 'already_connected_or_pending';
+				}
+				if (btn.disabled) continue;
+				if (loc.connect.includes(text) || (label.includes('invite') && label.includes('connect'))) {
+					btn.scrollIntoView({ block: "center" });
+					btn.click();
+					return 'clicked';
+				}
+			}
+			return 'connect_button_not_found';
+		}
+		return 'card_not_found';
+	}`, slug, loc)
+	if err != nil {
+		return fmt.Errorf("failed to find card connect button: %w", err)
+	}
+
+	switch res.Value.Str() {
+	case "clicked":
+		return nil
+	case "already_connected_or_pending":
+		return fmt.Errorf("already connected or request pending")
+	}
+	return errNoCardButton
+}
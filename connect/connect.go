@@ -2,13 +2,18 @@ package connect
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-rod/rod"
 
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
+	"github.com/Nehilsa2/linkedin_automation/persistence"
 	"github.com/Nehilsa2/linkedin_automation/stealth"
 )
 
@@ -20,6 +25,26 @@ const (
 	RequestsFile = "connection_requests.json"
 )
 
+// StopOnNoteLimit controls what happens when the monthly personalized-note cap is hit:
+// false sends the invite without a note, true cancels the invite and returns ErrorNoteLimitReached
+var StopOnNoteLimit = false
+
+// TruncateNoteAtWord makes an over-long note end on a word boundary
+// ("I'd love to..." rather than "I'd love to lear...")
+var TruncateNoteAtWord = true
+
+// ErrHowDoYouKnow is returned when Connect opens LinkedIn's "How do you know"
+// verification screen and it can't be passed without picking a relationship
+var ErrHowDoYouKnow = errors.New("linkedin asks how you know this member - skipped")
+
+// ErrNoteRequired is returned when RequireNote is set and the invite can't
+// carry its note - the invite is cancelled instead of going out note-less
+var ErrNoteRequired = errors.New("note required but could not be added - invite not sent")
+
+// ErrInviteNotPending is returned when Send was clicked but the profile still
+// offers Connect afterwards - LinkedIn silently dropped the invite
+var ErrInviteNotPending = errors.New("invite not pending after send - silently dropped by linkedin")
+
 // GetDefaultDailyLimit returns the daily limit from central config
 func GetDefaultDailyLimit() int {
 	return stealth.GetConnectionDailyLimit()
@@ -32,6 +57,15 @@ type ConnectionRequest struct {
 	Note       string    `json:"note,omitempty"`
 	SentAt     time.Time `json:"sent_at"`
 	Status     string    `json:"status"` // "sent", "pending", "accepted", "declined"
+
+	// NoteSkipped is set when a note was requested but dropped because of the note limit
+	NoteSkipped bool `json:"note_skipped,omitempty"`
+
+	// NoteOmitted is set when the note was deliberately left out (NoteOmissionRate)
+	NoteOmitted bool `json:"note_omitted,omitempty"`
+
+	// WithdrawnAt is set when the invite was withdrawn (AutoWithdrawPolicy)
+	WithdrawnAt *time.Time `json:"withdrawn_at,omitempty"`
 }
 
 // ConnectionTracker tracks sent requests and enforces limits
@@ -39,6 +73,25 @@ type ConnectionTracker struct {
 	Requests   []ConnectionRequest `json:"requests"`
 	DailyLimit int                 `json:"daily_limit"`
 	DryRun     bool                `json:"-"` // Don't persist this flag
+
+	// ValidateDryRun makes dry runs walk the real UI (Connect, modal, note)
+	// and stop right before Send, so broken selectors show up early
+	ValidateDryRun bool `json:"-"`
+
+	// NoteOmissionRate is the fraction (0-1) of requests sent without a note
+	// even when one is provided - a note on 100% of invites is itself a pattern
+	NoteOmissionRate float64 `json:"-"`
+
+	// RequireNote only sends invites that carry a personalized note: if the
+	// note can't be added the invite is cancelled, and NoteOmissionRate is ignored
+	RequireNote bool `json:"-"`
+
+	// EmailLookup returns a lead's email address ("" if unknown), entered when
+	// a member only accepts invites from people who know their email
+	EmailLookup func(profileURL string) string `json:"-"`
+
+	// notesSent holds hashes of notes sent this run (see uniqueNote)
+	notesSent map[string]bool
 }
 
 // LoadTracker loads the tracker from file
@@ -100,23 +153,51 @@ func (t *ConnectionTracker) AddRequest(req ConnectionRequest) {
 }
 
 // AlreadySent checks if a request was already sent to this profile
+// Requests withdrawn longer than the re-send cooldown ago don't count
 func (t *ConnectionTracker) AlreadySent(profileURL string) bool {
-	normalized := normalizeProfileURL(profileURL)
+	normalized := linkedinurl.Canonicalize(profileURL)
 	for _, req := range t.Requests {
-		if normalizeProfileURL(req.ProfileURL) == normalized {
-			return true
+		if linkedinurl.Canonicalize(req.ProfileURL) != normalized {
+			continue
+		}
+		if req.WithdrawnAt != nil && time.Since(*req.WithdrawnAt) >= persistence.WithdrawResendCooldown {
+			continue
 		}
+		return true
 	}
 	return false
 }
 
-// normalizeProfileURL normalizes LinkedIn profile URLs for comparison
-func normalizeProfileURL(url string) string {
-	url = strings.TrimSuffix(url, "/")
-	url = strings.TrimPrefix(url, "https://")
-	url = strings.TrimPrefix(url, "http://")
-	url = strings.TrimPrefix(url, "www.")
-	return strings.ToLower(url)
+// MarkWithdrawn records that the request to this profile was withdrawn
+func (t *ConnectionTracker) MarkWithdrawn(profileURL string) {
+	now := time.Now()
+	if req := t.GetRequest(profileURL); req != nil {
+		req.Status = persistence.StatusWithdrawn
+		req.WithdrawnAt = &now
+	}
+}
+
+// GetRequest returns the latest tracked request for a profile, or nil
+func (t *ConnectionTracker) GetRequest(profileURL string) *ConnectionRequest {
+	normalized := linkedinurl.Canonicalize(profileURL)
+	for i := len(t.Requests) - 1; i >= 0; i-- {
+		if linkedinurl.Canonicalize(t.Requests[i].ProfileURL) == normalized {
+			return &t.Requests[i]
+		}
+	}
+	return nil
+}
+
+// NoteSkipped reports whether the request to this profile went out without its note
+// because the personalized-note limit was reached
+func (t *ConnectionTracker) NoteSkipped(profileURL string) bool {
+	normalized := linkedinurl.Canonicalize(profileURL)
+	for _, req := range t.Requests {
+		if linkedinurl.Canonicalize(req.ProfileURL) == normalized {
+			return req.NoteSkipped
+		}
+	}
+	return false
 }
 
 // NavigateToProfile navigates to a LinkedIn profile
@@ -124,7 +205,7 @@ func NavigateToProfile(page *rod.Page, profileURL string) error {
 	fmt.Printf("📍 Navigating to profile: %s\n", profileURL)
 
 	// Set timeout to prevent hanging
-	timeoutPage := page.Timeout(15 * time.Second)
+	timeoutPage := page.Timeout(stealth.GetNavigationTimeout())
 
 	err := timeoutPage.Navigate(profileURL)
 	if err != nil {
@@ -155,14 +236,46 @@ func NavigateToProfile(page *rod.Page, profileURL string) error {
 // SendConnectionRequest sends a connection request to the current profile
 // If note is empty, sends without a note
 func SendConnectionRequest(page *rod.Page, note string) error {
+	_, err := sendConnectionRequest(page, note, "", false, false)
+	return err
+}
+
+// sendConnectionRequest sends the request and reports whether the note was dropped
+// because LinkedIn's personalized-note limit was reached
+// With validate set, everything runs except the final Send click and the modal is dismissed
+// With requireNote set, the invite is cancelled if the note can't be added
+// email is entered if the member asks for it (empty skips such members)
+func sendConnectionRequest(page *rod.Page, note, email string, validate, requireNote bool) (bool, error) {
 	fmt.Println("🔗 Looking for Connect button...")
 
+	// Read-only wins over validate mode: Connect alone can send on some profiles
+	if err := stealth.BlockWrite("connect", ""); err != nil {
+		return false, err
+	}
+
+	// Banners and tips left over from browsing would swallow the Connect click
+	stealth.DismissOverlays(page)
+
 	// Set timeout to prevent hanging
-	page = page.Timeout(15 * time.Second)
+	page = page.Timeout(stealth.GetEvalTimeout())
 	defer page.CancelTimeout()
 
+	// Button texts depend on the LinkedIn UI language
+	loc := stealth.DetectLocale(page)
+
 	// First, try to find and click the Connect button
-	result := page.MustEval(`() => {
+	// Long profiles also render a sticky top bar with its own Connect button;
+	// whichever Connect is currently in the viewport wins. When the profile's
+	// own Connect sits under "More", it is taken from that menu - never from a
+	// sidebar card, which would invite someone else
+	res, err := page.Eval(`async (loc) => {
+		// Sticky header bar selectors (shown once the main actions scroll out of view)
+		const stickySelectors = [
+			'.pv-profile-sticky-header-v2__actions-container button',
+			'[class*="profile-sticky-header"] button',
+			'[class*="sticky-header"] button[aria-label*="connect" i]',
+		];
+
 		// Various Connect button selectors
 		const connectSelectors = [
 			'button[aria-label*="Invite"][aria-label*="connect"]',
@@ -172,17 +285,26 @@ func SendConnectionRequest(page *rod.Page, note string) error {
 			'main button[aria-label*="connect" i]',
 		];
 
+		const isSticky = (btn) => !!btn.closest('[class*="sticky-header"]');
+		const inViewport = (el) => {
+			const r = el.getBoundingClientRect();
+			return r.width > 0 && r.height > 0 && r.bottom > 0 && r.top < window.innerHeight;
+		};
+		const looksLikeConnect = (btn) => {
+			const text = btn.innerText.toLowerCase();
+			return loc.connect.some(c => text.includes(c)) && !loc.message.some(m => text.includes(m));
+		};
+
+		const candidates = [];
+		const add = (btn) => {
+			if (btn && !btn.disabled && !candidates.includes(btn)) candidates.push(btn);
+		};
+
 		// Try each selector
-		for (const selector of connectSelectors) {
+		for (const selector of [...stickySelectors, ...connectSelectors]) {
 			try {
-				const btn = document.querySelector(selector);
-				if (btn && !btn.disabled) {
-					const text = btn.innerText.toLowerCase();
-					if (text.includes('connect') && !text.includes('message')) {
-						btn.scrollIntoView({ block: "center" });
-						btn.click();
-						return { found: true, clicked: true, error: null };
-					}
+				for (const btn of document.querySelectorAll(selector)) {
+					if (looksLikeConnect(btn)) add(btn);
 				}
 			} catch (e) {}
 		}
@@ -190,24 +312,65 @@ func SendConnectionRequest(page *rod.Page, note string) error {
 		// Try finding by text content
 		const buttons = document.querySelectorAll('button');
 		for (const btn of buttons) {
+			if (loc.connect.includes(btn.innerText.trim().toLowerCase())) add(btn);
+		}
+
+		// Sidebar cards ("People also viewed") have Connect buttons too - never use those
+		const own = candidates.filter(b => !b.closest('aside'));
+		if (own.length > 0) {
+			const btn = own.find(inViewport) || own.find(b => !isSticky(b)) || own[0];
+			const sticky = isSticky(btn);
+			// The sticky bar is fixed - scrolling to it would only move the page
+			if (!sticky) btn.scrollIntoView({ block: "center" });
+			btn.click();
+			return { found: true, clicked: true, sticky: sticky, error: null };
+		}
+
+		// No Connect of its own on the top card: look in the "More" menu
+		const isMore = (btn) => {
 			const text = btn.innerText.trim().toLowerCase();
-			if (text === 'connect' && !btn.disabled) {
-				btn.scrollIntoView({ block: "center" });
-				btn.click();
-				return { found: true, clicked: true, error: null };
+			const label = (btn.getAttribute('aria-label') || '').trim().toLowerCase();
+			return loc.more.includes(text) || loc.more.includes(label);
+		};
+		const more = Array.from(document.querySelectorAll('main button'))
+			.find(b => !b.closest('aside') && !isSticky(b) && isMore(b));
+		if (more) {
+			more.scrollIntoView({ block: "center" });
+			more.click();
+			await new Promise(r => setTimeout(r, 600 + Math.random() * 600));
+
+			const items = document.querySelectorAll([
+				'.artdeco-dropdown__content [role="button"]',
+				'.artdeco-dropdown__content li > div',
+				'div[role="menu"] [role="menuitem"]',
+			].join(', '));
+			for (const item of items) {
+				if (item.closest('aside')) continue;
+				const text = (item.innerText || '').trim().toLowerCase();
+				const label = (item.getAttribute('aria-label') || '').toLowerCase();
+				if (loc.connect.includes(text) || (label.includes('invite') && label.includes('connect'))) {
+					item.click();
+					return { found: true, clicked: true, sticky: false, viaMore: true, error: null };
+				}
 			}
+			// Close the menu again
+			more.click();
 		}
 
 		// Check if already connected or pending
 		for (const btn of buttons) {
 			const text = btn.innerText.trim().toLowerCase();
-			if (text === 'pending' || text === 'message') {
+			if (loc.pending.includes(text) || loc.message.includes(text)) {
 				return { found: false, clicked: false, error: 'already_connected_or_pending' };
 			}
 		}
 
 		return { found: false, clicked: false, error: 'connect_button_not_found' };
-	}`)
+	}`, loc)
+	if err != nil {
+		return false, fmt.Errorf("failed to find connect button: %w", err)
+	}
+	result := res.Value
 
 	found := result.Get("found").Bool()
 	clicked := result.Get("clicked").Bool()
@@ -215,13 +378,20 @@ func SendConnectionRequest(page *rod.Page, note string) error {
 
 	if !found {
 		if errorMsg == "already_connected_or_pending" {
-			return fmt.Errorf("already connected or request pending")
+			return false, fmt.Errorf("already connected or request pending")
 		}
-		return fmt.Errorf("connect button not found")
+		return false, fmt.Errorf("connect button not found")
+	}
+
+	if result.Get("sticky").Bool() {
+		fmt.Println("   📌 Used the Connect button in the sticky header")
+	}
+	if result.Get("viaMore").Bool() {
+		fmt.Println("   📂 Connect was under the \"More\" menu")
 	}
 
 	if !clicked {
-		return fmt.Errorf("failed to click connect button")
+		return false, fmt.Errorf("failed to click connect button")
 	}
 
 	// Wait for modal to appear
@@ -231,43 +401,132 @@ func SendConnectionRequest(page *rod.Page, note string) error {
 	detectionResult := stealth.QuickCheck(page)
 	if detectionResult.HasError {
 		stealth.PrintDetectionStatus(detectionResult)
-		return detectionResult.Error
+		return false, detectionResult.Error
+	}
+
+	// Some members require "How do you know ..." before the invite modal
+	if err := handleHowDoYouKnow(page); err != nil {
+		return false, err
+	}
+
+	// Others only take invites from people who know their email
+	if err := handleEmailRequired(page, email); err != nil {
+		return false, err
 	}
 
 	// Handle the connection modal
+	noteSkipped := false
 	if note != "" {
 		// Truncate note if too long
-		if len(note) > MaxNoteLength {
-			note = note[:MaxNoteLength-3] + "..."
+		if utf8.RuneCountInString(note) > MaxNoteLength {
+			note = truncateNote(note)
 			fmt.Printf("⚠️ Note truncated to %d characters\n", MaxNoteLength)
 		}
 
 		// Click "Add a note" button if present
 		err := clickAddNote(page)
-		if err != nil {
+		if stealth.IsNoteLimit(err) {
+			if StopOnNoteLimit || requireNote {
+				fmt.Println("🛑 Personalized note limit reached - cancelling invite")
+				dismissModal(page)
+				return false, err
+			}
+			fmt.Println("⚠️ Personalized note limit reached - sending without note")
+			noteSkipped = true
+		} else if err != nil && requireNote {
+			fmt.Printf("🛑 Could not add note (%v) - cancelling invite\n", err)
+			dismissModal(page)
+			return false, ErrNoteRequired
+		} else if err != nil {
 			fmt.Println("⚠️ Could not add note, sending without note")
 		} else {
 			// Type the note
 			err = typeNote(page, note)
 			if err != nil {
-				return fmt.Errorf("failed to type note: %w", err)
+				return false, fmt.Errorf("failed to type note: %w", err)
 			}
 		}
 	}
 
 	// Click Send button
-	err := clickSendButton(page)
+	err = clickSendButton(page, validate)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return false, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if validate {
+		fmt.Println("🧪 [VALIDATE] Send button located - closing the modal without sending")
+		dismissModal(page)
+		return noteSkipped, nil
+	}
+
+	dismissPostSendPrompt(page)
+
+	// Soft-blocked profiles accept the click but never create the invite
+	if err := verifyInvitePending(page, loc); err != nil {
+		return noteSkipped, err
 	}
 
 	fmt.Println("✅ Connection request sent!")
+	return noteSkipped, nil
+}
+
+// verifyInvitePending re-checks the profile's own action buttons after Send
+// Returns ErrInviteNotPending if Connect is still offered and nothing reads
+// "Pending"; when neither button is visible the send is given the benefit of the doubt
+func verifyInvitePending(page *rod.Page, loc *stealth.Locale) error {
+	stealth.SleepMillis(1500, 2500)
+
+	res, err := page.Eval(`(loc) => {
+		let connect = false;
+		for (const btn of document.querySelectorAll('main button')) {
+			// Sidebar cards ("People also viewed") keep their own Connect buttons
+			if (btn.closest('aside')) continue;
+			const text = btn.innerText.trim().toLowerCase();
+			const label = (btn.getAttribute('aria-label') || '').toLowerCase();
+			if (loc.pending.some(p => text.includes(p) || label.includes(p))) return 'pending';
+			if (loc.connect.includes(text)) connect = true;
+		}
+		return connect ? 'connect' : 'unknown';
+	}`, loc)
+	if err != nil {
+		fmt.Printf("   ⚠️ Could not verify the invite state: %v\n", err)
+		return nil
+	}
+
+	if res.Value.Str() == "connect" {
+		fmt.Println("❌ Profile still shows Connect after sending - invite was silently dropped")
+		return ErrInviteNotPending
+	}
 	return nil
 }
 
-// clickAddNote clicks the "Add a note" button in the modal
+// clickAddNote clicks the "Add a note" button in the connection modal
+// Returns ErrorNoteLimitReached if the button is disabled or a note paywall appears
 func clickAddNote(page *rod.Page) error {
-	result := page.MustEval(`() => {
+	loc := stealth.DetectLocale(page)
+
+	res, err := page.Eval(`(loc) => {
+		const paywallPhrases = [
+			'used all your personalized invitations',
+			'out of personalized invitations',
+			'personalized invitations for the month',
+			'send unlimited personalized invitations',
+		];
+		const hasPaywall = () => {
+			const dialogs = document.querySelectorAll('div[role="dialog"], .artdeco-modal');
+			for (const d of dialogs) {
+				const text = (d.innerText || '').toLowerCase();
+				if (paywallPhrases.some(p => text.includes(p))) return true;
+			}
+			return false;
+		};
+		const isDisabled = (btn) => btn.disabled || btn.getAttribute('aria-disabled') === 'true';
+
+		if (hasPaywall()) {
+			return { clicked: false, limit: true };
+		}
+
 		const selectors = [
 			'button[aria-label="Add a note"]',
 			'button:contains("Add a note")',
@@ -278,8 +537,9 @@ func clickAddNote(page *rod.Page) error {
 			try {
 				const btn = document.querySelector(selector);
 				if (btn) {
+					if (isDisabled(btn)) return { clicked: false, limit: true };
 					btn.click();
-					return true;
+					return { clicked: true, limit: false };
 				}
 			} catch (e) {}
 		}
@@ -287,26 +547,188 @@ func clickAddNote(page *rod.Page) error {
 		// Try by text
 		const buttons = document.querySelectorAll('button');
 		for (const btn of buttons) {
-			if (btn.innerText.toLowerCase().includes('add a note')) {
+			const text = btn.innerText.toLowerCase();
+			if (loc.addNote.some(a => text.includes(a))) {
+				if (isDisabled(btn)) return { clicked: false, limit: true };
 				btn.click();
-				return true;
+				return { clicked: true, limit: false };
 			}
 		}
 
-		return false;
-	}`)
+		return { clicked: false, limit: false };
+	}`, loc)
+	if err != nil {
+		return fmt.Errorf("failed to find add note button: %w", err)
+	}
+	result := res.Value
 
-	if !result.Bool() {
+	if result.Get("limit").Bool() {
+		return stealth.NewError(stealth.ErrorNoteLimitReached)
+	}
+	if !result.Get("clicked").Bool() {
 		return fmt.Errorf("add note button not found")
 	}
 
 	stealth.SleepMillis(400, 700)
+
+	// The paywall can also open after clicking "Add a note"
+	res, err = page.Eval(`() => {
+		const dialogs = document.querySelectorAll('div[role="dialog"], .artdeco-modal');
+		for (const d of dialogs) {
+			const text = (d.innerText || '').toLowerCase();
+			if (text.includes('personalized invitations')) return true;
+		}
+		return false;
+	}`)
+	if err != nil {
+		return fmt.Errorf("failed to check note paywall: %w", err)
+	}
+	if res.Value.Bool() {
+		return stealth.NewError(stealth.ErrorNoteLimitReached)
+	}
+
 	return nil
 }
 
+// handleHowDoYouKnow deals with the "select how you know them" verification screen
+// Continues past it when LinkedIn allows it without a selection, otherwise
+// dismisses it and returns ErrHowDoYouKnow so the profile is skipped
+func handleHowDoYouKnow(page *rod.Page) error {
+	res, err := page.Eval(`() => {
+		const options = ['colleague', 'classmate', 'we\'ve done business together',
+			'friend', 'other', 'i don\'t know'];
+
+		const dialogs = document.querySelectorAll('div[role="dialog"], .artdeco-modal');
+		for (const d of dialogs) {
+			const text = (d.innerText || '').toLowerCase();
+			const isVerify = text.includes('how you know') || text.includes('how do you know');
+			const labels = Array.from(d.querySelectorAll('button, label'))
+				.map(el => (el.innerText || '').trim().toLowerCase());
+			const matches = options.filter(o => labels.includes(o)).length;
+			if (!isVerify && matches < 2) continue;
+
+			// Some variants let you continue without choosing
+			for (const btn of d.querySelectorAll('button')) {
+				const t = (btn.innerText || '').trim().toLowerCase();
+				const disabled = btn.disabled || btn.getAttribute('aria-disabled') === 'true';
+				if ((t === 'connect' || t === 'continue' || t === 'next') && !disabled) {
+					btn.click();
+					return { present: true, passed: true };
+				}
+			}
+			return { present: true, passed: false };
+		}
+		return { present: false, passed: false };
+	}`)
+	if err != nil {
+		return fmt.Errorf("failed to check for verification screen: %w", err)
+	}
+	result := res.Value
+
+	if !result.Get("present").Bool() {
+		return nil
+	}
+
+	if result.Get("passed").Bool() {
+		fmt.Println("ℹ️ \"How do you know\" screen shown - continued without a selection")
+		stealth.SleepMillis(800, 1500)
+		return nil
+	}
+
+	fmt.Println("⏭️ \"How do you know\" screen requires a selection - skipping profile")
+	dismissModal(page)
+	return ErrHowDoYouKnow
+}
+
+// handleEmailRequired handles the "enter their email to connect" screen some
+// members put in front of invites. With an email it is filled in and the
+// invite continues; without one the modal is dismissed and ErrorEmailRequired
+// returned so the profile is skipped
+func handleEmailRequired(page *rod.Page, email string) error {
+	res, err := page.Eval(`(email) => {
+		const dialogs = document.querySelectorAll('div[role="dialog"], .artdeco-modal');
+		for (const d of dialogs) {
+			const input = d.querySelector('input[type="email"], input[name="email"], input#email');
+			const text = (d.innerText || '').toLowerCase();
+			if (!input && !(text.includes('email') && text.includes('verify'))) continue;
+			if (!input || !email) return { present: true, filled: false };
+
+			input.focus();
+			input.value = email;
+			input.dispatchEvent(new Event('input', { bubbles: true }));
+			input.dispatchEvent(new Event('change', { bubbles: true }));
+			return { present: true, filled: true };
+		}
+		return { present: false, filled: false };
+	}`, email)
+	if err != nil {
+		return fmt.Errorf("failed to check for email screen: %w", err)
+	}
+	result := res.Value
+
+	if !result.Get("present").Bool() {
+		return nil
+	}
+
+	if result.Get("filled").Bool() {
+		fmt.Println("✉️ Member asks for their email - entered the stored address")
+		stealth.SleepMillis(500, 1000)
+		return nil
+	}
+
+	fmt.Println("⏭️ Member only accepts invites with their email and none is stored - skipping profile")
+	dismissModal(page)
+	return stealth.NewError(stealth.ErrorEmailRequired)
+}
+
+// dismissModal closes the open connection modal without sending
+func dismissModal(page *rod.Page) {
+	// Best effort - a failed dismiss leaves the modal for the next navigation
+	page.Eval(`() => {
+		const btn = document.querySelector('button[aria-label="Dismiss"]') ||
+		            document.querySelector('.artdeco-modal__dismiss');
+		if (btn) btn.click();
+	}`)
+	stealth.SleepMillis(300, 600)
+}
+
+// dismissPostSendPrompt closes the prompts LinkedIn sometimes shows after an invite
+// ("Want a reminder to follow up?", "Add to a list", Sales Navigator upsells)
+// so the next navigation starts from a clean page. Returns true if one was dismissed.
+func dismissPostSendPrompt(page *rod.Page) bool {
+	stealth.SleepMillis(700, 1200)
+
+	res, err := page.Eval(`() => {
+		const promptText = /reminder|follow[- ]up|add (them )?to (a )?list|save to list|sales navigator/i;
+		const labels = ['no thanks', 'not now', 'dismiss', 'skip', 'close', 'maybe later'];
+		const containers = document.querySelectorAll(
+			'div[role="dialog"], div[role="alertdialog"], .artdeco-modal, .artdeco-toast-item, [class*="post-send"]'
+		);
+		for (const c of containers) {
+			if (!promptText.test(c.innerText || '')) continue;
+			for (const btn of c.querySelectorAll('button, a[role="button"]')) {
+				const text = (btn.innerText || '').trim().toLowerCase();
+				const label = (btn.getAttribute('aria-label') || '').trim().toLowerCase();
+				if (labels.some(l => text === l || label.startsWith(l))) {
+					btn.click();
+					return true;
+				}
+			}
+		}
+		return false;
+	}`)
+	if err != nil || !res.Value.Bool() {
+		return false
+	}
+
+	fmt.Println("   🧹 Dismissed post-send prompt")
+	stealth.SleepMillis(300, 600)
+	return true
+}
+
 // typeNote types the personalized note
 func typeNote(page *rod.Page, note string) error {
-	result := page.MustEval(`(note) => {
+	res, err := page.Eval(`(note) => {
 		const selectors = [
 			'textarea[name="message"]',
 			'textarea#custom-message',
@@ -327,6 +749,10 @@ func typeNote(page *rod.Page, note string) error {
 
 		return false;
 	}`, note)
+	if err != nil {
+		return fmt.Errorf("failed to find note textarea: %w", err)
+	}
+	result := res.Value
 
 	if !result.Bool() {
 		return fmt.Errorf("note textarea not found")
@@ -335,95 +761,210 @@ func typeNote(page *rod.Page, note string) error {
 	return nil
 }
 
+// sendButtonWait is how long clickSendButton waits for a disabled Send button to enable
+const sendButtonWait = 3 * time.Second
+
 // clickSendButton clicks the Send/Connect button in the modal
-func clickSendButton(page *rod.Page) error {
+// The button is briefly disabled while LinkedIn validates the invite, so a
+// disabled button is polled until it enables - unless the note is too long,
+// which won't fix itself. With locateOnly the button is found but not clicked.
+func clickSendButton(page *rod.Page, locateOnly bool) error {
+	if err := stealth.BlockWrite("connection request send", ""); err != nil {
+		dismissModal(page)
+		return err
+	}
+
 	stealth.SleepMillis(400, 700)
 
-	result := page.MustEval(`() => {
-		const selectors = [
-			'button[aria-label="Send now"]',
-			'button[aria-label="Send invitation"]',
-			'button.artdeco-button--primary[type="submit"]',
-		];
+	loc := stealth.DetectLocale(page)
+	deadline := time.Now().Add(sendButtonWait)
+
+	for {
+		res, err := page.Eval(`(loc, maxNote, locateOnly) => {
+			const isDisabled = (btn) => btn.disabled || btn.getAttribute('aria-disabled') === 'true';
+			const press = (btn) => { if (!locateOnly) btn.click(); };
+			let sawDisabled = false;
+
+			// A note over the limit keeps Send disabled for good
+			const modal = document.querySelector('div[role="dialog"]');
+			if (modal) {
+				const textarea = modal.querySelector('textarea');
+				if (textarea) {
+					const limit = parseInt(textarea.getAttribute('maxlength') || '', 10) || maxNote;
+					const counterError = modal.querySelector('.artdeco-text-input__error, [class*="counter"][class*="error"]');
+					if (textarea.value.length > limit || counterError) {
+						return { clicked: false, error: 'note_too_long' };
+					}
+				}
+			}
 
-		// Try selectors
-		for (const selector of selectors) {
-			try {
-				const btn = document.querySelector(selector);
-				if (btn && !btn.disabled) {
-					btn.click();
-					return { clicked: true, error: null };
+			const selectors = [
+				'button[aria-label="Send now"]',
+				'button[aria-label="Send invitation"]',
+				'button.artdeco-button--primary[type="submit"]',
+			];
+
+			// Try selectors
+			for (const selector of selectors) {
+				try {
+					const btn = document.querySelector(selector);
+					if (btn) {
+						if (isDisabled(btn)) { sawDisabled = true; continue; }
+						press(btn);
+						return { clicked: true, error: null };
+					}
+				} catch (e) {}
+			}
+
+			// Try by text content in modal
+			if (modal) {
+				const buttons = modal.querySelectorAll('button');
+				for (const btn of buttons) {
+					const text = btn.innerText.trim().toLowerCase();
+					if (loc.send.some(s => text.includes(s)) || loc.connect.includes(text)) {
+						if (isDisabled(btn)) { sawDisabled = true; continue; }
+						press(btn);
+						return { clicked: true, error: null };
+					}
 				}
-			} catch (e) {}
-		}
+			}
 
-		// Try by text content in modal
-		const modal = document.querySelector('div[role="dialog"]');
-		if (modal) {
-			const buttons = modal.querySelectorAll('button');
-			for (const btn of buttons) {
-				const text = btn.innerText.toLowerCase();
-				if ((text.includes('send') || text === 'connect') && !btn.disabled) {
-					btn.click();
+			// Fallback to any send button
+			const allButtons = document.querySelectorAll('button');
+			for (const btn of allButtons) {
+				const text = btn.innerText.trim().toLowerCase();
+				if (loc.send.includes(text)) {
+					if (isDisabled(btn)) { sawDisabled = true; continue; }
+					press(btn);
 					return { clicked: true, error: null };
 				}
 			}
-		}
 
-		// Fallback to any send button
-		const allButtons = document.querySelectorAll('button');
-		for (const btn of allButtons) {
-			const text = btn.innerText.toLowerCase();
-			if (text === 'send' || text === 'send invitation' || text === 'send now') {
-				btn.click();
-				return { clicked: true, error: null };
-			}
+			return { clicked: false, error: sawDisabled ? 'disabled' : 'send_button_not_found' };
+		}`, loc, MaxNoteLength, locateOnly)
+		if err != nil {
+			return fmt.Errorf("failed to find send button: %w", err)
 		}
+		result := res.Value
 
-		return { clicked: false, error: 'send_button_not_found' };
-	}`)
+		if result.Get("clicked").Bool() {
+			break
+		}
 
-	if !result.Get("clicked").Bool() {
-		return fmt.Errorf("send button not found or disabled")
+		switch result.Get("error").Str() {
+		case "note_too_long":
+			return fmt.Errorf("send button disabled: note exceeds %d characters", MaxNoteLength)
+		case "disabled":
+			// Still validating - wait for it to enable
+			if time.Now().Before(deadline) {
+				stealth.SleepMillis(300, 500)
+				continue
+			}
+			return fmt.Errorf("send button stayed disabled for %v", sendButtonWait)
+		default:
+			return fmt.Errorf("send button not found")
+		}
 	}
 
 	stealth.SleepMillis(800, 1500)
 	return nil
-
 }
 
-// ConnectWithTracking sends a connection request and tracks it
-func ConnectWithTracking(page *rod.Page, profileURL string, personName string, note string, tracker *ConnectionTracker) error {
+// prepareRequest runs the checks every invite goes through and returns the
+// note to send, which may be left out (NoteOmissionRate) or reworded (uniqueNote)
+func (t *ConnectionTracker) prepareRequest(profileURL, note string) (string, bool, error) {
 	// Check daily limit
-	if !tracker.CanSendMore() {
-		return fmt.Errorf("daily limit reached (%d requests). Try again tomorrow", tracker.DailyLimit)
+	if !t.CanSendMore() {
+		return "", false, fmt.Errorf("daily limit reached (%d requests). Try again tomorrow", t.DailyLimit)
 	}
 
 	// Check if already sent
-	if tracker.AlreadySent(profileURL) {
-		return fmt.Errorf("connection request already sent to this profile")
+	if t.AlreadySent(profileURL) {
+		return "", false, fmt.Errorf("connection request already sent to this profile")
+	}
+
+	// Never contact blocked people/companies
+	if err := stealth.CheckBlocked(profileURL, ""); err != nil {
+		return "", false, err
+	}
+
+	if t.RequireNote && note == "" {
+		return "", false, ErrNoteRequired
+	}
+
+	// Randomly leave the note out so not every invite is personalized
+	noteOmitted := false
+	if note != "" && !t.RequireNote && t.NoteOmissionRate > 0 && stealth.Rand().Float64() < t.NoteOmissionRate {
+		fmt.Printf("🎲 Sending without a note this time (%.0f%% omission rate)\n", t.NoteOmissionRate*100)
+		note = ""
+		noteOmitted = true
+	}
+
+	// Never send the exact same text twice in a day
+	return t.uniqueNote(note), noteOmitted, nil
+}
+
+// trackRequest records an invite that went out (or would have, in dry run)
+func (t *ConnectionTracker) trackRequest(request ConnectionRequest) {
+	t.rememberNote(request.Note)
+
+	// In dry run mode, don't actually save
+	if t.DryRun {
+		fmt.Println("🧪 [DRY RUN] Would track request (not saving)")
+	} else {
+		t.AddRequest(request)
+		// Save tracker
+		if err := t.Save(); err != nil {
+			fmt.Printf("⚠️ Failed to save tracker: %v\n", err)
+		}
+	}
+
+	remaining := t.RemainingToday()
+	fmt.Printf("📊 Requests sent today: %d/%d (remaining: %d)\n",
+		t.GetTodayCount(), t.DailyLimit, remaining)
+}
+
+// ConnectWithTracking sends a connection request and tracks it
+func ConnectWithTracking(page *rod.Page, profileURL string, personName string, note string, tracker *ConnectionTracker) error {
+	note, noteOmitted, err := tracker.prepareRequest(profileURL, note)
+	if err != nil {
+		return err
 	}
 
 	// Navigate to profile
-	err := NavigateToProfile(page, profileURL)
+	err = NavigateToProfile(page, profileURL)
 	if err != nil {
 		return err
 	}
 
+	noteSkipped := false
+
 	// DRY RUN MODE - just log what would happen
-	if tracker.DryRun {
+	if tracker.DryRun && tracker.ValidateDryRun {
+		fmt.Println("🧪 [VALIDATE] Walking the invite flow without sending")
+		noteSkipped, err = sendConnectionRequest(page, note, tracker.emailFor(profileURL), true, tracker.RequireNote)
+		if err != nil {
+			return fmt.Errorf("dry-run validation failed: %w", err)
+		}
+		fmt.Println("✅ [VALIDATE] Connect, modal and note selectors all worked")
+	} else if tracker.DryRun {
 		fmt.Println("🧪 [DRY RUN] Would send connection request")
 		fmt.Printf("   📍 Profile: %s\n", profileURL)
 		fmt.Printf("   👤 Name: %s\n", personName)
 		if note != "" {
-			fmt.Printf("   📝 Note (%d chars): %s\n", len(note), note)
+			fmt.Printf("   📝 Note (%d chars): %s\n", utf8.RuneCountInString(note), note)
 		} else {
 			fmt.Println("   📝 Note: (none)")
 		}
 		fmt.Println("✅ [DRY RUN] Connection request simulated successfully!")
 	} else {
+		// Confirm-before-send mode
+		if err := stealth.RequireApproval(stealth.ActionConnection, profileURL, note); err != nil {
+			return err
+		}
+
 		// Send request (actual mode)
-		err = SendConnectionRequest(page, note)
+		noteSkipped, err = sendConnectionRequest(page, note, tracker.emailFor(profileURL), false, tracker.RequireNote)
 		if err != nil {
 			return err
 		}
@@ -431,27 +972,18 @@ func ConnectWithTracking(page *rod.Page, profileURL string, personName string, n
 
 	// Track the request
 	request := ConnectionRequest{
-		ProfileURL: profileURL,
-		Name:       personName,
-		Note:       note,
-		SentAt:     time.Now(),
-		Status:     "sent",
+		ProfileURL:  profileURL,
+		Name:        personName,
+		Note:        note,
+		SentAt:      time.Now(),
+		Status:      "sent",
+		NoteSkipped: noteSkipped,
+		NoteOmitted: noteOmitted,
 	}
-
-	// In dry run mode, don't actually save
-	if tracker.DryRun {
-		fmt.Println("🧪 [DRY RUN] Would track request (not saving)")
-	} else {
-		tracker.AddRequest(request)
-		// Save tracker
-		if err := tracker.Save(); err != nil {
-			fmt.Printf("⚠️ Failed to save tracker: %v\n", err)
-		}
+	if noteSkipped {
+		request.Note = ""
 	}
-
-	remaining := tracker.RemainingToday()
-	fmt.Printf("📊 Requests sent today: %d/%d (remaining: %d)\n",
-		tracker.GetTodayCount(), tracker.DailyLimit, remaining)
+	tracker.trackRequest(request)
 
 	return nil
 }
@@ -491,20 +1023,139 @@ func BatchConnect(page *rod.Page, profiles []string, noteTemplate string, tracke
 	return successCount, failCount, nil
 }
 
-// GeneratePersonalizedNote generates a personalized note from a template
-// Supported placeholders: {name}, {company}, {title}
-func GeneratePersonalizedNote(template string, name string, company string, title string) string {
+// NoteFields are the values filled into a connection note template
+type NoteFields struct {
+	Name       string // {name}
+	Company    string // {company}
+	Title      string // {title}
+	RecentPost string // {recent_post}
+
+	// CompanyInfo is the company search result for {company_industry} and
+	// {company_location} (nil when the company was never searched)
+	CompanyInfo *persistence.CompanySearchResult
+}
+
+// GeneratePersonalizedNote fills the placeholders of a note template with fields
+// The template should already be the one for the target's language (see NoteTemplateFor).
+// {recent_post} is shortened to fit the note limit. A sentence whose placeholder
+// has no value (no recent post, unknown name, title or company details) is dropped
+func GeneratePersonalizedNote(template string, fields NoteFields) string {
 	note := template
-	note = strings.ReplaceAll(note, "{name}", name)
-	note = strings.ReplaceAll(note, "{company}", company)
-	note = strings.ReplaceAll(note, "{title}", title)
 
-	// Truncate if needed
-	if len(note) > MaxNoteLength {
-		note = note[:MaxNoteLength-3] + "..."
+	values := companyPlaceholders(fields.CompanyInfo)
+	values["{name}"] = strings.TrimSpace(fields.Name)
+	values["{company}"] = strings.TrimSpace(fields.Company)
+	values["{title}"] = strings.TrimSpace(fields.Title)
+	for placeholder, value := range values {
+		if value != "" {
+			note = strings.ReplaceAll(note, placeholder, value)
+			continue
+		}
+		for strings.Contains(note, placeholder) {
+			note = dropSentence(note, placeholder)
+		}
+	}
+
+	if strings.Contains(note, RecentPostPlaceholder) {
+		if fields.RecentPost == "" {
+			note = dropSentence(note, RecentPostPlaceholder)
+		} else {
+			room := MaxNoteLength - utf8.RuneCountInString(strings.ReplaceAll(note, RecentPostPlaceholder, ""))
+			note = strings.ReplaceAll(note, RecentPostPlaceholder, shortenSnippet(fields.RecentPost, room))
+		}
 	}
 
-	return note
+	return truncateNote(note)
+}
+
+// RecentPostPlaceholder is replaced with a snippet of the member's latest post
+const RecentPostPlaceholder = "{recent_post}"
+
+// LeadPlaceholders are filled from the member's search result or profile card
+var LeadPlaceholders = []string{"{name}", "{company}", "{title}"}
+
+// CompanyPlaceholders are filled from the company search result of the member's company
+var CompanyPlaceholders = []string{"{company_industry}", "{company_location}"}
+
+// companyPlaceholders maps each company placeholder to its value ("" when unknown)
+func companyPlaceholders(info *persistence.CompanySearchResult) map[string]string {
+	values := make(map[string]string, len(CompanyPlaceholders))
+	for _, p := range CompanyPlaceholders {
+		values[p] = ""
+	}
+	if info != nil {
+		values["{company_industry}"] = strings.TrimSpace(info.Industry)
+		values["{company_location}"] = strings.TrimSpace(info.Location)
+	}
+	return values
+}
+
+// maxPostSnippet keeps a quoted post short even when the note has room to spare
+const maxPostSnippet = 80
+
+// shortenSnippet cuts a post snippet at a word boundary to fit in room characters
+func shortenSnippet(snippet string, room int) string {
+	if room > maxPostSnippet {
+		room = maxPostSnippet
+	}
+	snippet = strings.Join(strings.Fields(snippet), " ")
+	runes := []rune(snippet)
+	if len(runes) <= room {
+		return snippet
+	}
+	if room <= 3 {
+		return ""
+	}
+	cut := string(runes[:room-3])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "..."
+}
+
+// dropSentence removes the sentence containing placeholder from text
+func dropSentence(text, placeholder string) string {
+	i := strings.Index(text, placeholder)
+	if i < 0 {
+		return text
+	}
+
+	start := strings.LastIndexAny(text[:i], ".!?\n") + 1
+	end := len(text)
+	if j := strings.IndexAny(text[i:], ".!?\n"); j >= 0 {
+		end = i + j + 1
+	}
+
+	before := strings.TrimRight(text[:start], " ")
+	after := strings.TrimLeft(text[end:], " ")
+	if before != "" && after != "" && !strings.HasSuffix(before, "\n") {
+		before += " "
+	}
+	return strings.TrimSpace(before + after)
+}
+
+// truncateNote cuts a note to MaxNoteLength characters, ending in "..."
+// LinkedIn counts characters, not bytes, so accents and emoji count as one
+// and are never split mid-character. With TruncateNoteAtWord the cut backs up
+// to the last space; a note that is one long word is still cut hard
+func truncateNote(note string) string {
+	runes := []rune(note)
+	if len(runes) <= MaxNoteLength {
+		return note
+	}
+
+	cut := runes[:MaxNoteLength-3]
+	if TruncateNoteAtWord && !unicode.IsSpace(runes[len(cut)]) {
+		for i := len(cut) - 1; i > 0; i-- {
+			if unicode.IsSpace(cut[i]) {
+				cut = cut[:i]
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",.;:-", r)
+	}) + "..."
 }
 
 // SetDailyLimit updates the daily limit
@@ -514,6 +1165,35 @@ func (t *ConnectionTracker) SetDailyLimit(limit int) {
 	}
 }
 
+// SetNoteOmissionRate sets the fraction (0-1) of requests sent without a note
+func (t *ConnectionTracker) SetNoteOmissionRate(rate float64) {
+	if rate < 0 {
+		rate = 0
+	}
+	if rate > 1 {
+		rate = 1
+	}
+	t.NoteOmissionRate = rate
+}
+
+// SetEmailLookup sets how a lead's email is found for members who ask for it
+func (t *ConnectionTracker) SetEmailLookup(lookup func(profileURL string) string) {
+	t.EmailLookup = lookup
+}
+
+// emailFor returns the stored email for a profile, or ""
+func (t *ConnectionTracker) emailFor(profileURL string) string {
+	if t.EmailLookup == nil {
+		return ""
+	}
+	return t.EmailLookup(profileURL)
+}
+
+// SetRequireNote makes invites that can't carry their note get cancelled instead of sent without it
+func (t *ConnectionTracker) SetRequireNote(enabled bool) {
+	t.RequireNote = enabled
+}
+
 // SetDryRun enables or disables dry run mode
 func (t *ConnectionTracker) SetDryRun(enabled bool) {
 	t.DryRun = enabled
@@ -522,6 +1202,14 @@ func (t *ConnectionTracker) SetDryRun(enabled bool) {
 	}
 }
 
+// SetValidateDryRun makes dry runs exercise the real UI up to the Send click
+func (t *ConnectionTracker) SetValidateDryRun(enabled bool) {
+	t.ValidateDryRun = enabled
+	if enabled && t.DryRun {
+		fmt.Println("🧪 Dry run will validate selectors (stops before Send)")
+	}
+}
+
 // GetStats returns connection statistics
 func (t *ConnectionTracker) GetStats() map[string]int {
 	stats := map[string]int{
@@ -534,6 +1222,15 @@ func (t *ConnectionTracker) GetStats() map[string]int {
 	// Count by status
 	for _, req := range t.Requests {
 		stats[req.Status]++
+		if req.NoteSkipped {
+			stats["notes_skipped"]++
+		}
+		if req.NoteOmitted {
+			stats["notes_omitted"]++
+		}
+		if req.Note == "" {
+			stats["without_note"]++
+		}
 	}
 
 	return stats
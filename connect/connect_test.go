@@ -0,0 +1,150 @@
+package connect
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+)
+
+func TestTruncateNoteAtWord(t *testing.T) {
+	filler := strings.Repeat("word ", 59) // 295 characters
+
+	tests := []struct {
+		name string
+		note string
+	}{
+		{"cut mid-word", filler + "networking opportunities"},
+		{"cut on a space", filler + "ab cdefgh and more"},
+		{"cut after punctuation", filler[:290] + "done, and then some more words"},
+	}
+
+	for _, tt := range tests {
+		got := truncateNote(tt.note)
+		if n := utf8.RuneCountInString(got); n > MaxNoteLength {
+			t.Errorf("%s: got %d characters, want at most %d", tt.name, n, MaxNoteLength)
+		}
+		if !strings.HasSuffix(got, "...") {
+			t.Errorf("%s: %q does not end in \"...\"", tt.name, got)
+			continue
+		}
+
+		kept := strings.TrimSuffix(got, "...")
+		if !strings.HasPrefix(tt.note, kept) {
+			t.Errorf("%s: %q is not a prefix of the note", tt.name, kept)
+			continue
+		}
+		// The character after the kept text must end a word, so no partial word is left
+		if next, _ := utf8.DecodeRuneInString(tt.note[len(kept):]); unicode.IsLetter(next) {
+			t.Errorf("%s: %q ends in a partial word", tt.name, kept)
+		}
+	}
+}
+
+func TestTruncateNoteOneLongWord(t *testing.T) {
+	note := strings.Repeat("a", 400)
+	got := truncateNote(note)
+	if want := strings.Repeat("a", MaxNoteLength-3) + "..."; got != want {
+		t.Errorf("got %d characters, want a hard cut to %d", utf8.RuneCountInString(got), MaxNoteLength)
+	}
+}
+
+func TestTruncateNoteShort(t *testing.T) {
+	note := "Hi Jane, I'd love to connect."
+	if got := truncateNote(note); got != note {
+		t.Errorf("truncateNote(%q) = %q, want it unchanged", note, got)
+	}
+}
+
+func TestTruncateNoteAccented(t *testing.T) {
+	defer func(v bool) { TruncateNoteAtWord = v }(TruncateNoteAtWord)
+
+	for _, atWord := range []bool{true, false} {
+		TruncateNoteAtWord = atWord
+		for _, note := range []string{
+			strings.Repeat("é", 350),
+			strings.Repeat("Ça été très agréable, señor Müller ", 10),
+		} {
+			got := truncateNote(note)
+			if !utf8.ValidString(got) {
+				t.Errorf("atWord=%v: truncated note is not valid UTF-8: %q", atWord, got)
+			}
+			n := utf8.RuneCountInString(got)
+			if (!atWord || !strings.Contains(note, " ")) && n != MaxNoteLength {
+				t.Errorf("atWord=%v: got %d characters, want %d", atWord, n, MaxNoteLength)
+			}
+			if n > MaxNoteLength {
+				t.Errorf("atWord=%v: got %d characters, want at most %d", atWord, n, MaxNoteLength)
+			}
+		}
+	}
+}
+
+func TestCompanyFromHeadline(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Software Engineer at Acme", "Acme"},
+		{"Founder @ Globex | Speaker", "Globex"},
+		{" Engineer at Initech, ex-Hooli", "Initech"},
+		{"Head of Data at Umbrella Corp • Hiring", "Umbrella Corp"},
+		{"Data Scientist", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := CompanyFromHeadline(tt.in); got != tt.want {
+			t.Errorf("CompanyFromHeadline(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGeneratePersonalizedNote(t *testing.T) {
+	acme := &persistence.CompanySearchResult{Name: "Acme", Industry: "Software Development", Location: "Berlin"}
+	tests := []struct {
+		name     string
+		template string
+		fields   NoteFields
+		want     string
+	}{
+		{
+			name:     "all known",
+			template: "Hi {name}! Fellow {title} here. Acme in {company_industry}, {company_location}?",
+			fields:   NoteFields{Name: "Ada", Company: "Acme", Title: "Engineer", CompanyInfo: acme},
+			want:     "Hi Ada! Fellow Engineer here. Acme in Software Development, Berlin?",
+		},
+		{
+			name:     "unknown name drops its sentence",
+			template: "Hi {name}! I saw you work at {company}.",
+			fields:   NoteFields{Company: "Acme"},
+			want:     "I saw you work at Acme.",
+		},
+		{
+			name:     "unknown company details drop their sentence",
+			template: "Hi {name}! How is {company_industry} treating you? Let's connect.",
+			fields:   NoteFields{Name: "Ada"},
+			want:     "Hi Ada! Let's connect.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GeneratePersonalizedNote(tt.template, tt.fields)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTitleFromHeadline(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Senior Engineer at Acme | Speaker", "Senior Engineer"},
+		{"Founder @ Globex", "Founder"},
+		{"Data Scientist • ML", "Data Scientist"},
+		{"Data Scientist", "Data Scientist"},
+	}
+	for _, tt := range tests {
+		if got := TitleFromHeadline(tt.in); got != tt.want {
+			t.Errorf("TitleFromHeadline(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
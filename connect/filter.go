@@ -0,0 +1,96 @@
+package connect
+
+import (
+	"fmt"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// FilterMode says how a profile trait affects whether we connect
+type FilterMode string
+
+const (
+	FilterAny     FilterMode = ""        // Ignore the trait
+	FilterRequire FilterMode = "require" // Only connect when the profile has it
+	FilterExclude FilterMode = "exclude" // Skip profiles that have it
+)
+
+// ProfileFilter decides from a profile's top card whether to connect
+// e.g. a recruiter requires OpenToWork; a networker requires Photo to skip
+// photo-less accounts, which are often fake
+type ProfileFilter struct {
+	Photo      FilterMode
+	OpenToWork FilterMode
+}
+
+// Active reports whether any filter option is set
+func (f ProfileFilter) Active() bool {
+	return f.Photo != FilterAny || f.OpenToWork != FilterAny
+}
+
+// ProfileTraits are the profile details ProfileFilter looks at
+type ProfileTraits struct {
+	HasPhoto   bool
+	OpenToWork bool
+}
+
+// Check returns why a profile with these traits should be skipped, or "" to connect
+func (f ProfileFilter) Check(t ProfileTraits) string {
+	if reason := checkTrait(f.Photo, t.HasPhoto, "has no profile photo", "has a profile photo"); reason != "" {
+		return reason
+	}
+	return checkTrait(f.OpenToWork, t.OpenToWork, "is not open to work", "is open to work")
+}
+
+// checkTrait applies one filter mode to one trait
+func checkTrait(mode FilterMode, has bool, missing, present string) string {
+	switch {
+	case mode == FilterRequire && !has:
+		return missing
+	case mode == FilterExclude && has:
+		return present
+	}
+	return ""
+}
+
+// ReadProfileTraits reads the photo and Open to Work badge from the top card
+// of the profile the page is on
+func ReadProfileTraits(page *rod.Page) (ProfileTraits, error) {
+	page = page.Timeout(stealth.GetEvalTimeout())
+	defer page.CancelTimeout()
+
+	res, err := page.Eval(`() => {
+		const main = document.querySelector('main') || document;
+		const photo = main.querySelector([
+			'img.pv-top-card-profile-picture__image',
+			'img.pv-top-card-profile-picture__image--show',
+			'.pv-top-card__photo img',
+			'img.profile-photo-edit__preview',
+		].join(', '));
+
+		// Members without a photo get LinkedIn's grey "ghost" placeholder
+		const src = photo ? (photo.getAttribute('src') || '') : '';
+		const ghost = !photo || !src || src.startsWith('data:') ||
+			/ghost/i.test(src) || /ghost/i.test(photo.className);
+
+		// The green frame's alt text carries #OPEN_TO_WORK
+		const alt = photo ? (photo.getAttribute('alt') || '') : '';
+		const openToWork = /#OPEN_TO_WORK/i.test(alt) ||
+			!!main.querySelector('.pv-open-to-carousel, [data-view-name="profile-open-to-work"]');
+
+		return { found: !!main.querySelector('h1'), hasPhoto: !ghost, openToWork };
+	}`)
+	if err != nil {
+		return ProfileTraits{}, fmt.Errorf("failed to read profile traits: %w", err)
+	}
+	if !res.Value.Get("found").Bool() {
+		return ProfileTraits{}, fmt.Errorf("profile top card not found")
+	}
+
+	return ProfileTraits{
+		HasPhoto:   res.Value.Get("hasPhoto").Bool(),
+		OpenToWork: res.Value.Get("openToWork").Bool(),
+	}, nil
+}
@@ -0,0 +1,110 @@
+package connect
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-rod/rod"
+)
+
+// DefaultNoteLanguage is used whenever a profile's language can't be told apart
+const DefaultNoteLanguage = "en"
+
+// NoteTemplatesByLanguage holds localized connection notes keyed by language
+// code ("de", "fr", ...). Languages without an entry get the default template
+var NoteTemplatesByLanguage = map[string]string{}
+
+// NoteTemplateFor returns the note template for language, falling back to template
+func NoteTemplateFor(template, language string) string {
+	if localized, ok := NoteTemplatesByLanguage[language]; ok && localized != "" {
+		return localized
+	}
+	return template
+}
+
+// locationLanguages maps countries and big cities (in English and their own
+// language, since LinkedIn shows locations in the viewer's UI language) to the
+// language most people there write in. Multilingual places are left out
+var locationLanguages = map[string][]string{
+	"de": {"germany", "deutschland", "austria", "österreich", "berlin", "munich", "münchen", "hamburg",
+		"frankfurt", "cologne", "köln", "stuttgart", "düsseldorf", "vienna", "wien", "zurich", "zürich"},
+	"fr": {"france", "paris", "lyon", "marseille", "toulouse", "bordeaux", "lille", "nantes", "québec", "quebec"},
+	"es": {"spain", "españa", "madrid", "barcelona", "valencia", "sevilla", "seville", "mexico", "méxico",
+		"argentina", "colombia", "chile", "peru", "perú", "bogotá", "bogota", "buenos aires", "lima"},
+	"pt": {"portugal", "brazil", "brasil", "lisbon", "lisboa", "são paulo", "sao paulo", "rio de janeiro"},
+	"it": {"italy", "italia", "milan", "milano", "rome", "roma", "turin", "torino"},
+	"nl": {"netherlands", "nederland", "amsterdam", "rotterdam", "utrecht", "eindhoven"},
+}
+
+// headlineLanguages lists words that give a headline's language away -
+// mostly job titles and the "at <company>" preposition
+var headlineLanguages = map[string][]string{
+	"en": {"at", "and", "engineer", "developer", "manager", "founder", "consultant", "head", "lead", "student"},
+	"de": {"bei", "und", "für", "entwickler", "softwareentwickler", "geschäftsführer", "berater", "leiter",
+		"gründer", "mitarbeiter", "werkstudent", "inhaber"},
+	"fr": {"chez", "et", "pour", "développeur", "développeuse", "ingénieur", "ingénieure", "directeur",
+		"directrice", "fondateur", "fondatrice", "stagiaire", "chef"},
+	"es": {"desarrollador", "desarrolladora", "ingeniero", "ingeniera", "jefe", "jefa", "fundadora",
+		"gerente", "analista", "para"},
+	"pt": {"desenvolvedor", "desenvolvedora", "engenheiro", "engenheira", "fundadora", "gerente", "analista"},
+	"it": {"presso", "sviluppatore", "sviluppatrice", "ingegnere", "responsabile", "fondatore", "direttore"},
+	"nl": {"bij", "ontwikkelaar", "medewerker", "oprichter", "adviseur", "eigenaar"},
+}
+
+// wordPattern splits text into words for keyword matching
+var wordPattern = regexp.MustCompile(`\p{L}+`)
+
+// normalizeWords lowercases text and joins its words with single spaces,
+// padded so " word " matches whole words and phrases only
+func normalizeWords(text string) string {
+	return " " + strings.Join(wordPattern.FindAllString(strings.ToLower(text), -1), " ") + " "
+}
+
+// DetectProfileLanguage guesses the language a member writes in from their
+// location and headline. A matching location counts double; every telltale
+// headline word counts once (English words count against the location, so
+// "Software Engineer at X" in Berlin stays English). Only a clear winner with
+// at least two points is returned - anything else is DefaultNoteLanguage
+func DetectProfileLanguage(location, headline string) string {
+	scores := map[string]int{}
+
+	loc := normalizeWords(location)
+	for lang, places := range locationLanguages {
+		for _, place := range places {
+			if strings.Contains(loc, " "+place+" ") {
+				scores[lang] += 2
+				break
+			}
+		}
+	}
+
+	head := normalizeWords(headline)
+	for lang, words := range headlineLanguages {
+		for _, word := range words {
+			if strings.Contains(head, " "+word+" ") {
+				scores[lang]++
+			}
+		}
+	}
+
+	best, bestScore, tied := DefaultNoteLanguage, 0, false
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = lang, score, false
+		case score == bestScore:
+			tied = true
+		}
+	}
+	if bestScore < 2 || tied {
+		return DefaultNoteLanguage
+	}
+	return best
+}
+
+// ReadProfileHints reads the headline and location from the profile's top card
+// Missing fields come back empty, which DetectProfileLanguage treats as uncertain
+func ReadProfileHints(page *rod.Page) (headline, location string) {
+	card, _ := ReadProfileCard(page)
+	return card.Headline, card.Location
+}
@@ -0,0 +1,120 @@
+package connect
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// Identical note text on several invites in a day lets LinkedIn cluster them
+// as one campaign. Templates render identically whenever the personal fields
+// are empty, so a repeat is reworded slightly before it goes out
+var (
+	noteGreeting  = regexp.MustCompile(`(?i)^(hi there|hello|hey|hi)\b`)
+	noteGreetings = []string{"Hi", "Hello", "Hey", "Hi there"}
+	noteClosings  = []string{"Thanks!", "Best regards.", "Cheers!", "All the best."}
+)
+
+// noteHash identifies a note's text, ignoring case and spacing
+func noteHash(note string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(note), " "))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:8])
+}
+
+// noteSentToday reports whether the same note text went out today or
+// earlier in this run (dry-run invites aren't saved, so the run is tracked too)
+func (t *ConnectionTracker) noteSentToday(note string) bool {
+	hash := noteHash(note)
+	if t.notesSent[hash] {
+		return true
+	}
+
+	// Local midnight - Truncate would cut at UTC midnight
+	y, m, d := time.Now().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	for _, req := range t.Requests {
+		if req.Note != "" && req.SentAt.After(today) && noteHash(req.Note) == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberNote records a note sent in this run
+func (t *ConnectionTracker) rememberNote(note string) {
+	if note == "" {
+		return
+	}
+	if t.notesSent == nil {
+		t.notesSent = make(map[string]bool)
+	}
+	t.notesSent[noteHash(note)] = true
+}
+
+// uniqueNote returns note unchanged unless the same text was already sent
+// today, in which case it returns a lightly reworded variant (another
+// greeting, closing or final punctuation) that hasn't been used yet
+func (t *ConnectionTracker) uniqueNote(note string) string {
+	if note == "" || !t.noteSentToday(note) {
+		return note
+	}
+
+	for _, variant := range noteVariants(note) {
+		if utf8.RuneCountInString(variant) <= MaxNoteLength && !t.noteSentToday(variant) {
+			fmt.Println("🔀 Same note already sent today - varying the wording")
+			return variant
+		}
+	}
+
+	fmt.Println("⚠️ Same note already sent today and no unused wording left - sending it as is")
+	return note
+}
+
+// noteVariants returns reworded versions of note in random order
+func noteVariants(note string) []string {
+	greetings := []string{note}
+	if m := noteGreeting.FindString(note); m != "" {
+		for _, g := range noteGreetings {
+			if !strings.EqualFold(g, m) {
+				greetings = append(greetings, g+note[len(m):])
+			}
+		}
+	}
+
+	var variants []string
+	for _, g := range greetings {
+		if g != note {
+			variants = append(variants, g)
+		}
+		if swapped := swapFinalPunctuation(g); swapped != g {
+			variants = append(variants, swapped)
+		}
+	}
+	for _, closing := range noteClosings {
+		variants = append(variants, strings.TrimSpace(note)+" "+closing)
+	}
+
+	stealth.Rand().Shuffle(len(variants), func(i, j int) {
+		variants[i], variants[j] = variants[j], variants[i]
+	})
+	return variants
+}
+
+// swapFinalPunctuation turns a closing "!" into "." and vice versa
+func swapFinalPunctuation(note string) string {
+	trimmed := strings.TrimSpace(note)
+	switch {
+	case strings.HasSuffix(trimmed, "!"):
+		return strings.TrimSuffix(trimmed, "!") + "."
+	case strings.HasSuffix(trimmed, "."):
+		return strings.TrimSuffix(trimmed, ".") + "!"
+	}
+	return note
+}
@@ -0,0 +1,109 @@
+package connect
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// ProfileCard is what the top card of a profile shows
+type ProfileCard struct {
+	Name     string
+	Headline string
+	Location string
+	Company  string // Current company; from the headline when the card has no company button
+}
+
+// headlineCompany matches "Engineer at Acme" / "Founder @ Acme | Speaker"
+var headlineCompany = regexp.MustCompile(`(?i)(?:\bat|@)\s+([^|•·,]+)`)
+
+// CompanyFromHeadline returns the company named in a headline or a search
+// card's "Current: ..." line, or ""
+func CompanyFromHeadline(headline string) string {
+	m := headlineCompany.FindStringSubmatch(headline)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(m[1])
+}
+
+// TitleFromHeadline returns the job title part of a headline: "Senior Engineer"
+// for "Senior Engineer at Acme | Speaker"
+func TitleFromHeadline(headline string) string {
+	if loc := headlineCompany.FindStringIndex(headline); loc != nil {
+		headline = headline[:loc[0]]
+	}
+	if i := strings.IndexAny(headline, "|•·,"); i >= 0 {
+		headline = headline[:i]
+	}
+	return strings.TrimSpace(headline)
+}
+
+// ReadProfileCard reads the name, headline, location and current company from
+// the top card of the profile the page is on
+func ReadProfileCard(page *rod.Page) (ProfileCard, error) {
+	page = page.Timeout(stealth.GetEvalTimeout())
+	defer page.CancelTimeout()
+
+	res, err := page.Eval(`() => {
+		const main = document.querySelector('main') || document;
+		const pick = (selectors) => {
+			for (const selector of selectors) {
+				const el = main.querySelector(selector);
+				if (el && el.innerText.trim()) return el.innerText.trim();
+			}
+			return '';
+		};
+
+		// "Current company: Acme. Click to skip to experience card"
+		let company = '';
+		const button = main.querySelector('button[aria-label^="Current company"]');
+		if (button) {
+			company = (button.getAttribute('aria-label') || '')
+				.replace(/^Current company:\s*/i, '')
+				.replace(/\.\s*Click.*$/i, '')
+				.trim();
+		}
+		if (!company) {
+			company = pick([
+				'.pv-text-details__right-panel-item-text',
+				'ul.pv-text-details__right-panel li:first-child',
+			]);
+		}
+
+		return {
+			found: !!main.querySelector('h1'),
+			name: pick(['h1']),
+			headline: pick([
+				'.pv-text-details__left-panel .text-body-medium',
+				'div.text-body-medium.break-words',
+			]),
+			location: pick([
+				'.pv-text-details__left-panel .text-body-small.inline',
+				'span.text-body-small.inline.t-black--light.break-words',
+			]),
+			company,
+		};
+	}`)
+	if err != nil {
+		return ProfileCard{}, fmt.Errorf("failed to read profile card: %w", err)
+	}
+	if !res.Value.Get("found").Bool() {
+		return ProfileCard{}, fmt.Errorf("profile top card not found")
+	}
+
+	card := ProfileCard{
+		Name:     res.Value.Get("name").Str(),
+		Headline: res.Value.Get("headline").Str(),
+		Location: res.Value.Get("location").Str(),
+		Company:  res.Value.Get("company").Str(),
+	}
+	if card.Company == "" {
+		card.Company = CompanyFromHeadline(card.Headline)
+	}
+	return card, nil
+}
@@ -0,0 +1,211 @@
+package connect
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+const (
+	// SentInvitationsURL is LinkedIn's manager for outgoing invitations
+	SentInvitationsURL = "https://www.linkedin.com/mynetwork/invitation-manager/sent/"
+
+	// MaxInvitationPages caps how far the sent-invitations manager is paged
+	MaxInvitationPages = 20
+
+	// MaxProfileChecks caps how many profiles are opened per reconcile run
+	// to resolve requests that dropped off the invitation manager
+	MaxProfileChecks = 15
+)
+
+// ReconcileResult summarizes a ReconcilePendingRequests run
+type ReconcileResult struct {
+	StillPending int
+	Accepted     int
+	Withdrawn    int
+	Unresolved   int
+}
+
+// ReconcilePendingRequests re-checks requests stored as pending against LinkedIn's
+// sent-invitations manager. Invites still listed stay pending; the rest are marked
+// accepted when the member is now a connection, and withdrawn when the profile
+// offers a plain Connect button again (LinkedIn keeps ignored invites listed as
+// pending, so one that dropped off was withdrawn or expired).
+// Requests that can't be resolved (profile checks capped or inconclusive) are left pending.
+func ReconcilePendingRequests(page *rod.Page, store *persistence.Store) (*ReconcileResult, error) {
+	pending, err := store.GetPendingRequests()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pending requests: %w", err)
+	}
+
+	result := &ReconcileResult{}
+	if len(pending) == 0 {
+		fmt.Println("ℹ️ No pending connection requests to reconcile")
+		return result, nil
+	}
+
+	fmt.Printf("🔄 Reconciling %d pending connection requests...\n", len(pending))
+
+	stillSent, err := collectSentInvitations(page)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("📨 %d invitations still pending on LinkedIn\n", len(stillSent))
+
+	checks := 0
+	for _, req := range pending {
+		if stillSent[linkedinurl.Canonicalize(req.ProfileURL)] {
+			result.StillPending++
+			continue
+		}
+
+		// Already synced as a connection - no need to open the profile
+		if conn, _ := store.GetConnection(req.ProfileURL); conn != nil {
+			store.UpdateRequestStatus(req.ProfileURL, persistence.StatusAccepted)
+			result.Accepted++
+			continue
+		}
+
+		if checks >= MaxProfileChecks {
+			result.Unresolved++
+			continue
+		}
+		checks++
+
+		status, err := profileConnectionStatus(page, req.ProfileURL)
+		if err != nil {
+			fmt.Printf("⚠️ Could not check %s: %v\n", req.ProfileURL, err)
+			if stealth.IsCritical(err) {
+				return result, err
+			}
+			result.Unresolved++
+			continue
+		}
+
+		switch status {
+		case persistence.StatusAccepted:
+			store.UpdateRequestStatus(req.ProfileURL, persistence.StatusAccepted)
+			result.Accepted++
+		case persistence.StatusWithdrawn:
+			store.UpdateRequestStatus(req.ProfileURL, persistence.StatusWithdrawn)
+			store.SetConnectionState(req.ProfileURL, "") // the card no longer shows Pending
+			result.Withdrawn++
+		default:
+			result.Unresolved++
+		}
+
+		stealth.Sleep(3, 7)
+	}
+
+	fmt.Printf("✅ Reconciled: %d still pending, %d accepted, %d withdrawn, %d unresolved\n",
+		result.StillPending, result.Accepted, result.Withdrawn, result.Unresolved)
+	return result, nil
+}
+
+// collectSentInvitations pages through the sent-invitations manager and
+// returns the normalized profile URLs of every invitation still listed
+func collectSentInvitations(page *rod.Page) (map[string]bool, error) {
+	sent := make(map[string]bool)
+
+	for pageNum := 1; pageNum <= MaxInvitationPages; pageNum++ {
+		url := SentInvitationsURL
+		if pageNum > 1 {
+			url = fmt.Sprintf("%s?page=%d", SentInvitationsURL, pageNum)
+		}
+
+		timeoutPage := page.Timeout(stealth.GetNavigationTimeout())
+		err := timeoutPage.Navigate(url)
+		if err == nil {
+			err = timeoutPage.WaitStable(time.Second)
+		}
+		timeoutPage.CancelTimeout()
+		if err != nil && pageNum == 1 {
+			return nil, fmt.Errorf("failed to open sent invitations: %w", err)
+		}
+
+		stealth.Sleep(2, 4)
+
+		if result := stealth.CheckPage(page); result.HasError {
+			stealth.PrintDetectionStatus(result)
+			return nil, result.Error
+		}
+
+		stealth.ScrollToBottom(page, 5)
+
+		res, err := page.Eval(`() => {
+			const cards = document.querySelectorAll(
+				'li.invitation-card, [class*="invitation-card"], .mn-invitation-list li, main ul li'
+			);
+			const urls = new Set();
+			for (const card of cards) {
+				const link = card.querySelector('a[href*="/in/"]');
+				if (link) urls.add(link.href.split('?')[0]);
+			}
+			return Array.from(urls);
+		}`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sent invitations: %w", err)
+		}
+
+		newOnPage := 0
+		for _, v := range res.Value.Arr() {
+			key := linkedinurl.Canonicalize(v.Str())
+			if !sent[key] {
+				sent[key] = true
+				newOnPage++
+			}
+		}
+
+		fmt.Printf("   Page %d: %d invitations\n", pageNum, newOnPage)
+
+		// An empty page (or one repeating the last) means we ran past the end
+		if newOnPage == 0 {
+			break
+		}
+	}
+
+	return sent, nil
+}
+
+// profileConnectionStatus opens a profile and reports whether the member is now
+// a 1st-degree connection (accepted) or can be invited again (withdrawn).
+// Returns StatusPending when the profile still shows a pending invite or is inconclusive.
+func profileConnectionStatus(page *rod.Page, profileURL string) (string, error) {
+	if err := NavigateToProfile(page, profileURL); err != nil {
+		return "", err
+	}
+
+	loc := stealth.DetectLocale(page)
+
+	res, err := page.Eval(`(loc) => {
+		const main = document.querySelector('main') || document.body;
+		const degree = main.querySelector('.dist-value, [class*="distance-badge"]');
+		if (degree && degree.innerText.trim().startsWith('1')) return 'accepted';
+
+		for (const btn of main.querySelectorAll('button')) {
+			const text = btn.innerText.trim().toLowerCase();
+			if (loc.pending.includes(text)) return 'pending';
+		}
+		for (const btn of main.querySelectorAll('button')) {
+			const text = btn.innerText.trim().toLowerCase();
+			if (loc.connect.includes(text)) return 'withdrawn';
+		}
+		return 'pending';
+	}`, loc)
+	if err != nil {
+		return "", fmt.Errorf("failed to read connection state: %w", err)
+	}
+
+	switch res.Value.Str() {
+	case "accepted":
+		return persistence.StatusAccepted, nil
+	case "withdrawn":
+		return persistence.StatusWithdrawn, nil
+	}
+	return persistence.StatusPending, nil
+}
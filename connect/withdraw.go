@@ -0,0 +1,176 @@
+package connect
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// AutoWithdrawPolicy withdraws old, still-pending invites at the start of a run.
+// A large pile of unanswered invites counts against the account, so the oldest
+// ones are cleared to keep the pending count under PendingThreshold.
+type AutoWithdrawPolicy struct {
+	// EnabledAfterDays is the minimum age of an invite before it may be withdrawn (0 = disabled)
+	EnabledAfterDays int
+
+	// MaxPerRun caps how many invites are withdrawn per run
+	MaxPerRun int
+
+	// PendingThreshold is the pending count to stay under (0 = withdraw every eligible invite)
+	PendingThreshold int
+}
+
+// Enabled reports whether the policy withdraws anything at all
+func (p AutoWithdrawPolicy) Enabled() bool {
+	return p.EnabledAfterDays > 0 && p.MaxPerRun > 0
+}
+
+// Apply withdraws the oldest pending requests allowed by the policy and returns how many
+// were withdrawn. Withdrawals go through the rate limiter and respect tracker.DryRun.
+// Withdrawn profiles can be invited again after persistence.WithdrawResendCooldown.
+func (p AutoWithdrawPolicy) Apply(page *rod.Page, store *persistence.Store, tracker *ConnectionTracker) (int, error) {
+	if !p.Enabled() {
+		return 0, nil
+	}
+
+	pending, err := store.GetPendingRequests()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load pending requests: %w", err)
+	}
+
+	toWithdraw := len(pending)
+	if p.PendingThreshold > 0 {
+		toWithdraw = len(pending) - p.PendingThreshold
+	}
+	if toWithdraw > p.MaxPerRun {
+		toWithdraw = p.MaxPerRun
+	}
+	if toWithdraw <= 0 {
+		return 0, nil
+	}
+
+	// Pending requests come newest first; the oldest are at the end
+	cutoff := time.Now().AddDate(0, 0, -p.EnabledAfterDays)
+	var candidates []persistence.ConnectionRequest
+	for i := len(pending) - 1; i >= 0 && len(candidates) < toWithdraw; i-- {
+		if pending[i].SentAt.Before(cutoff) {
+			candidates = append(candidates, pending[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	fmt.Printf("🧹 Auto-withdraw: %d pending invites, withdrawing %d older than %d days\n",
+		len(pending), len(candidates), p.EnabledAfterDays)
+
+	rateLimiter := stealth.GetRateLimiter()
+	withdrawn := 0
+	for _, req := range candidates {
+		age := int(time.Since(req.SentAt).Hours() / 24)
+
+		if tracker.DryRun {
+			fmt.Printf("🧪 [DRY RUN] Would withdraw invite to %s (%d days old)\n", req.ProfileURL, age)
+			withdrawn++
+			continue
+		}
+
+		if can, reason := rateLimiter.CanPerform(stealth.ActionWithdraw); !can {
+			fmt.Printf("⏸️ Withdrawals rate limited: %s - stopping\n", reason)
+			break
+		}
+
+		err := WithdrawRequest(page, req.ProfileURL)
+		if err != nil {
+			fmt.Printf("⚠️ Could not withdraw %s: %v\n", req.ProfileURL, err)
+			if stealth.IsCritical(err) {
+				return withdrawn, err
+			}
+			continue
+		}
+
+		rateLimiter.RecordAction(stealth.ActionWithdraw)
+		store.UpdateRequestStatus(req.ProfileURL, persistence.StatusWithdrawn)
+		store.SetConnectionState(req.ProfileURL, "") // the card no longer shows Pending
+		tracker.MarkWithdrawn(req.ProfileURL)
+		withdrawn++
+		fmt.Printf("↩️ Withdrew invite to %s (%d days old)\n", req.ProfileURL, age)
+
+		stealth.Sleep(3, 7)
+	}
+
+	if !tracker.DryRun && withdrawn > 0 {
+		if err := tracker.Save(); err != nil {
+			fmt.Printf("⚠️ Failed to save tracker: %v\n", err)
+		}
+	}
+
+	fmt.Printf("✅ Auto-withdraw done: %d withdrawn\n", withdrawn)
+	return withdrawn, nil
+}
+
+// WithdrawRequest opens a profile, clicks its Pending button and confirms the withdrawal
+func WithdrawRequest(page *rod.Page, profileURL string) error {
+	if err := stealth.BlockWrite("withdraw", profileURL); err != nil {
+		return err
+	}
+
+	if err := NavigateToProfile(page, profileURL); err != nil {
+		return err
+	}
+
+	loc := stealth.DetectLocale(page)
+
+	res, err := page.Eval(`(loc) => {
+		const main = document.querySelector('main') || document.body;
+		for (const btn of main.querySelectorAll('button')) {
+			const text = btn.innerText.trim().toLowerCase();
+			const label = (btn.getAttribute('aria-label') || '').toLowerCase();
+			if (loc.pending.includes(text) || label.includes('withdraw invitation')) {
+				btn.scrollIntoView({ block: "center" });
+				btn.click();
+				return true;
+			}
+		}
+		return false;
+	}`, loc)
+	if err != nil {
+		return fmt.Errorf("failed to find pending button: %w", err)
+	}
+	if !res.Value.Bool() {
+		return fmt.Errorf("no pending invitation on this profile")
+	}
+
+	stealth.SleepMillis(800, 1500)
+
+	res, err = page.Eval(`(loc) => {
+		const dialog = document.querySelector('div[role="alertdialog"], div[role="dialog"]');
+		if (!dialog) return false;
+		for (const btn of dialog.querySelectorAll('button')) {
+			if (loc.withdraw.includes(btn.innerText.trim().toLowerCase())) {
+				btn.click();
+				return true;
+			}
+		}
+		return false;
+	}`, loc)
+	if err != nil {
+		return fmt.Errorf("failed to confirm withdrawal: %w", err)
+	}
+	if !res.Value.Bool() {
+		dismissModal(page)
+		return fmt.Errorf("withdraw confirmation not found")
+	}
+
+	stealth.SleepMillis(800, 1500)
+
+	if result := stealth.QuickCheck(page); result.HasError {
+		stealth.PrintDetectionStatus(result)
+		return result.Error
+	}
+	return nil
+}
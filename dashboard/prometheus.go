@@ -0,0 +1,68 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// metricActions are the rate-limited actions exported per action label
+var metricActions = []stealth.ActionType{
+	stealth.ActionConnection,
+	stealth.ActionMessage,
+	stealth.ActionSearch,
+	stealth.ActionWithdraw,
+}
+
+// PrometheusHandler serves the bot's stats in the Prometheus text format
+// Values are read from the store and rate limiter on every scrape, so the
+// handler can be mounted at /metrics for an existing Prometheus/Grafana setup
+func PrometheusHandler(store *persistence.Store, rl *stealth.RateLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var b strings.Builder
+
+		connStats := rl.GetStats(stealth.ActionConnection)
+		stats, err := store.GetConnectionRequestStats(connStats.DailyLimit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read connection stats: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		writeMetric(&b, "linkedin_connections_sent_total", "counter", "Connection requests sent", float64(stats.TotalSent))
+		writeMetric(&b, "linkedin_connections_accepted_total", "counter", "Connection requests accepted", float64(stats.Accepted))
+		writeMetric(&b, "linkedin_connections_pending", "gauge", "Connection requests still pending", float64(stats.Pending))
+		writeMetric(&b, "linkedin_acceptance_rate", "gauge", "Share of sent connection requests accepted (0-1)", stats.AcceptanceRate/100)
+
+		writeHeader(&b, "linkedin_daily_remaining", "gauge", "Actions left in today's rate limit")
+		for _, action := range metricActions {
+			s := rl.GetStats(action)
+			fmt.Fprintf(&b, "linkedin_daily_remaining{action=%q} %d\n", action, max(s.DailyRemaining, 0))
+		}
+
+		writeHeader(&b, "linkedin_cooldown_active", "gauge", "1 while the action is in a rate-limit cooldown")
+		for _, action := range metricActions {
+			active := 0
+			if rl.GetStats(action).InCooldown {
+				active = 1
+			}
+			fmt.Fprintf(&b, "linkedin_cooldown_active{action=%q} %d\n", action, active)
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write([]byte(b.String()))
+	})
+}
+
+// writeHeader writes a metric's HELP and TYPE lines
+func writeHeader(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// writeMetric writes an unlabelled metric with its header
+func writeMetric(b *strings.Builder, name, kind, help string, value float64) {
+	writeHeader(b, name, kind, help)
+	fmt.Fprintf(b, "%s %g\n", name, value)
+}
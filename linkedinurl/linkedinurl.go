@@ -0,0 +1,43 @@
+// Package linkedinurl normalizes LinkedIn URLs so the same member is
+// recognised no matter how the link was copied or scraped
+package linkedinurl
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Canonicalize returns a comparison key for a LinkedIn URL, e.g.
+// "https://de.linkedin.com/in/Jane-Doe-123/?locale=en_US" -> "linkedin.com/in/jane-doe-123"
+// It strips the scheme, www/locale subdomains, query, fragment and trailing slash,
+// lowercases, decodes %-escapes, and trims profile sub-pages (/in/x/details/...) to /in/x.
+// Use it for dedup/lookup, not for navigation.
+func Canonicalize(raw string) string {
+	s := strings.TrimSpace(raw)
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimPrefix(s, "https://")
+	s = strings.TrimPrefix(s, "http://")
+	s = strings.ToLower(s)
+
+	host, path, _ := strings.Cut(s, "/")
+	if host == "linkedin.com" || strings.HasSuffix(host, ".linkedin.com") {
+		host = "linkedin.com"
+	}
+
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	path = strings.Trim(path, "/")
+
+	// /in/<slug>/... -> /in/<slug>
+	if parts := strings.Split(path, "/"); len(parts) > 2 && parts[0] == "in" {
+		path = parts[0] + "/" + parts[1]
+	}
+
+	if path == "" {
+		return host
+	}
+	return host + "/" + path
+}
@@ -0,0 +1,45 @@
+package linkedinurl
+
+import "testing"
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://www.linkedin.com/in/jane-doe", "linkedin.com/in/jane-doe"},
+		{"https://www.linkedin.com/in/jane-doe/", "linkedin.com/in/jane-doe"},
+		{"https://www.linkedin.com/in/jane-doe//", "linkedin.com/in/jane-doe"},
+		{"http://linkedin.com/in/Jane-Doe", "linkedin.com/in/jane-doe"},
+		{"www.linkedin.com/in/jane-doe", "linkedin.com/in/jane-doe"},
+		{"  https://www.linkedin.com/in/jane-doe  ", "linkedin.com/in/jane-doe"},
+
+		// Query strings and fragments
+		{"https://www.linkedin.com/in/jane-doe?miniProfileUrn=urn%3Ali%3Afs", "linkedin.com/in/jane-doe"},
+		{"https://www.linkedin.com/in/jane-doe/?locale=en_US#experience", "linkedin.com/in/jane-doe"},
+
+		// Profile sub-pages
+		{"https://www.linkedin.com/in/jane-doe/overlay/contact-info/", "linkedin.com/in/jane-doe"},
+		{"https://www.linkedin.com/in/jane-doe/details/experience", "linkedin.com/in/jane-doe"},
+
+		// Locale subdomains
+		{"https://de.linkedin.com/in/jane-doe", "linkedin.com/in/jane-doe"},
+		{"https://uk.linkedin.com/in/jane-doe/?originalSubdomain=uk", "linkedin.com/in/jane-doe"},
+
+		// Percent-encoded slugs
+		{"https://www.linkedin.com/in/j%C3%B3zef-nowak", "linkedin.com/in/józef-nowak"},
+		{"https://www.linkedin.com/in/J%C3%B3zef-Nowak/", "linkedin.com/in/józef-nowak"},
+		{"https://www.linkedin.com/in/józef-nowak", "linkedin.com/in/józef-nowak"},
+
+		// Non-profile URLs keep their path
+		{"https://www.linkedin.com/company/acme/", "linkedin.com/company/acme"},
+		{"https://www.linkedin.com/", "linkedin.com"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Canonicalize(tt.in); got != tt.want {
+			t.Errorf("Canonicalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
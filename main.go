@@ -1,16 +1,27 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/joho/godotenv"
 
 	"github.com/Nehilsa2/linkedin_automation/auth"
+	"github.com/Nehilsa2/linkedin_automation/connect"
+	"github.com/Nehilsa2/linkedin_automation/dashboard"
+	"github.com/Nehilsa2/linkedin_automation/message"
 	"github.com/Nehilsa2/linkedin_automation/persistence"
+	"github.com/Nehilsa2/linkedin_automation/search"
 	"github.com/Nehilsa2/linkedin_automation/stealth"
 )
 
@@ -19,20 +30,193 @@ const (
 	// Dry run mode (set to false to perform real actions)
 	DryRunMode = true
 
+	// "Validate" dry run: walk the real invite UI (Connect, modal, note)
+	// and stop right before Send, so selector breakage shows up in dry runs
+	DryRunValidate = false
+
+	// Count dry-run connects and messages in daily_stats as if they were sent,
+	// so dry-run session summaries show what a real run would have done.
+	// Applies to every workflow; set false to keep dry runs out of the stats
+	CountDryRunStats = true
+
+	// Read-only safe mode: blocks every connect/send/like click at the click
+	// helpers themselves, whatever the dry-run flags say
+	ReadOnlyMode = false
+
+	// Custom stealth script: if this file exists its JavaScript is injected
+	// into every page instead of the built-in webdriver patch
+	StealthScriptFile = "stealth.js"
+
+	// Watch the open tab for account warnings during long waits (cooldowns,
+	// delays between actions) and pause as soon as a critical one appears
+	MonitorLongWaits    = true
+	MonitorIntervalSecs = 10
+
+	// Confirm-before-send mode: pause before each real connect/message and
+	// ask for approval on the console (ignored in dry run)
+	RequireApproval = false
+
+	// Print the run's plan (sends, templates, search pages) before launching
+	// the browser and wait this long so a wrong config can be Ctrl-C'd (0 = no wait)
+	PlanPreviewSeconds = 5
+
+	// Send connection requests in batches of about this many (±1), with a
+	// long break (the safety level's break duration) between batches (0 = no batches)
+	ConnectBatchSize = 4
+
+	// How many times a crashed/disconnected browser is relaunched per run
+	MaxBrowserRestarts = 2
+
 	// Schedule enforcement (set to false to ignore work hours)
 	EnforceSchedule = false // TEMPORARILY DISABLED FOR TESTING
 
+	// Per-action hours inside the work day (24-hour clock, end exclusive),
+	// e.g. connects 9-12 and messages 14-17 (start == end = whole work day)
+	ConnectWindowStartHour = 0
+	ConnectWindowEndHour   = 0
+	MessageWindowStartHour = 0
+	MessageWindowEndHour   = 0
+
 	// Search settings
 	SearchKeywordPeople    = "software engineer"
 	SearchKeywordCompanies = "E-commerce"
 	SearchMaxPages         = 2
+	MaxResultsPerKeyword   = 0 // Stop a keyword's crawl after this many profiles, even mid-page (0 = no cap)
+
+	// Re-crawls of a keyword skip pages whose profiles are all stored already
+	// (jumping past the stored pages, or stopping) to save the search allowance
+	SkipKnownSearchPages = true
+
+	// Send invites from the Connect button on people search result cards
+	// while crawling, saving a profile visit per invite. Card invites carry
+	// no note; cards without an inline Connect open the profile instead
+	SearchCardConnect = false
+
+	// Save the search page's HTML when results render but no result cards
+	// match the selectors (LinkedIn layout change), for fixing the selectors
+	DumpSearchHTMLOnMismatch = false
+
+	// Saved search URL (optional) - when set, people are scraped from this
+	// LinkedIn search results URL instead of SearchKeywordPeople
+	SavedSearchURL = ""
+
+	// "People you may know" settings
+	SuggestionsMaxPages    = 3
+	SuggestionsGridConnect = false // Send invites straight from the suggestion cards (no note)
+
+	// "Who viewed your profile" (viewers workflow) as a lead source.
+	// ProfileViewerPolicy decides what the connect workflow does with members
+	// who viewed us in the last ProfileViewerWindowDays days: "prioritize"
+	// invites them first, "skip" leaves them out, "" ignores profile views
+	ProfileViewersMaxPages  = 3
+	ProfileViewerPolicy     = ViewerPolicyPrioritize
+	ProfileViewerWindowDays = 14
+
+	// Skip leads discovered more than this many days ago (0 = never expire)
+	MaxLeadAgeDays = 30
+
+	// Spread invites across companies: skip a target once this many requests
+	// went to people at the same company in the last 7 days (0 = no cap)
+	MaxRequestsPerCompanyPerWeek = 3
+
+	// Don't work through one company's staff back to back: connect targets
+	// are interleaved across companies, and once SameCompanyStreak invites in
+	// a row went to one company the next one there waits SameCompanyCooldownMins
+	// (0 = off)
+	SameCompanyStreak       = 2
+	SameCompanyCooldownMins = 30
+
+	// Fraction (0-1) of connection requests sent without a note, chosen at random
+	NoteOmissionRate = 0.0
+
+	// Only send invites that carry the personalized note: if the note can't be
+	// added (or there is none) the invite is cancelled instead of sent without it
+	RequireConnectNote = false
+
+	// Connects/messages that failed for a transient reason are retried by the
+	// "retry" workflow, at most this many attempts per profile
+	MaxActionRetries = 3
+
+	// Warmup workflow: view this many stored profiles (no connects or messages),
+	// skipping profiles already viewed in the last WarmupRevisitDays
+	WarmupProfileCount = 15
+	WarmupRevisitDays  = 30
+
+	// Personalized note sent with connection requests
+	ConnectNoteTemplate = "Hi! I came across your profile and would love to connect. Looking forward to learning from your experience!"
+
+	// Load each target's recent activity to fill {recent_post} in the note
+	// (one extra page load per invite; without it the {recent_post} sentence is dropped)
+	RecentPostNotes = false
+
+	// Auto-withdraw: at the start of connect/session runs, withdraw pending invites
+	// older than AutoWithdrawAfterDays (0 = off) until at most AutoWithdrawPendingAbove
+	// remain pending (0 = every old invite). Withdrawn profiles can be re-invited after 3 weeks.
+	AutoWithdrawAfterDays    = 0
+	AutoWithdrawMaxPerRun    = 10
+	AutoWithdrawPendingAbove = 0
+
+	// Pending ceiling: before each invite, if MaxPendingInvites (0 = off) or more
+	// invites are pending, either withdraw the oldest ones (at least
+	// PendingCeilingMinAgeDays old) or stop sending new invites for the run.
+	// A huge pending pile runs into the weekly invite limit sooner
+	MaxPendingInvites        = 0
+	PendingCeilingPolicy     = PendingCeilingWithdraw
+	PendingCeilingMinAgeDays = 14
 
 	// Organic browsing settings
 	EnableOrganicBrowsing = true // Browse profiles/feed between connections
 
+	// Profile filters checked on the target's profile before connecting:
+	// connect.FilterAny (ignore), FilterRequire (only with it) or FilterExclude
+	// (skip with it). Requiring a photo skips likely-fake accounts; recruiters
+	// may want to require Open to Work
+	ConnectPhotoFilter      = connect.FilterAny
+	ConnectOpenToWorkFilter = connect.FilterAny
+
+	// Idle (pause + feed browse, longer at safer levels) when a session
+	// switches between connecting and messaging instead of switching instantly
+	IdleBetweenPhases = true
+
+	// Chance that RunConnections views a target and moves on without
+	// connecting, so not every opened profile gets an invite. The target
+	// stays unprocessed and is retried next run. Keep it low (0 = never)
+	ViewWithoutConnectChance = 0.05
+
 	// Messaging settings
-	MessageTemplate     = "follow_up_simple"
-	MaxFollowUpMessages = 1
+	// "auto" picks a template per connection from their headline
+	// (recruiter/founder/software), or set a template name to use it for everyone
+	MessageTemplate        = message.AutoTemplate
+	MaxFollowUpMessages    = 1
+	MinHoursSinceConnected = 24   // Only message connections that accepted at least this long ago
+	ShuffleContactOrder    = true // Message ready connections in random order (false = newest first, for debugging)
+	SuppressLinkPreview    = true // Remove auto link preview cards before sending
+
+	// Segments: connections are auto-tagged from their headline on every sync
+	// ("investor", "founder", "recruiter", "candidate", "partner") and can be
+	// tagged by hand with -tag/-tag-as. Set MessageSegment to only follow up with
+	// one segment, and MessageSegmentTemplate to use its own template ("" = MessageTemplate)
+	AutoTagConnections     = true
+	MessageSegment         = ""
+	MessageSegmentTemplate = ""
+
+	// Only follow up with people who accepted one of our own requests sent in the
+	// last OurRequestMaxAgeDays days (0 = any age) - never the existing network
+	OnlyMessageOurConnections = false
+	OurRequestMaxAgeDays      = 90
+
+	// "Connect then message" pipeline (nurture workflow): people who accept one
+	// of our invites are queued for a follow-up this many hours after accepting
+	NurtureDelayHours    = 48
+	NurtureSyncMaxToScan = 50
+
+	// LinkedIn UI language for button texts: "" detects it from the page,
+	// or force one of "en", "de", "fr", "es"
+	UILocale = ""
+
+	// Seed for delays/scrolling/mouse/typing randomness (0 = seed from the clock)
+	// Set a fixed value to replay a run's timing while debugging
+	RandomSeed = 0
 
 	// Database settings
 	DatabasePath = "linkedin_automation.db"
@@ -40,13 +224,49 @@ const (
 	// Safety level for rate limiting (all limits controlled from stealth/ratelimit.go)
 	// Options: SafetyUltraConservative, SafetyConservative, SafetyModerate, SafetyAggressive
 	DefaultSafetyLevel = stealth.SafetyConservative
+
+	// How the rate limiter paces actions: LimiterWindow counts daily/hourly
+	// windows with burst cooldowns; LimiterTokenBucket refills continuously at
+	// the daily limit per 24h, holding at most TokenBucketBurst actions
+	RateLimiterMode  = stealth.LimiterWindow
+	TokenBucketBurst = 2
+
+	// Serve Prometheus metrics at /metrics on this address while running
+	// (e.g. ":9101"; empty = off)
+	MetricsAddr = ""
 )
 
+// Connection notes for members whose location/headline point to another
+// language (same placeholders as ConnectNoteTemplate). Anyone whose language
+// isn't clear, or has no entry here, gets the English ConnectNoteTemplate
+var ConnectNoteTemplatesByLanguage = map[string]string{
+	"de": "Hallo! Ich bin auf Ihr Profil gestoßen und würde mich gerne vernetzen. Ich freue mich darauf, von Ihrer Erfahrung zu lernen!",
+	"fr": "Bonjour ! J'ai découvert votre profil et j'aimerais beaucoup me connecter avec vous. Au plaisir d'échanger sur votre expérience !",
+	"es": "¡Hola! Encontré tu perfil y me encantaría conectar. ¡Espero aprender de tu experiencia!",
+}
+
 // Global store instance
 var store *persistence.Store
 
+// Global page pool - workflows borrow pages instead of opening new tabs
+var pagePool *stealth.PagePool
+
 func main() {
-	workflow := flag.String("workflow", "search", "Workflow to run: search, connect, followup")
+	workflow := flag.String("workflow", "search", "Workflow to run: search, connect, followup, session, plan, queue, nurture, reconcile, suggestions, viewers, retry, warmup")
+	importCSV := flag.String("import", "", "CSV of profile URLs to import as connection targets")
+	blockProfile := flag.String("block", "", "Profile URL to add to the do-not-contact list")
+	blockCompany := flag.String("block-company", "", "Company name to add to the do-not-contact list")
+	blockReason := flag.String("block-reason", "", "Reason recorded with -block / -block-company")
+	tagProfile := flag.String("tag", "", "Profile URL of a connection to tag with -tag-as")
+	tagAs := flag.String("tag-as", "", "Segment tag added by -tag (e.g. investor, candidate, partner)")
+	resetProcessed := flag.String("reset-processed", "", "Mark processed people results for this search keyword unprocessed again (\"*\" = every keyword) and exit")
+	resetOnlyFailed := flag.Bool("reset-only-failed", false, "With -reset-processed, only reset profiles without a pending or accepted request")
+	report := flag.Bool("report", false, "Write the weekly Markdown report and exit")
+	exportActions := flag.String("export-actions", "", "Write the rate limiter's action history to this CSV file and exit")
+	resume := flag.String("resume", "", "Resume the paused workflow of this type: search, connect, message, session")
+	detectionStats := flag.Bool("detection-stats", false, "Print how often each detection pattern fired, with recent matches, and exit")
+	history := flag.Int("history", 0, "Print the last N runs and exit")
+	startAt := flag.String("start-at", "", "Wait until this time before starting (15:04, \"2006-01-02 15:04\" or RFC3339)")
 	flag.Parse()
 
 	if err := godotenv.Load(); err != nil {
@@ -56,13 +276,62 @@ func main() {
 	// ==================== RATE LIMIT CONFIG ====================
 	stealth.SetSafetyLevel(DefaultSafetyLevel)
 	stealth.PrintConfig()
+	stealth.GetRateLimiter().SetMode(RateLimiterMode, TokenBucketBurst)
+	if RateLimiterMode == stealth.LimiterTokenBucket {
+		fmt.Printf("🪣 Token bucket pacing: up to %d actions at once, refilled over the day\n", TokenBucketBurst)
+	}
+
+	message.SuppressLinkPreview = SuppressLinkPreview
+	connect.NoteTemplatesByLanguage = ConnectNoteTemplatesByLanguage
+	search.DumpHTMLOnSelectorMismatch = DumpSearchHTMLOnMismatch
+	message.CountDryRunStats = CountDryRunStats
+	stealth.LocaleOverride = UILocale
+	if RandomSeed != 0 {
+		stealth.SetSeed(RandomSeed)
+		fmt.Printf("🎲 Using fixed random seed %d\n", RandomSeed)
+	}
+
+	if err := stealth.LoadStealthScript(StealthScriptFile); err != nil {
+		fmt.Printf("⚠️ Failed to load stealth script (using built-in): %v\n", err)
+	}
+
+	stealth.SetReadOnly(ReadOnlyMode)
+	stealth.MonitorWaits = MonitorLongWaits
+	stealth.MonitorInterval = time.Duration(MonitorIntervalSecs) * time.Second
+
+	if RequireApproval && !DryRunMode {
+		stealth.SetApprovalGate(stealth.NewConsoleApprovalGate())
+		fmt.Println("🙋 Approval mode enabled - each send needs confirmation")
+	}
+
+	// ==================== DELAYED START ====================
+	if *startAt != "" && !*report && *workflow != "plan" {
+		t, err := parseStartAt(*startAt, time.Now())
+		if err != nil {
+			log.Fatal("❌ Invalid -start-at: ", err)
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		err = stealth.WaitUntil(ctx, t)
+		stop()
+		if err != nil {
+			fmt.Println("👋 Cancelled before start")
+			return
+		}
+	}
 
 	// ==================== SCHEDULE CHECK ====================
+	if ConnectWindowEndHour > ConnectWindowStartHour {
+		stealth.ScheduleCfg.ActionWindows[stealth.ActionConnection] = []stealth.ActivityWindow{{StartHour: ConnectWindowStartHour, EndHour: ConnectWindowEndHour}}
+	}
+	if MessageWindowEndHour > MessageWindowStartHour {
+		stealth.ScheduleCfg.ActionWindows[stealth.ActionMessage] = []stealth.ActivityWindow{{StartHour: MessageWindowStartHour, EndHour: MessageWindowEndHour}}
+	}
+
 	if EnforceSchedule {
 		scheduler := stealth.NewScheduler()
 		fmt.Println("📅 Schedule status:", scheduler.GetStatus())
 
-		if !scheduler.CanOperate() {
+		if !scheduler.CanOperate("") {
 			fmt.Println("⏰ Outside work hours or on break")
 			if !scheduler.WaitUntilCanOperate() {
 				fmt.Println("🌙 Work day ended - exiting")
@@ -81,55 +350,340 @@ func main() {
 	defer store.Close()
 
 	fmt.Println("✅ Database initialized:", DatabasePath)
+
+	// Downgrade risky safety levels when the account looks unhealthy
+	if health, err := store.ComputeAccountHealth(); err != nil {
+		fmt.Printf("⚠️ Could not compute account health: %v\n", err)
+	} else {
+		fmt.Printf("🩺 Account health: %d/100\n", health.Score)
+		stealth.ApplyAccountHealth(stealth.AccountHealth(health.Score))
+	}
 	store.MigrateFromJSON()
+
+	if requeued, err := store.RequeueStaleResults(MaxLeadAgeDays); err == nil && requeued > 0 {
+		fmt.Printf("♻️ Marked %d stale search results for re-discovery (older than %d days)\n", requeued, MaxLeadAgeDays)
+	}
+
+	// Record today's network size for growth tracking
+	if snap, err := store.SnapshotConnections(); err != nil {
+		fmt.Printf("⚠️ Could not snapshot connections: %v\n", err)
+	} else {
+		fmt.Printf("📈 Network: %d connections, %d pending requests\n", snap.TotalConnections, snap.PendingRequests)
+	}
+
+	if *importCSV != "" {
+		if _, err := store.ImportTargetsCSV(*importCSV, SearchKeywordPeople); err != nil {
+			log.Fatal("❌ Failed to import targets:", err)
+		}
+	}
+	if *blockProfile != "" {
+		if err := store.AddToBlocklist(*blockProfile, *blockReason); err != nil {
+			log.Fatal("❌ Failed to block profile:", err)
+		}
+		fmt.Printf("🚫 Blocked profile: %s\n", *blockProfile)
+	}
+	if *blockCompany != "" {
+		if err := store.AddCompanyToBlocklist(*blockCompany, *blockReason); err != nil {
+			log.Fatal("❌ Failed to block company:", err)
+		}
+		fmt.Printf("🚫 Blocked company: %s\n", *blockCompany)
+	}
+	if *tagProfile != "" {
+		if err := store.TagConnection(*tagProfile, *tagAs); err != nil {
+			log.Fatal("❌ Failed to tag connection:", err)
+		}
+		fmt.Printf("🏷️ Tagged %s as %q\n", *tagProfile, *tagAs)
+	}
+	stealth.SetBlocklist(store)
+	stealth.SetEventLogger(store)
+	stealth.SetDetectionLogger(store)
+	message.SetMessageStore(store)
+	if url := os.Getenv("ALERT_WEBHOOK_URL"); url != "" {
+		stealth.SetAlertNotifier(stealth.NewWebhookAlertNotifier(url))
+	}
+	if MetricsAddr != "" {
+		serveMetrics(MetricsAddr)
+	}
+
+	if *report {
+		writeWeeklyReport()
+		return
+	}
+
+	if *history > 0 {
+		printRunHistory(*history)
+		return
+	}
+
+	if *detectionStats {
+		printDetectionStats()
+		return
+	}
+
+	if *resetProcessed != "" {
+		keyword := *resetProcessed
+		if keyword == "*" {
+			keyword = ""
+		}
+		reset, err := store.ResetProcessed(keyword, *resetOnlyFailed)
+		if err != nil {
+			log.Fatal("❌ Failed to reset processed results:", err)
+		}
+		fmt.Printf("♻️ Marked %d people results for re-processing\n", reset)
+		return
+	}
+
+	if *exportActions != "" {
+		limiter := stealth.GetRateLimiter()
+		if err := limiter.ExportActions(*exportActions); err != nil {
+			log.Fatal("❌ Failed to export actions:", err)
+		}
+		fmt.Printf("📤 Exported action history to %s\n", *exportActions)
+		limiter.PrintIntervalStats("")
+		limiter.PrintIntervalStats(stealth.ActionConnection)
+		limiter.PrintIntervalStats(stealth.ActionMessage)
+		return
+	}
+
+	// Actions left running by a crash go back into the queue
+	if requeued, err := store.RequeueInterruptedActions(); err == nil && requeued > 0 {
+		fmt.Printf("♻️ Requeued %d interrupted actions\n", requeued)
+	}
+
+	// Planning only touches the database - no browser needed
+	if *workflow == "plan" {
+		PlanQueue()
+		return
+	}
 	checkResumableWorkflows()
 
-	u := launcher.New().
+	if *resume != "" {
+		state, err := store.GetActiveWorkflow(*resume)
+		if err != nil || state == nil || state.Status != persistence.WorkflowStatusPaused {
+			log.Fatalf("❌ No paused %s workflow to resume", *resume)
+		}
+	}
+
+	if *resume == "" {
+		BuildPlan(store, stealth.GetRateLimiter()).Print(*workflow)
+		if PlanPreviewSeconds > 0 {
+			fmt.Printf("\n⏳ Starting in %ds - press Ctrl-C to abort\n", PlanPreviewSeconds)
+			time.Sleep(PlanPreviewSeconds * time.Second)
+		}
+	}
+
+	runName := *workflow
+	if *resume != "" {
+		runName = "resume:" + *resume
+	}
+	run := startRun(runName)
+
+	browser, err := startBrowser()
+	if err != nil {
+		run.finish(err)
+		log.Fatal("❌ ", err)
+	}
+	defer func() {
+		pagePool.Close()
+		browser.Close()
+	}()
+
+	// A crashed/disconnected Chrome is relaunched and the workflow resumed
+	for restarts := 0; ; restarts++ {
+		err := runWorkflow(*workflow, *resume, browser)
+		if err == nil {
+			break
+		}
+
+		fmt.Printf("💥 Workflow interrupted: %v\n", err)
+		pauseInterruptedWorkflows(err)
+
+		if browserAlive(browser) || restarts >= MaxBrowserRestarts {
+			fmt.Println("🛑 Progress saved - stopping")
+			run.finish(err)
+			return
+		}
+
+		fmt.Printf("🔁 Browser connection lost - relaunching (%d/%d)\n", restarts+1, MaxBrowserRestarts)
+		pagePool.Close()
+		browser.Close()
+		stealth.Sleep(10, 20)
+
+		browser, err = startBrowser()
+		if err != nil {
+			run.finish(fmt.Errorf("could not relaunch browser: %w", err))
+			log.Fatal("❌ Could not relaunch browser: ", err)
+		}
+	}
+
+	printSessionSummary()
+	run.finish(nil)
+	fmt.Println("\n✅ Workflow completed!")
+}
+
+// parseStartAt parses a -start-at value relative to now
+// A bare clock time means its next occurrence (tonight's 09:00 -> tomorrow 09:00)
+func parseStartAt(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		next := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised time %q", value)
+}
+
+// startBrowser launches Chrome, logs in, sets up the page pool and warms up on the feed
+func startBrowser() (*rod.Browser, error) {
+	// Same screen and user agent for this account every session
+	device, err := stealth.LoadDeviceProfile(os.Getenv("LINKEDIN_EMAIL"))
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := launcher.New().
 		Bin("C://Program Files//Google//Chrome//Application//chrome.exe").
 		Set("disable-blink-features", "AutomationControlled").
+		Set("window-size", device.WindowSize()).
 		Headless(false).
 		Leakless(false).
-		MustLaunch()
+		Launch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch browser: %w", err)
+	}
 
-	browser := rod.New().ControlURL(u).MustConnect()
-	defer browser.MustClose()
+	browser := rod.New().ControlURL(u)
+	if err := browser.Connect(); err != nil {
+		return nil, fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	if err := device.Apply(browser); err != nil {
+		browser.Close()
+		return nil, err
+	}
 
-	err = auth.EnsureAuthenticated(browser)
-	if err != nil {
-		log.Fatal("❌ Authentication failed:", err)
+	if err := auth.EnsureAuthenticated(browser); err != nil {
+		browser.Close()
+		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
 
 	pages, err := browser.Pages()
 	if err != nil || len(pages) == 0 {
-		log.Fatal("❌ Could not get feed page after authentication")
+		browser.Close()
+		return nil, fmt.Errorf("could not get feed page after authentication")
+	}
+
+	pagePool = stealth.NewPagePool(browser, 3)
+	if err := pagePool.Adopt(pages[len(pages)-1]); err != nil {
+		browser.Close()
+		return nil, fmt.Errorf("could not prepare feed page: %w", err)
+	}
+
+	feedPage, err := pagePool.Get()
+	if err != nil {
+		browser.Close()
+		return nil, fmt.Errorf("could not get feed page: %w", err)
 	}
-	feedPage := pages[len(pages)-1]
 	organicBrowser := stealth.NewOrganicBrowser(feedPage)
 	organicBrowser.BrowseFeed()
 	organicBrowser.RandomDelay()
+	pagePool.Put(feedPage)
+
+	return browser, nil
+}
+
+// runWorkflow runs one workflow, turning a panic (rod's Must* calls panic when
+// Chrome dies) into an error so progress can be saved
+// A non-empty resume type continues that paused workflow instead
+func runWorkflow(workflow, resume string, browser *rod.Browser) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if resume != "" {
+		ResumeWorkflow(browser, resume)
+		return nil
+	}
 
-	switch *workflow {
+	switch workflow {
 	case "search":
 		var people, companies []string
 		people, companies = RunSearch(browser)
 		fmt.Printf("\n📋 Search Summary: %d people, %d companies\n", len(people), len(companies))
 	case "connect":
 		// Get unprocessed profiles from DB for connection workflow
-		unprocessed, _ := store.GetUnprocessedSearchResults(SearchKeywordPeople, stealth.GetConnectionDailyLimit())
+		unprocessed, _ := store.GetUnprocessedSearchResults(SearchKeywordPeople, stealth.GetConnectionDailyLimit(), MaxLeadAgeDays)
 		var people []string
 		for _, r := range unprocessed {
 			people = append(people, r.ProfileURL)
 		}
-		RunConnections(feedPage, people)
+		RunConnections(people)
 	case "followup":
-		RunMessaging(browser)
+		RunMessaging()
+	case "session":
+		RunSession()
+	case "queue":
+		RunQueue()
+	case "nurture":
+		RunNurture()
+	case "reconcile":
+		RunReconcile()
+	case "suggestions":
+		RunSuggestions()
+	case "viewers":
+		RunProfileViewers()
+	case "retry":
+		RetryFailedActions()
+	case "warmup":
+		RunWarmup(WarmupProfileCount)
 	default:
-		fmt.Println("❌ Unknown workflow. Use: search, connect, followup")
-		return
+		fmt.Println("❌ Unknown workflow. Use: search, connect, followup, session, plan, queue, nurture, reconcile, suggestions, viewers, retry, warmup")
 	}
 
-	printSessionSummary()
-	fmt.Println("\n✅ Workflow completed!")
+	return nil
+}
+
+// browserAlive reports whether the browser still answers CDP calls
+func browserAlive(browser *rod.Browser) bool {
+	_, err := browser.Pages()
+	return err == nil
+}
+
+// pauseInterruptedWorkflows pauses every in-progress workflow and requeues
+// running queue actions so the next run resumes where this one died
+func pauseInterruptedWorkflows(cause error) {
+	workflowTypes := []string{
+		persistence.WorkflowTypeSearch,
+		persistence.WorkflowTypeConnect,
+		persistence.WorkflowTypeMessage,
+		persistence.WorkflowTypeSession,
+	}
+
+	for _, wfType := range workflowTypes {
+		state, err := store.GetActiveWorkflow(wfType)
+		if err != nil || state == nil || state.Status != persistence.WorkflowStatusInProgress {
+			continue
+		}
+		state.ErrorMessage = cause.Error()
+		store.SaveWorkflowState(state)
+		store.PauseWorkflow(state.ID)
+		fmt.Printf("⏸️ Paused %s workflow at %d/%d\n", wfType, state.CurrentIndex, state.TotalItems)
+	}
+
+	if requeued, err := store.RequeueInterruptedActions(); err == nil && requeued > 0 {
+		fmt.Printf("♻️ Requeued %d interrupted actions\n", requeued)
+	}
 }
 
 // checkResumableWorkflows checks for any paused workflows that can be resumed
@@ -140,6 +694,7 @@ func checkResumableWorkflows() {
 		persistence.WorkflowTypeSearch,
 		persistence.WorkflowTypeConnect,
 		persistence.WorkflowTypeMessage,
+		persistence.WorkflowTypeSession,
 	}
 
 	for _, wfType := range workflowTypes {
@@ -154,6 +709,43 @@ func checkResumableWorkflows() {
 			if state.PausedAt != nil {
 				fmt.Printf("   Paused: %s\n", state.PausedAt.Format("2006-01-02 15:04:05"))
 			}
+			fmt.Printf("   Resume with: -resume %s\n", wfType)
+		}
+	}
+}
+
+// printDetectionStats prints how often each detection pattern fired and the
+// latest matches, to spot patterns that trigger on harmless page text
+func printDetectionStats() {
+	stats, err := store.GetDetectionStats()
+	if err != nil {
+		fmt.Printf("⚠️ Could not load detection stats: %v\n", err)
+		return
+	}
+
+	fmt.Println("\n==================================================")
+	fmt.Println("🔎 DETECTION PATTERNS")
+	fmt.Println("==================================================")
+	if len(stats) == 0 {
+		fmt.Println("ℹ️ No detections recorded yet")
+		return
+	}
+	for _, stat := range stats {
+		fmt.Printf("   %4d  %-28s %-5s %q\n", stat.Count, stat.ErrorType, stat.Source, stat.Pattern)
+	}
+
+	recent, err := store.GetRecentDetections("", 10)
+	if err != nil {
+		return
+	}
+	fmt.Println("\n🕵️ Latest matches:")
+	for _, d := range recent {
+		fmt.Printf("   %s  %s (%s %q)\n", d.CreatedAt.Local().Format("2006-01-02 15:04"), d.ErrorType, d.Source, d.Pattern)
+		if d.PageURL != "" {
+			fmt.Printf("      %s\n", d.PageURL)
+		}
+		if d.Snippet != "" {
+			fmt.Printf("      …%s…\n", d.Snippet)
 		}
 	}
 }
@@ -173,6 +765,9 @@ func printSessionSummary() {
 	fmt.Printf("\n📅 Today's Activity:\n")
 	fmt.Printf("   🔍 Profiles discovered: %d\n", stats.ProfilesSearched)
 	fmt.Printf("   🔗 Connection requests sent: %d\n", stats.ConnectionsSent)
+	if stats.NotesSkipped > 0 {
+		fmt.Printf("   📝 Sent without note (note limit): %d\n", stats.NotesSkipped)
+	}
 	fmt.Printf("   ✅ Connections accepted: %d\n", stats.ConnectionsAccepted)
 	fmt.Printf("   📬 Messages sent: %d\n", stats.MessagesSent)
 
@@ -193,9 +788,47 @@ func printSessionSummary() {
 		fmt.Printf("   Initial: %d\n", msgStats.InitialSent)
 		fmt.Printf("   Follow-ups: %d\n", msgStats.FollowUpsSent)
 	}
+
+	// Account health
+	health, err := store.ComputeAccountHealth()
+	if err == nil {
+		fmt.Printf("\n🩺 Account Health: %d/100\n", health.Score)
+		for _, f := range health.Factors {
+			fmt.Printf("   %s: %s (-%d)\n", f.Name, f.Detail, f.Penalty)
+		}
+		if stealth.AccountHealth(health.Score).IsLow() {
+			fmt.Println("   ⚠️ Health is low - only conservative safety levels will run")
+		}
+	}
 }
 
 // GetStore returns the global store instance for use in other packages
 func GetStore() *persistence.Store {
 	return store
 }
+
+// writeWeeklyReport generates the last 7 days' report as weekly_report_<date>.md
+func writeWeeklyReport() {
+	report, err := store.GenerateWeeklyReport()
+	if err != nil {
+		log.Fatal("❌ Failed to generate weekly report:", err)
+	}
+
+	path := fmt.Sprintf("weekly_report_%s.md", report.GeneratedAt.Format("2006-01-02"))
+	if err := report.WriteMarkdown(path); err != nil {
+		log.Fatal("❌ ", err)
+	}
+	fmt.Printf("📄 Weekly report written to %s\n", path)
+}
+
+// serveMetrics serves Prometheus metrics at /metrics in the background
+func serveMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", dashboard.PrometheusHandler(store, stealth.GetRateLimiter()))
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			fmt.Printf("⚠️ Metrics server stopped: %v\n", err)
+		}
+	}()
+	fmt.Printf("📈 Serving metrics at http://%s/metrics\n", addr)
+}
@@ -2,10 +2,16 @@ package message
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
 )
 
 // DetectNewConnections scans the connections page for newly accepted connections
@@ -16,7 +22,7 @@ func DetectNewConnections(page *rod.Page, tracker *Tracker, maxToScan int) ([]Co
 	connectionsURL := "https://www.linkedin.com/mynetwork/invite-connect/connections/"
 	fmt.Printf("📍 Navigating to: %s\n", connectionsURL)
 
-	timeoutPage := page.Timeout(15 * time.Second)
+	timeoutPage := page.Timeout(stealth.GetNavigationTimeout())
 	err := timeoutPage.Navigate(connectionsURL)
 	if err != nil {
 		timeoutPage.CancelTimeout()
@@ -29,7 +35,10 @@ func DetectNewConnections(page *rod.Page, tracker *Tracker, maxToScan int) ([]Co
 		fmt.Println("⚠️ Page stability wait timed out, continuing...")
 	}
 
-	time.Sleep(3 * time.Second)
+	// Wait for the connections list XHRs to settle instead of a fixed sleep
+	if err := stealth.WaitForNetworkIdle(page, 800, stealth.GetStabilityTimeout()); err != nil {
+		fmt.Printf("⚠️ %v, continuing...\n", err)
+	}
 
 	// Debug: log page URL
 	fmt.Printf("📍 Current URL: %s\n", page.MustInfo().URL)
@@ -166,6 +175,7 @@ func DetectNewConnections(page *rod.Page, tracker *Tracker, maxToScan int) ([]Co
 
 	// Use gson.JSON array iteration
 	arr := result.Arr()
+	seen := make(map[string]bool)
 	for _, item := range arr {
 		profileURL := item.Get("profileURL").Str()
 		name := item.Get("name").Str()
@@ -177,6 +187,13 @@ func DetectNewConnections(page *rod.Page, tracker *Tracker, maxToScan int) ([]Co
 			name = extractNameFromURL(profileURL)
 		}
 
+		// The same member can show up under URL variants (locale params, case)
+		key := linkedinurl.Canonicalize(profileURL)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
 		// Check if this is a new connection (not already tracked)
 		existing := tracker.GetConnection(profileURL)
 		if existing == nil && profileURL != "" {
@@ -262,32 +279,99 @@ func isNumeric(s string) bool {
 	return digitCount > len(s)/2
 }
 
-// parseConnectedTime attempts to parse connection time
+// connectedTimeToken splits "Connected 3 days ago" / "vor 2 Wochen" / "2mo"
+// into number and word tokens
+var connectedTimeToken = regexp.MustCompile(`\d+|\p{L}+`)
+
+// Words for "one" in the supported UI languages ("a week ago", "vor einer Woche", "il y a un mois")
+var connectedTimeOne = map[string]bool{
+	"a": true, "an": true, "one": true,
+	"ein": true, "eine": true, "einem": true, "einer": true,
+	"un": true, "une": true, "una": true, "uno": true,
+}
+
+var (
+	connectedTimeToday     = []string{"today", "now", "heute", "gerade", "aujourd", "instant", "hoy", "ahora", "moment"}
+	connectedTimeYesterday = []string{"yesterday", "gestern", "hier", "ayer"}
+)
+
+// connectedTimeUnits maps unit word prefixes (en, de, fr, es) to how far back n units go
+// Compact forms ("3d", "2w", "5h", "2mo") must match the whole token
+var connectedTimeUnits = []struct {
+	prefixes []string
+	exact    []string
+	back     func(now time.Time, n int) time.Time
+}{
+	{[]string{"min"}, []string{"m"}, func(now time.Time, n int) time.Time { return now.Add(-time.Duration(n) * time.Minute) }},
+	{[]string{"hour", "hr", "stunde", "std", "heure", "hora"}, []string{"h"}, func(now time.Time, n int) time.Time { return now.Add(-time.Duration(n) * time.Hour) }},
+	{[]string{"day", "tag", "jour", "día", "dia"}, []string{"d"}, func(now time.Time, n int) time.Time { return now.AddDate(0, 0, -n) }},
+	{[]string{"week", "wk", "woche", "semaine", "semana"}, []string{"w"}, func(now time.Time, n int) time.Time { return now.AddDate(0, 0, -7*n) }},
+	{[]string{"month", "monat", "mois", "mes"}, []string{"mo"}, func(now time.Time, n int) time.Time { return now.AddDate(0, -n, 0) }},
+	{[]string{"year", "yr", "jahr", "an", "año", "ano"}, nil, func(now time.Time, n int) time.Time { return now.AddDate(-n, 0, 0) }},
+}
+
+// parseConnectedTime parses LinkedIn's relative connection time
+// ("Connected 2 days ago", "vor 3 Wochen", "il y a 2 mois", "hace 1 semana")
+// Falls back to now when the text can't be parsed
 func parseConnectedTime(timeStr string) time.Time {
-	timeStr = strings.ToLower(timeStr)
-	now := time.Now()
+	return parseConnectedTimeAt(timeStr, time.Now())
+}
 
-	if strings.Contains(timeStr, "just now") || strings.Contains(timeStr, "today") {
-		return now
-	}
-	if strings.Contains(timeStr, "yesterday") {
-		return now.AddDate(0, 0, -1)
+// parseConnectedTimeAt is parseConnectedTime relative to a given time
+func parseConnectedTimeAt(timeStr string, now time.Time) time.Time {
+	tokens := connectedTimeToken.FindAllString(strings.ToLower(timeStr), -1)
+
+	for _, tok := range tokens {
+		if hasWordPrefix(tok, connectedTimeToday) {
+			return now
+		}
+		if hasWordPrefix(tok, connectedTimeYesterday) {
+			return now.AddDate(0, 0, -1)
+		}
 	}
-	if strings.Contains(timeStr, "day") {
-		// "X days ago"
-		return now.AddDate(0, 0, -3) // Approximate
+
+	// The unit is the first unit word after the count; without a count
+	// ("last week", "letzte Woche") one unit is assumed. Digits win over
+	// "one" words since French "il y a 3 jours" has an "a" before the number
+	n, rest := 1, tokens
+	found := false
+	for i, tok := range tokens {
+		if v, err := strconv.Atoi(tok); err == nil {
+			n, rest, found = v, tokens[i+1:], true
+			break
+		}
 	}
-	if strings.Contains(timeStr, "week") {
-		return now.AddDate(0, 0, -7)
+	if !found {
+		for i, tok := range tokens {
+			if connectedTimeOne[tok] {
+				rest = tokens[i+1:]
+				break
+			}
+		}
 	}
-	if strings.Contains(timeStr, "month") {
-		return now.AddDate(0, -1, 0)
+
+	for _, tok := range rest {
+		for _, unit := range connectedTimeUnits {
+			if hasWordPrefix(tok, unit.prefixes) || slices.Contains(unit.exact, tok) {
+				return unit.back(now, n)
+			}
+		}
 	}
 
 	// Default to now if can't parse
 	return now
 }
 
+// hasWordPrefix reports whether tok starts with any of the prefixes
+func hasWordPrefix(tok string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(tok, p) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetRecentConnections returns connections from the last N days that haven't been messaged
 func GetRecentConnections(tracker *Tracker, days int) []Connection {
 	cutoff := time.Now().AddDate(0, 0, -days)
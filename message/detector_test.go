@@ -0,0 +1,58 @@
+package message
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseConnectedTimeAt(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"Connected 3 days ago", now.AddDate(0, 0, -3)},
+		{"Connected 1 week ago", now.AddDate(0, 0, -7)},
+		{"Connected 2 months ago", now.AddDate(0, -2, 0)},
+		{"Connected a year ago", now.AddDate(-1, 0, 0)},
+		{"Connected an hour ago", now.Add(-time.Hour)},
+		{"Connected 45 minutes ago", now.Add(-45 * time.Minute)},
+		{"3d", now.AddDate(0, 0, -3)},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"5h", now.Add(-5 * time.Hour)},
+		{"2mo", now.AddDate(0, -2, 0)},
+		{"Connected today", now},
+		{"Connected yesterday", now.AddDate(0, 0, -1)},
+		{"Connected a moment ago", now},
+		{"Connected on Monday", now},
+
+		// German
+		{"Vor 3 Tagen verbunden", now.AddDate(0, 0, -3)},
+		{"vor einer Woche", now.AddDate(0, 0, -7)},
+		{"vor 2 Monaten", now.AddDate(0, -2, 0)},
+		{"gestern", now.AddDate(0, 0, -1)},
+
+		// French
+		{"il y a 3 jours", now.AddDate(0, 0, -3)},
+		{"il y a une semaine", now.AddDate(0, 0, -7)},
+		{"il y a 2 mois", now.AddDate(0, -2, 0)},
+		{"il y a 2 ans", now.AddDate(-2, 0, 0)},
+		{"à l'instant", now},
+
+		// Spanish
+		{"hace 3 días", now.AddDate(0, 0, -3)},
+		{"hace 1 semana", now.AddDate(0, 0, -7)},
+		{"hace 2 meses", now.AddDate(0, -2, 0)},
+		{"hace un momento", now},
+
+		{"", now},
+		{"something else", now},
+	}
+
+	for _, tt := range tests {
+		if got := parseConnectedTimeAt(tt.in, now); !got.Equal(tt.want) {
+			t.Errorf("parseConnectedTimeAt(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+)
+
+// GetConversationHistory reads every message bubble of the conversation open on page
+// and returns them oldest first. Messages written by conn are typed "reply";
+// everything else is ours. The thread must already be open (e.g. after SendMessage
+// clicked the Message button); older messages LinkedIn hasn't loaded are not included.
+func GetConversationHistory(page *rod.Page, conn Connection) ([]Message, error) {
+	res, err := page.Eval(`() => {
+		const thread = document.querySelector(
+			'.msg-s-message-list, .msg-s-message-list-content, [class*="msg-s-message-list"]'
+		);
+		if (!thread) return null;
+
+		const out = [];
+		let sender = '';
+		let day = '';
+		for (const item of thread.querySelectorAll('li')) {
+			const dayEl = item.querySelector('.msg-s-message-list__time-heading, time[class*="time-heading"]');
+			if (dayEl) day = dayEl.innerText.trim();
+
+			// The name and time only appear on the first bubble of a group
+			const nameEl = item.querySelector('.msg-s-message-group__name, [class*="message-group__name"]');
+			if (nameEl) sender = nameEl.innerText.trim();
+			const timeEl = item.querySelector('.msg-s-message-group__timestamp, time');
+
+			const body = item.querySelector('.msg-s-event-listitem__body, [class*="event-listitem__body"]');
+			if (!body) continue;
+			const text = body.innerText.trim();
+			if (!text) continue;
+
+			const time = timeEl ? timeEl.innerText.trim() : '';
+			out.push({
+				sender: sender,
+				text: text,
+				time: [day, time].filter(Boolean).join(' '),
+			});
+		}
+		return out;
+	}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conversation: %w", err)
+	}
+	if res.Value.Nil() {
+		return nil, fmt.Errorf("no conversation open for %s", conn.Name)
+	}
+
+	var history []Message
+	for _, m := range res.Value.Arr() {
+		sender := m.Get("sender").Str()
+		msg := Message{
+			RecipientURL:  conn.ProfileURL,
+			RecipientName: conn.Name,
+			Content:       m.Get("text").Str(),
+			Status:        "delivered",
+			MessageType:   "follow_up",
+			SenderName:    sender,
+			TimeText:      m.Get("time").Str(),
+		}
+		if isSender(sender, conn.Name) {
+			msg.MessageType = "reply"
+		}
+		history = append(history, msg)
+	}
+
+	return history, nil
+}
+
+// ReconcileHistory adds messages stored for conn that the scraped thread is missing
+// (usually older ones LinkedIn didn't load), ahead of the scraped history
+func ReconcileHistory(store *persistence.Store, conn Connection, history []Message) ([]Message, error) {
+	stored, err := store.GetMessagesByRecipient(conn.ProfileURL)
+	if err != nil {
+		return history, fmt.Errorf("failed to load stored messages: %w", err)
+	}
+
+	seen := make(map[string]bool, len(history))
+	for _, m := range history {
+		seen[strings.TrimSpace(m.Content)] = true
+	}
+
+	// Stored messages come newest first; walk backwards to keep them oldest first
+	var missing []Message
+	for i := len(stored) - 1; i >= 0; i-- {
+		s := stored[i]
+		if s.Status == persistence.MessageStatusFailed || seen[strings.TrimSpace(s.Content)] {
+			continue
+		}
+		missing = append(missing, Message{
+			ConversationID: s.ConversationID,
+			RecipientURL:   s.RecipientURL,
+			RecipientName:  s.RecipientName,
+			Content:        s.Content,
+			TemplateName:   s.TemplateName,
+			SentAt:         s.SentAt,
+			Status:         s.Status,
+			MessageType:    s.MessageType,
+			TimeText:       s.SentAt.Format("Jan 2 15:04"),
+		})
+	}
+
+	if len(missing) > 0 {
+		fmt.Printf("🗂️ Added %d stored messages missing from the thread with %s\n", len(missing), conn.Name)
+	}
+	return append(missing, history...), nil
+}
+
+// isSender reports whether a bubble's sender name is the connection
+// LinkedIn sometimes shows only the first name in threads
+func isSender(sender, name string) bool {
+	sender = strings.ToLower(strings.TrimSpace(sender))
+	name = strings.ToLower(strings.TrimSpace(name))
+	if sender == "" || name == "" {
+		return false
+	}
+	if sender == name {
+		return true
+	}
+	parts := splitName(name)
+	return len(parts) > 0 && sender == strings.ToLower(parts[0])
+}
@@ -1,14 +1,26 @@
 package message
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-rod/rod"
 
 	"github.com/Nehilsa2/linkedin_automation/stealth"
 )
 
+// ErrMessageNotSent is returned when Send was clicked but the message never
+// left the composer
+var ErrMessageNotSent = errors.New("message not confirmed in the thread after send")
+
+// SuppressLinkPreview removes LinkedIn's auto-generated link preview card before sending
+// so the recipient only sees the text that was typed
+var SuppressLinkPreview = false
+
 // SendMessage sends a message to a profile (must be on their profile page or in messaging)
 func SendMessage(page *rod.Page, content string, dryRun bool) error {
 	fmt.Println("💬 Attempting to send message...")
@@ -20,12 +32,27 @@ func SendMessage(page *rod.Page, content string, dryRun bool) error {
 		return nil
 	}
 
+	// Confirm-before-send mode
+	info, err := page.Info()
+	if err != nil {
+		return fmt.Errorf("failed to read page info: %w", err)
+	}
+	if err := stealth.RequireApproval(stealth.ActionMessage, info.URL, content); err != nil {
+		return err
+	}
+
+	// Close banners and stale conversation bubbles before opening ours
+	stealth.DismissOverlays(page)
+
 	// Set timeout
-	timeoutPage := page.Timeout(15 * time.Second)
+	timeoutPage := page.Timeout(stealth.GetEvalTimeout())
 	defer timeoutPage.CancelTimeout()
 
+	// Button texts depend on the LinkedIn UI language
+	loc := stealth.DetectLocale(timeoutPage)
+
 	// Try to find and click the Message button on profile
-	result := timeoutPage.MustEval(`() => {
+	res, err := timeoutPage.Eval(`(loc) => {
 		// Find Message button on profile
 		const messageSelectors = [
 			'button[aria-label*="Message"]',
@@ -45,7 +72,7 @@ func SendMessage(page *rod.Page, content string, dryRun bool) error {
 		// Try finding by text
 		const buttons = document.querySelectorAll('button');
 		for (const btn of buttons) {
-			if (btn.innerText.trim().toLowerCase() === 'message') {
+			if (loc.message.includes(btn.innerText.trim().toLowerCase())) {
 				btn.scrollIntoView({ block: "center" });
 				btn.click();
 				return { found: true, clicked: true };
@@ -53,7 +80,11 @@ func SendMessage(page *rod.Page, content string, dryRun bool) error {
 		}
 
 		return { found: false, clicked: false };
-	}`)
+	}`, loc)
+	if err != nil {
+		return fmt.Errorf("failed to find message button: %w", err)
+	}
+	result := res.Value
 
 	if !result.Get("found").Bool() {
 		return fmt.Errorf("message button not found on profile")
@@ -70,17 +101,28 @@ func SendMessage(page *rod.Page, content string, dryRun bool) error {
 	}
 
 	// Type the message
-	err := typeMessage(timeoutPage, content)
+	err = typeMessage(timeoutPage, content)
 	if err != nil {
 		return fmt.Errorf("failed to type message: %w", err)
 	}
 
+	// Remove the link preview card LinkedIn attaches to pasted/typed URLs
+	if SuppressLinkPreview && containsLink(content) {
+		removeLinkPreview(timeoutPage)
+	}
+
 	// Send the message
+	before := readThreadState(timeoutPage)
 	err = clickSendMessage(timeoutPage)
 	if err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
 
+	// Only count it as sent once it actually left the composer
+	if err := verifyMessageSent(timeoutPage, content, before); err != nil {
+		return err
+	}
+
 	fmt.Println("✅ Message sent!")
 	return nil
 }
@@ -95,7 +137,7 @@ func SendMessage(page *rod.Page, content string, dryRun bool) error {
 // - Natural timing varies: faster for common letters, slower for symbols
 func typeMessage(page *rod.Page, content string) error {
 	// First, find and focus the message input
-	result := page.MustEval(`() => {
+	res, err := page.Eval(`() => {
 		const inputSelectors = [
 			'div[role="textbox"][contenteditable="true"]',
 			'div.msg-form__contenteditable',
@@ -119,6 +161,10 @@ func typeMessage(page *rod.Page, content string) error {
 
 		return { found: false };
 	}`)
+	if err != nil {
+		return fmt.Errorf("failed to find message input: %w", err)
+	}
+	result := res.Value
 
 	if !result.Get("found").Bool() {
 		return fmt.Errorf("message input not found")
@@ -128,7 +174,7 @@ func typeMessage(page *rod.Page, content string) error {
 
 	// Type the message character by character with human-like timing
 	fmt.Printf("⌨️ Typing message (%d chars)...\n", len(content))
-	err := stealth.TypeTextJS(page, content, stealth.DefaultTypingConfig())
+	err = stealth.TypeTextJS(page, content, stealth.DefaultTypingConfig())
 	if err != nil {
 		return fmt.Errorf("failed to type message: %w", err)
 	}
@@ -137,11 +183,64 @@ func typeMessage(page *rod.Page, content string) error {
 	return nil
 }
 
+// containsLink reports whether message content includes a URL LinkedIn would preview
+func containsLink(content string) bool {
+	lower := strings.ToLower(content)
+	return strings.Contains(lower, "http://") ||
+		strings.Contains(lower, "https://") ||
+		strings.Contains(lower, "www.")
+}
+
+// removeLinkPreview waits for the link preview card to render and dismisses it
+// The preview is fetched asynchronously after the URL is typed, so poll briefly
+func removeLinkPreview(page *rod.Page) {
+	for attempt := 0; attempt < 6; attempt++ {
+		res, err := page.Eval(`() => {
+			const dismissSelectors = [
+				'.msg-form__link-preview button[aria-label*="Remove"]',
+				'.msg-form__link-preview button[aria-label*="Dismiss"]',
+				'button[aria-label*="Remove link preview"]',
+				'button[aria-label*="remove preview" i]',
+				'.msg-link-preview__dismiss',
+			];
+
+			for (const selector of dismissSelectors) {
+				const btn = document.querySelector(selector);
+				if (btn && !btn.disabled) {
+					btn.click();
+					return true;
+				}
+			}
+			return false;
+		}`)
+		if err != nil {
+			fmt.Printf("⚠️ Could not check for link preview: %v\n", err)
+			return
+		}
+
+		if res.Value.Bool() {
+			fmt.Println("🔗 Link preview removed")
+			stealth.SleepMillis(300, 600)
+			return
+		}
+
+		stealth.SleepMillis(400, 600)
+	}
+
+	fmt.Println("ℹ️ No link preview appeared")
+}
+
 // clickSendMessage clicks the send button
 func clickSendMessage(page *rod.Page) error {
+	if err := stealth.BlockWrite("message send", ""); err != nil {
+		return err
+	}
+
 	stealth.SleepMillis(400, 700)
 
-	result := page.MustEval(`() => {
+	loc := stealth.DetectLocale(page)
+
+	res, err := page.Eval(`(loc) => {
 		const sendSelectors = [
 			'button[type="submit"].msg-form__send-button',
 			'button.msg-form__send-button',
@@ -161,16 +260,19 @@ func clickSendMessage(page *rod.Page) error {
 		const buttons = document.querySelectorAll('button');
 		for (const btn of buttons) {
 			const text = btn.innerText.toLowerCase().trim();
-			if (text === 'send' && !btn.disabled) {
+			if (loc.send.includes(text) && !btn.disabled) {
 				btn.click();
 				return true;
 			}
 		}
 
 		return false;
-	}`)
+	}`, loc)
+	if err != nil {
+		return fmt.Errorf("failed to find send button: %w", err)
+	}
 
-	if !result.Bool() {
+	if !res.Value.Bool() {
 		return fmt.Errorf("send button not found or disabled")
 	}
 
@@ -178,6 +280,101 @@ func clickSendMessage(page *rod.Page) error {
 	return nil
 }
 
+// threadState is a snapshot of the open conversation: what's left in the
+// composer and the message bubbles in the thread
+type threadState struct {
+	Draft    string
+	Bubbles  int
+	Last     string
+	HasList  bool // Whether a message list was found at all
+	Readable bool
+}
+
+// readThreadState reads the composer and thread of the open conversation
+func readThreadState(page *rod.Page) threadState {
+	res, err := page.Eval(`() => {
+		const box = document.querySelector(
+			'div.msg-form__contenteditable, div[role="textbox"][contenteditable="true"], textarea.msg-form__textarea'
+		);
+		const draft = box ? (box.tagName === 'TEXTAREA' ? box.value : box.innerText) : '';
+
+		const bubbleSelectors = [
+			'.msg-s-event-listitem__body',
+			'.msg-s-message-list__event',
+			'li.msg-s-message-list__event',
+		];
+		let bubbles = [];
+		for (const selector of bubbleSelectors) {
+			const found = document.querySelectorAll(selector);
+			if (found.length > 0) {
+				bubbles = found;
+				break;
+			}
+		}
+
+		return {
+			draft: draft.trim(),
+			bubbles: bubbles.length,
+			last: bubbles.length ? bubbles[bubbles.length - 1].innerText : '',
+			hasList: bubbles.length > 0 || !!document.querySelector('.msg-s-message-list, .msg-s-message-list-content'),
+		};
+	}`)
+	if err != nil {
+		return threadState{}
+	}
+
+	v := res.Value
+	return threadState{
+		Draft:    v.Get("draft").Str(),
+		Bubbles:  v.Get("bubbles").Int(),
+		Last:     v.Get("last").Str(),
+		HasList:  v.Get("hasList").Bool(),
+		Readable: true,
+	}
+}
+
+// verifyMessageSent polls the conversation after Send until the composer is
+// empty and a new bubble with the message shows up in the thread
+// Only a message still in the composer is a failure: an empty composer with no
+// new bubble (or no thread list, after a layout change) is accepted with a
+// warning, so a sent message isn't re-sent later
+func verifyMessageSent(page *rod.Page, content string, before threadState) error {
+	snippet := []rune(strings.Join(strings.Fields(content), " "))
+	if len(snippet) > 40 {
+		snippet = snippet[:40]
+	}
+
+	var state threadState
+	for attempt := 0; attempt < 5; attempt++ {
+		state = readThreadState(page)
+		if !state.Readable {
+			fmt.Println("   ⚠️ Could not read the conversation to verify the send")
+			return nil
+		}
+
+		if state.Draft == "" {
+			last := strings.Join(strings.Fields(state.Last), " ")
+			if state.Bubbles > before.Bubbles || strings.Contains(last, string(snippet)) {
+				return nil
+			}
+			if !state.HasList {
+				fmt.Println("   ⚠️ Message list not found - trusting the cleared composer")
+				return nil
+			}
+		}
+		stealth.SleepMillis(800, 1300)
+	}
+
+	if state.Draft != "" {
+		fmt.Println("❌ Message is still in the composer after clicking Send")
+		return fmt.Errorf("%w: still in the composer", ErrMessageNotSent)
+	}
+	// The text left the composer, so it most likely went out; failing here would
+	// let a retry send it twice
+	fmt.Println("   ⚠️ Composer cleared but no new message appeared in the thread - treating it as sent")
+	return nil
+}
+
 // SendFollowUpMessage navigates to profile and sends a follow-up message
 func SendFollowUpMessage(page *rod.Page, conn Connection, content string, tracker *Tracker) error {
 	fmt.Printf("📨 Sending follow-up to: %s\n", conn.Name)
@@ -192,9 +389,19 @@ func SendFollowUpMessage(page *rod.Page, conn Connection, content string, tracke
 		return fmt.Errorf("already messaged this connection")
 	}
 
+	// Never contact blocked people/companies
+	if err := stealth.CheckBlocked(conn.ProfileURL, conn.Company); err != nil {
+		return err
+	}
+
+	// Never send two messages too close together, whatever the rate limiter thinks
+	if err := checkMessageInterval(); err != nil {
+		return err
+	}
+
 	// Navigate to profile
 	fmt.Printf("📍 Navigating to: %s\n", conn.ProfileURL)
-	timeoutPage := page.Timeout(15 * time.Second)
+	timeoutPage := page.Timeout(stealth.GetNavigationTimeout())
 
 	err := timeoutPage.Navigate(conn.ProfileURL)
 	if err != nil {
@@ -232,8 +439,10 @@ func SendFollowUpMessage(page *rod.Page, conn Connection, content string, tracke
 		if err := tracker.Save(); err != nil {
 			fmt.Printf("⚠️ Failed to save tracker: %v\n", err)
 		}
+		recordMessage(msg)
 	} else {
 		fmt.Println("🧪 [DRY RUN] Would track message (not saving)")
+		countDryRunMessage()
 	}
 
 	return nil
@@ -255,18 +464,36 @@ func SendTemplatedFollowUp(page *rod.Page, conn Connection, templateName string,
 		vars["{last_name}"] = nameParts[len(nameParts)-1]
 	}
 
+	// Never send a template whose variables we can't fill
+	t := templates.GetTemplate(templateName)
+	if t == nil {
+		return fmt.Errorf("template '%s' not found", templateName)
+	}
+	if missing := ValidateVariables(t, vars); len(missing) > 0 {
+		fallback := templates.GetTemplate(DefaultFollowUpTemplate)
+		if fallback == nil || templateName == DefaultFollowUpTemplate || len(ValidateVariables(fallback, vars)) > 0 {
+			return fmt.Errorf("template '%s' needs %s, which %s doesn't have", templateName, strings.Join(missing, ", "), conn.ProfileURL)
+		}
+		fmt.Printf("⚠️ Template '%s' needs %s - using '%s' instead\n", templateName, strings.Join(missing, ", "), DefaultFollowUpTemplate)
+		templateName = DefaultFollowUpTemplate
+	}
+
 	// Render template
 	content, err := templates.RenderTemplate(templateName, vars)
 	if err != nil {
 		return err
 	}
+	if leftover := ValidateRendered(content); len(leftover) > 0 {
+		return fmt.Errorf("rendered message still has placeholders %s - not sending", strings.Join(leftover, ", "))
+	}
 
 	fmt.Printf("📝 Using template: %s\n", templateName)
 	return SendFollowUpMessage(page, conn, content, tracker)
 }
 
 // BatchFollowUp sends follow-up messages to multiple connections
-func BatchFollowUp(page *rod.Page, connections []Connection, templateName string, templates *TemplateManager, tracker *Tracker, delayMinSec, delayMaxSec int) (int, int, error) {
+// onFailure (optional) is called for each message that fails
+func BatchFollowUp(page *rod.Page, connections []Connection, templateName string, templates *TemplateManager, tracker *Tracker, delayMinSec, delayMaxSec int, onFailure func(Connection, string, error)) (int, int, error) {
 	successCount := 0
 	failCount := 0
 
@@ -296,10 +523,18 @@ func BatchFollowUp(page *rod.Page, connections []Connection, templateName string
 
 		fmt.Printf("\n[%d/%d] Processing: %s\n", i+1, len(connections), conn.Name)
 
-		err := SendTemplatedFollowUp(page, conn, templateName, templates, tracker)
+		connTemplate := templateName
+		if templateName == AutoTemplate {
+			connTemplate = SelectTemplateForConnection(conn, templates)
+		}
+
+		err := SendTemplatedFollowUp(page, conn, connTemplate, templates, tracker)
 		if err != nil {
 			fmt.Printf("❌ Failed: %v\n", err)
 			failCount++
+			if onFailure != nil {
+				onFailure(conn, connTemplate, err)
+			}
 		} else {
 			successCount++
 			// Record action for rate limiting
@@ -320,33 +555,90 @@ func BatchFollowUp(page *rod.Page, connections []Connection, templateName string
 	return successCount, failCount, nil
 }
 
-// splitName splits a full name into parts
+// nameTitles are honorifics dropped from the front of a name ("Dr. Jane Smith")
+var nameTitles = map[string]bool{
+	"dr": true, "mr": true, "mrs": true, "ms": true, "miss": true, "mx": true,
+	"prof": true, "professor": true, "sir": true, "dame": true, "rev": true,
+	"herr": true, "frau": true, "mme": true, "mlle": true, "sr": true, "sra": true, "srta": true,
+}
+
+// nameSuffixes are generational suffixes and credentials dropped from the end of a name
+var nameSuffixes = map[string]bool{
+	"jr": true, "sr": true, "ii": true, "iii": true, "iv": true,
+	"phd": true, "md": true, "mba": true, "cpa": true, "pmp": true, "esq": true,
+	"msc": true, "bsc": true, "dds": true, "cfa": true,
+}
+
+// splitName splits a full name into parts, without honorifics, suffixes,
+// credentials after a comma ("Jane Smith, PhD") or parenthesised pronouns
+// Falls back to the whole name when nothing is left
 func splitName(name string) []string {
-	var parts []string
-	for _, p := range splitString(name, " ") {
-		if p != "" {
-			parts = append(parts, p)
-		}
+	cleaned := name
+	if i := strings.Index(cleaned, ","); i > 0 && onlySuffixes(cleaned[i+1:]) {
+		cleaned = cleaned[:i]
+	}
+	cleaned = strings.ReplaceAll(cleaned, ",", " ")
+	if i := strings.Index(cleaned, "("); i > 0 {
+		cleaned = cleaned[:i]
+	}
+
+	parts := strings.Fields(cleaned)
+	for len(parts) > 1 && nameTitles[nameKey(parts[0])] {
+		parts = parts[1:]
+	}
+	for len(parts) > 1 && nameSuffixes[nameKey(parts[len(parts)-1])] {
+		parts = parts[:len(parts)-1]
 	}
+	// "J. Robert Oppenheimer" goes by the middle name
+	for len(parts) > 2 && isInitial(parts[0]) {
+		parts = parts[1:]
+	}
+
 	if len(parts) == 0 {
 		return []string{name}
 	}
+	for i, p := range parts {
+		parts[i] = fixNameCase(p)
+	}
 	return parts
 }
 
-// splitString is a simple string splitter
-func splitString(s, sep string) []string {
-	var result []string
-	start := 0
-	for i := 0; i < len(s); i++ {
-		if i+len(sep) <= len(s) && s[i:i+len(sep)] == sep {
-			result = append(result, s[start:i])
-			start = i + len(sep)
-			i += len(sep) - 1
+// onlySuffixes reports whether text after a comma is just suffixes and credentials
+// ("PhD, MBA"), not the rest of the name as in "Smith, Jane"
+func onlySuffixes(text string) bool {
+	parts := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	for _, p := range parts {
+		if !nameSuffixes[nameKey(p)] {
+			return false
+		}
+	}
+	return len(parts) > 0
+}
+
+// nameKey lowercases a name token and drops its periods ("Ph.D." -> "phd")
+func nameKey(part string) string {
+	return strings.ToLower(strings.ReplaceAll(part, ".", ""))
+}
+
+// isInitial reports whether a name token is a lone initial ("J." or "J")
+func isInitial(part string) bool {
+	return utf8.RuneCountInString(strings.TrimSuffix(part, ".")) == 1
+}
+
+// fixNameCase capitalises names typed in all caps or all lowercase
+// ("JOSÉ" -> "José", "jean-luc" -> "Jean-Luc"); mixed case is left alone ("McDonald")
+func fixNameCase(part string) string {
+	if part != strings.ToUpper(part) && part != strings.ToLower(part) {
+		return part
+	}
+	segments := strings.Split(strings.ToLower(part), "-")
+	for i, seg := range segments {
+		r, size := utf8.DecodeRuneInString(seg)
+		if size > 0 {
+			segments[i] = string(unicode.ToUpper(r)) + seg[size:]
 		}
 	}
-	result = append(result, s[start:])
-	return result
+	return strings.Join(segments, "-")
 }
 
 // truncateString truncates a string with ellipsis
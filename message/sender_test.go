@@ -0,0 +1,32 @@
+package message
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"Jane Smith", []string{"Jane", "Smith"}},
+		{"Dr. Jane Smith", []string{"Jane", "Smith"}},
+		{"Jane Smith, PhD", []string{"Jane", "Smith"}},
+		{"Jane Smith, Ph.D., MBA", []string{"Jane", "Smith"}},
+		{"John Smith Jr.", []string{"John", "Smith"}},
+		{"J. Robert Oppenheimer", []string{"Robert", "Oppenheimer"}},
+		{"JOSÉ GARCÍA", []string{"José", "García"}},
+		{"jean-luc picard", []string{"Jean-Luc", "Picard"}},
+		{"Cher", []string{"Cher"}},
+		{"Smith, Jane", []string{"Smith", "Jane"}},
+		{"Alex Kim (they/them)", []string{"Alex", "Kim"}},
+		{"Mary McDonald", []string{"Mary", "McDonald"}},
+	}
+
+	for _, tt := range tests {
+		if got := splitName(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("splitName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
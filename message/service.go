@@ -2,8 +2,11 @@ package message
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
 )
 
 // MessagingService orchestrates all messaging operations
@@ -11,6 +14,30 @@ type MessagingService struct {
 	Page      *rod.Page
 	Tracker   *Tracker
 	Templates *TemplateManager
+
+	// MinHoursSinceConnected holds back follow-ups until a connection is this
+	// many hours old - messaging the minute someone accepts looks automated
+	MinHoursSinceConnected int
+
+	// InitiatedFilter, when set, limits follow-ups to connections it returns true for
+	// (e.g. only people who accepted one of our requests)
+	InitiatedFilter func(profileURL string) bool
+
+	// ShuffleOrder contacts ready connections in random order instead of
+	// newest-first, so who gets messaged when doesn't follow the list
+	ShuffleOrder bool
+
+	// SegmentFilter, when set, limits follow-ups to one segment of connections
+	// (e.g. everyone tagged "investor")
+	SegmentFilter func(profileURL string) bool
+
+	// OnSync is called with every tracked connection after a connection sync,
+	// e.g. to auto-tag new connections before segments are picked
+	OnSync func(connections []Connection)
+
+	// OnFailure, when set, is called for every follow-up that fails to send
+	// (e.g. to record it for a later retry)
+	OnFailure func(conn Connection, templateName string, err error)
 }
 
 // NewMessagingService creates a new messaging service
@@ -42,14 +69,101 @@ func (ms *MessagingService) SetDailyLimit(limit int) {
 	ms.Tracker.SetDailyLimit(limit)
 }
 
+// SetMinHoursSinceConnected sets how long after accepting a connection can be messaged
+func (ms *MessagingService) SetMinHoursSinceConnected(hours int) {
+	if hours < 0 {
+		hours = 0
+	}
+	ms.MinHoursSinceConnected = hours
+}
+
+// SetInitiatedFilter limits follow-ups to connections the filter accepts (nil = everyone)
+func (ms *MessagingService) SetInitiatedFilter(filter func(profileURL string) bool) {
+	ms.InitiatedFilter = filter
+}
+
+// SetShuffleOrder enables random contact order (false = list order, for debugging)
+func (ms *MessagingService) SetShuffleOrder(enabled bool) {
+	ms.ShuffleOrder = enabled
+}
+
+// SetSegmentFilter limits follow-ups to connections in a segment (nil = everyone)
+func (ms *MessagingService) SetSegmentFilter(filter func(profileURL string) bool) {
+	ms.SegmentFilter = filter
+}
+
+// SetOnSync sets the callback run after each connection sync
+func (ms *MessagingService) SetOnSync(onSync func(connections []Connection)) {
+	ms.OnSync = onSync
+}
+
+// SetOnFailure sets the callback run when a follow-up fails
+func (ms *MessagingService) SetOnFailure(onFailure func(conn Connection, templateName string, err error)) {
+	ms.OnFailure = onFailure
+}
+
 // SyncConnections detects and syncs new connections
 func (ms *MessagingService) SyncConnections(maxToScan int) (int, error) {
-	return SyncNewConnections(ms.Page, ms.Tracker, maxToScan)
+	count, err := SyncNewConnections(ms.Page, ms.Tracker, maxToScan)
+	if ms.OnSync != nil {
+		ms.OnSync(ms.Tracker.Connections)
+	}
+	return count, err
 }
 
-// GetUnmessagedConnections returns connections that haven't been messaged
+// GetUnmessagedConnections returns connections that haven't been messaged,
+// connected at least MinHoursSinceConnected hours ago and pass InitiatedFilter
+// and SegmentFilter. With ShuffleOrder they come back in random order
 func (ms *MessagingService) GetUnmessagedConnections() []Connection {
-	return ms.Tracker.GetUnmessagedConnections()
+	ready := ms.Tracker.GetUnmessagedConnectionsOlderThan(time.Duration(ms.MinHoursSinceConnected) * time.Hour)
+	if ms.ShuffleOrder {
+		ready = ShuffleConnections(ready)
+	}
+	if ms.SegmentFilter != nil {
+		var inSegment []Connection
+		for _, conn := range ready {
+			if ms.SegmentFilter(conn.ProfileURL) {
+				inSegment = append(inSegment, conn)
+			}
+		}
+		if skipped := len(ready) - len(inSegment); skipped > 0 {
+			fmt.Printf("ℹ️ Skipping %d connections outside the segment\n", skipped)
+		}
+		ready = inSegment
+	}
+	if ms.InitiatedFilter == nil {
+		return ready
+	}
+
+	var ours []Connection
+	for _, conn := range ready {
+		if ms.InitiatedFilter(conn.ProfileURL) {
+			ours = append(ours, conn)
+		}
+	}
+	if skipped := len(ready) - len(ours); skipped > 0 {
+		fmt.Printf("ℹ️ Skipping %d connections we didn't invite\n", skipped)
+	}
+	return ours
+}
+
+// ShuffleConnections returns the connections in random order, one entry per
+// profile. Uses the shared stealth RNG, so a fixed RandomSeed replays the order
+func ShuffleConnections(connections []Connection) []Connection {
+	seen := make(map[string]bool, len(connections))
+	shuffled := make([]Connection, 0, len(connections))
+	for _, conn := range connections {
+		if seen[conn.ProfileURL] {
+			continue
+		}
+		seen[conn.ProfileURL] = true
+		shuffled = append(shuffled, conn)
+	}
+
+	stealth.Rand().Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
 }
 
 // GetRecentUnmessaged returns recent connections that haven't been messaged
@@ -64,12 +178,19 @@ func (ms *MessagingService) GetConnectionsDaysAgo(days int) []Connection {
 
 // SendFollowUp sends a follow-up message to a connection
 func (ms *MessagingService) SendFollowUp(conn Connection, templateName string) error {
-	return SendTemplatedFollowUp(ms.Page, conn, templateName, ms.Templates, ms.Tracker)
+	if templateName == AutoTemplate {
+		templateName = SelectTemplateForConnection(conn, ms.Templates)
+	}
+	err := SendTemplatedFollowUp(ms.Page, conn, templateName, ms.Templates, ms.Tracker)
+	if err != nil && ms.OnFailure != nil {
+		ms.OnFailure(conn, templateName, err)
+	}
+	return err
 }
 
 // SendBatchFollowUps sends follow-up messages to multiple connections
 func (ms *MessagingService) SendBatchFollowUps(connections []Connection, templateName string, delayMinSec, delayMaxSec int) (int, int, error) {
-	return BatchFollowUp(ms.Page, connections, templateName, ms.Templates, ms.Tracker, delayMinSec, delayMaxSec)
+	return BatchFollowUp(ms.Page, connections, templateName, ms.Templates, ms.Tracker, delayMinSec, delayMaxSec, ms.OnFailure)
 }
 
 // SendCustomMessage sends a custom message to a connection
@@ -148,9 +269,14 @@ func (ms *MessagingService) FullWorkflow(templateName string, maxMessages int, d
 
 	// Step 3: Send follow-ups
 	fmt.Println("\n📨 Step 2: Sending follow-up messages...")
-	// Send follow-ups to all unmessaged connections (no days filter)
+	// Send follow-ups to unmessaged connections old enough to message
 	targets := ms.GetUnmessagedConnections()
 	if len(targets) == 0 {
+		ready := ms.Tracker.GetUnmessagedConnectionsOlderThan(time.Duration(ms.MinHoursSinceConnected) * time.Hour)
+		if waiting := len(ms.Tracker.GetUnmessagedConnections()); waiting > 0 && len(ready) == 0 {
+			fmt.Printf("ℹ️ %d unmessaged connections accepted less than %dh ago - messaging later\n", waiting, ms.MinHoursSinceConnected)
+			return nil
+		}
 		fmt.Println("ℹ️ No unmessaged connections found to message")
 		return nil
 	}
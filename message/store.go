@@ -0,0 +1,72 @@
+package message
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// messageStore, when set, records every sent message in the database and is
+// checked before each send so messages never go out closer together than the
+// configured minimum delay, even if the rate limiter's state was lost
+var messageStore *persistence.Store
+
+// SetMessageStore sets the database messages are recorded in and checked against
+func SetMessageStore(store *persistence.Store) {
+	messageStore = store
+}
+
+// checkMessageInterval refuses to send while the last stored message is more
+// recent than the minimum message delay
+func checkMessageInterval() error {
+	if messageStore == nil {
+		return nil
+	}
+
+	minInterval := stealth.GetConfig().MessageDelayMin
+	ok, wait, err := messageStore.CanSendMessageNow(minInterval)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("last message went out less than %ds ago (wait %v) - not sending", minInterval, wait.Round(time.Second))
+	}
+	return nil
+}
+
+// CountDryRunStats counts dry-run messages in daily_stats, the same way
+// dry-run connection requests are counted (kept in sync by main)
+var CountDryRunStats = false
+
+// countDryRunMessage counts a dry-run message in today's stats when enabled
+func countDryRunMessage() {
+	if messageStore == nil || !CountDryRunStats {
+		return
+	}
+	if err := messageStore.IncrementMessagesSent(); err != nil {
+		fmt.Printf("⚠️ Failed to count dry-run message: %v\n", err)
+	}
+}
+
+// recordMessage stores a sent message in the database
+func recordMessage(msg Message) {
+	if messageStore == nil {
+		return
+	}
+
+	err := messageStore.SaveMessage(&persistence.Message{
+		ConversationID: msg.ConversationID,
+		RecipientURL:   msg.RecipientURL,
+		RecipientName:  msg.RecipientName,
+		Content:        msg.Content,
+		TemplateName:   msg.TemplateName,
+		MessageType:    msg.MessageType,
+		Status:         msg.Status,
+		SentAt:         msg.SentAt,
+	})
+	if err != nil {
+		fmt.Printf("⚠️ Failed to record message in database: %v\n", err)
+	}
+}
@@ -4,11 +4,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/Nehilsa2/linkedin_automation/connect"
 )
 
 const TemplatesFile = "message_templates.json"
 
+// AutoTemplate selects a template per connection based on their headline
+const AutoTemplate = "auto"
+
+// DefaultFollowUpTemplate is used when no headline keyword matches
+const DefaultFollowUpTemplate = "follow_up_simple"
+
+// headlineTemplateRules maps headline keywords to templates, checked in order
+// Recruiters first: "Technical Recruiter" should not get the software template
+var headlineTemplateRules = []struct {
+	Template string
+	Keywords []string
+}{
+	{"follow_up_recruiter", []string{"recruiter", "recruiting", "talent acquisition", "talent partner", "hr ", "human resources", "headhunter"}},
+	{"follow_up_founder", []string{"founder", "co-founder", "ceo", "entrepreneur", "owner"}},
+	{"follow_up_software", []string{"software", "engineer", "developer", "programmer", "sde", "devops", "backend", "frontend", "full stack", "fullstack"}},
+}
+
 // TemplateManager manages message templates
 type TemplateManager struct {
 	Templates []Template `json:"templates"`
@@ -20,8 +41,8 @@ func DefaultTemplates() []Template {
 		{
 			Name:        "follow_up_generic",
 			Description: "Generic follow-up message for new connections",
-			Content:     "Hi {name}! Thanks for connecting. I noticed you're working at {company} - would love to learn more about your work. Looking forward to staying in touch!",
-			Variables:   []string{"{name}", "{company}"},
+			Content:     "Hi {name}! Thanks for connecting. {{if company}}I noticed you're working at {company} - would love to learn more about your work. {{end}}Looking forward to staying in touch!",
+			Variables:   []string{"{name}"},
 		},
 		{
 			Name:        "follow_up_software",
@@ -102,6 +123,7 @@ func (tm *TemplateManager) AddTemplate(t Template) error {
 	// Extract variables from content
 	t.Variables = extractVariables(t.Content)
 	tm.Templates = append(tm.Templates, t)
+	tm.warnIfTooLong(t.Name)
 	return tm.Save()
 }
 
@@ -111,12 +133,53 @@ func (tm *TemplateManager) UpdateTemplate(name string, content string) error {
 		if t.Name == name {
 			tm.Templates[i].Content = content
 			tm.Templates[i].Variables = extractVariables(content)
+			tm.warnIfTooLong(name)
 			return tm.Save()
 		}
 	}
 	return fmt.Errorf("template '%s' not found", name)
 }
 
+// sampleNoteVars are deliberately long-ish values for previewing rendered length
+var sampleNoteVars = map[string]string{
+	"{name}":        "Alexandra Montgomery",
+	"{first_name}":  "Alexandra",
+	"{last_name}":   "Montgomery",
+	"{company}":     "International Business Solutions",
+	"{headline}":    "Senior Software Engineer at International Business Solutions",
+	"{title}":       "Senior Software Engineer",
+	"{recent_post}": "Lessons learned migrating our monolith to event-driven services",
+}
+
+// ValidateLength renders a template with sample data and returns its length in
+// characters and whether it fits LinkedIn's connection-note limit
+// sampleVars overrides/extends the built-in sample values (nil uses them as-is)
+func (tm *TemplateManager) ValidateLength(name string, sampleVars map[string]string) (int, bool) {
+	t := tm.GetTemplate(name)
+	if t == nil {
+		return 0, false
+	}
+
+	vars := make(map[string]string, len(sampleNoteVars)+len(sampleVars))
+	for k, v := range sampleNoteVars {
+		vars[k] = v
+	}
+	for k, v := range sampleVars {
+		vars[k] = v
+	}
+
+	length := utf8.RuneCountInString(RenderContent(t.Content, vars))
+	return length, length <= connect.MaxNoteLength
+}
+
+// warnIfTooLong prints a warning when a template would not fit in a connection note
+func (tm *TemplateManager) warnIfTooLong(name string) {
+	if length, ok := tm.ValidateLength(name, nil); !ok {
+		fmt.Printf("⚠️ Template '%s' renders to ~%d characters - over the %d-character connection note limit, it would be truncated if used as a note\n",
+			name, length, connect.MaxNoteLength)
+	}
+}
+
 // DeleteTemplate removes a template
 func (tm *TemplateManager) DeleteTemplate(name string) error {
 	for i, t := range tm.Templates {
@@ -147,9 +210,38 @@ func (tm *TemplateManager) RenderTemplate(templateName string, vars map[string]s
 	return RenderContent(t.Content, vars), nil
 }
 
+// SelectTemplateForConnection picks the most relevant template for a connection's headline
+// Falls back to DefaultFollowUpTemplate when nothing matches, the matched template
+// does not exist, or it needs {company} and the company is unknown
+func SelectTemplateForConnection(conn Connection, tm *TemplateManager) string {
+	headline := strings.ToLower(conn.Headline) + " "
+
+	for _, rule := range headlineTemplateRules {
+		for _, kw := range rule.Keywords {
+			if !strings.Contains(headline, kw) {
+				continue
+			}
+			t := tm.GetTemplate(rule.Template)
+			if t == nil {
+				break
+			}
+			if conn.Company == "" && slices.Contains(extractVariables(t.Content), "{company}") {
+				break
+			}
+			return rule.Template
+		}
+	}
+
+	return DefaultFollowUpTemplate
+}
+
 // RenderContent fills variables in any content string
+// Conditional blocks are resolved first: {{if company}}...{{end}} is kept only
+// when {company} has a non-empty value, and dropped entirely otherwise
 func RenderContent(content string, vars map[string]string) string {
-	result := content
+	result := renderConditionals(content, func(name string) bool {
+		return strings.TrimSpace(vars["{"+name+"}"]) != ""
+	})
 	for key, value := range vars {
 		// Support both {var} and {VAR} style
 		result = strings.ReplaceAll(result, key, value)
@@ -158,8 +250,75 @@ func RenderContent(content string, vars map[string]string) string {
 	return result
 }
 
+// Conditional block markers: {{if var}}...{{end}}
+const (
+	condOpen = "{{if "
+	condEnd  = "{{end}}"
+)
+
+// renderConditionals keeps or drops each {{if var}}...{{end}} block depending
+// on keep(var), recursing into kept blocks so blocks can be nested
+// An unclosed block is left as-is so ValidateRendered catches it
+func renderConditionals(content string, keep func(name string) bool) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(content, condOpen)
+		if start < 0 {
+			b.WriteString(content)
+			return b.String()
+		}
+		b.WriteString(content[:start])
+
+		rest := content[start+len(condOpen):]
+		closing := strings.Index(rest, "}}")
+		if closing < 0 {
+			b.WriteString(content[start:])
+			return b.String()
+		}
+		body, after, ok := splitBlock(rest[closing+2:])
+		if !ok {
+			b.WriteString(content[start:])
+			return b.String()
+		}
+
+		name := strings.ToLower(strings.Trim(strings.TrimSpace(rest[:closing]), "{}"))
+		if keep(name) {
+			b.WriteString(renderConditionals(body, keep))
+		} else if b.Len() == 0 || strings.HasSuffix(b.String(), " ") {
+			// Don't leave a double space where the block was
+			after = strings.TrimLeft(after, " ")
+		}
+		content = after
+	}
+}
+
+// splitBlock splits s at the {{end}} closing an already-opened block,
+// skipping over nested blocks
+func splitBlock(s string) (body, rest string, ok bool) {
+	depth := 0
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], condOpen):
+			depth++
+			i += len(condOpen)
+		case strings.HasPrefix(s[i:], condEnd):
+			if depth == 0 {
+				return s[:i], s[i+len(condEnd):], true
+			}
+			depth--
+			i += len(condEnd)
+		default:
+			i++
+		}
+	}
+	return "", "", false
+}
+
 // extractVariables finds all {variable} patterns in content
+// Variables only used inside {{if}} blocks are optional and not returned
 func extractVariables(content string) []string {
+	content = renderConditionals(content, func(string) bool { return false })
+
 	var vars []string
 	seen := make(map[string]bool)
 
@@ -184,16 +343,28 @@ func extractVariables(content string) []string {
 }
 
 // ValidateVariables checks if all required variables are provided
+// A variable with an empty value counts as missing - it would render as an awkward blank.
+// Templates without a Variables list are checked against the placeholders in their content.
 func ValidateVariables(template *Template, vars map[string]string) []string {
+	required := template.Variables
+	if len(required) == 0 {
+		required = extractVariables(template.Content)
+	}
+
 	var missing []string
-	for _, v := range template.Variables {
-		if _, ok := vars[v]; !ok {
+	for _, v := range required {
+		if val, ok := vars[v]; !ok || strings.TrimSpace(val) == "" {
 			missing = append(missing, v)
 		}
 	}
 	return missing
 }
 
+// ValidateRendered returns any {placeholders} left in rendered content
+func ValidateRendered(content string) []string {
+	return extractVariables(content)
+}
+
 // PrintTemplates displays all templates nicely
 func (tm *TemplateManager) PrintTemplates() {
 	fmt.Println("\n📝 Available Message Templates:")
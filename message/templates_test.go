@@ -0,0 +1,67 @@
+package message
+
+import "testing"
+
+func TestRenderConditionals(t *testing.T) {
+	known := map[string]bool{"company": true, "title": true}
+	keep := func(name string) bool { return known[name] }
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"kept block", "Hi{{if company}} at {company}{{end}}!", "Hi at {company}!"},
+		{"dropped block", "Hi{{if city}} in {city}{{end}}!", "Hi!"},
+		{"no blocks", "Hi {name}!", "Hi {name}!"},
+		{"variable name is case-insensitive", "{{if Company}}at {company}{{end}}", "at {company}"},
+		{"sibling blocks", "{{if city}}In {city}. {{end}}{{if company}}At {company}.{{end}}", "At {company}."},
+
+		// Dropping a block between two spaces leaves one
+		{"double space after dropped block", "Hi {{if city}}from {city}{{end}} there", "Hi there"},
+		{"trailing space inside dropped block", "Hi {{if city}}from {city} {{end}}there", "Hi there"},
+		{"dropped block at the start", "{{if city}}In {city}.{{end}} Hello", "Hello"},
+		{"no space to collapse", "Hi{{if city}} from {city}{{end}} there", "Hi there"},
+
+		{"nested, both kept", "A{{if company}} at {company}{{if title}} as {title}{{end}}{{end}}.", "A at {company} as {title}."},
+		{"nested, inner dropped", "A{{if company}} at {company}{{if city}} in {city}{{end}}{{end}}.", "A at {company}."},
+		{"nested, outer dropped", "A{{if city}} in {city}{{if company}} at {company}{{end}}{{end}}.", "A."},
+
+		// Unclosed blocks are left for ValidateRendered to catch
+		{"unclosed block", "Hi {{if company}}at {company}", "Hi {{if company}}at {company}"},
+		{"unclosed marker", "Hi {{if company", "Hi {{if company"},
+		{"unclosed outer block", "{{if company}}x{{if title}}y{{end}}", "{{if company}}x{{if title}}y{{end}}"},
+		{"unclosed after a closed block", "{{if company}}x{{end}} {{if title}}y", "x {{if title}}y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderConditionals(tt.in, keep); got != tt.want {
+				t.Errorf("renderConditionals(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitBlock(t *testing.T) {
+	tests := []struct {
+		in         string
+		body, rest string
+		ok         bool
+	}{
+		{"body{{end}}rest", "body", "rest", true},
+		{"{{end}}", "", "", true},
+		{"a{{if x}}b{{end}}c{{end}}d", "a{{if x}}b{{end}}c", "d", true},
+		{"a{{end}}b{{end}}", "a", "b{{end}}", true},
+		{"no end", "", "", false},
+		{"a{{if x}}b{{end}}", "", "", false},
+	}
+
+	for _, tt := range tests {
+		body, rest, ok := splitBlock(tt.in)
+		if body != tt.body || rest != tt.rest || ok != tt.ok {
+			t.Errorf("splitBlock(%q) = %q, %q, %v, want %q, %q, %v",
+				tt.in, body, rest, ok, tt.body, tt.rest, tt.ok)
+		}
+	}
+}
@@ -4,8 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
 )
 
 const (
@@ -104,7 +105,7 @@ func (t *Tracker) AddMessage(msg Message) {
 func (t *Tracker) AddConnection(conn Connection) {
 	// Check if already exists
 	for i, existing := range t.Connections {
-		if normalizeURL(existing.ProfileURL) == normalizeURL(conn.ProfileURL) {
+		if linkedinurl.Canonicalize(existing.ProfileURL) == linkedinurl.Canonicalize(conn.ProfileURL) {
 			t.Connections[i] = conn
 			return
 		}
@@ -114,9 +115,9 @@ func (t *Tracker) AddConnection(conn Connection) {
 
 // GetConnection retrieves a connection by profile URL
 func (t *Tracker) GetConnection(profileURL string) *Connection {
-	normalized := normalizeURL(profileURL)
+	normalized := linkedinurl.Canonicalize(profileURL)
 	for i, conn := range t.Connections {
-		if normalizeURL(conn.ProfileURL) == normalized {
+		if linkedinurl.Canonicalize(conn.ProfileURL) == normalized {
 			return &t.Connections[i]
 		}
 	}
@@ -125,9 +126,9 @@ func (t *Tracker) GetConnection(profileURL string) *Connection {
 
 // HasMessaged checks if we've already messaged this person
 func (t *Tracker) HasMessaged(profileURL string) bool {
-	normalized := normalizeURL(profileURL)
+	normalized := linkedinurl.Canonicalize(profileURL)
 	for _, msg := range t.Messages {
-		if normalizeURL(msg.RecipientURL) == normalized {
+		if linkedinurl.Canonicalize(msg.RecipientURL) == normalized {
 			return true
 		}
 	}
@@ -145,11 +146,24 @@ func (t *Tracker) GetUnmessagedConnections() []Connection {
 	return unmessaged
 }
 
+// GetUnmessagedConnectionsOlderThan returns unmessaged connections that
+// connected at least minAge ago
+func (t *Tracker) GetUnmessagedConnectionsOlderThan(minAge time.Duration) []Connection {
+	cutoff := time.Now().Add(-minAge)
+	var ready []Connection
+	for _, conn := range t.GetUnmessagedConnections() {
+		if !conn.ConnectedAt.After(cutoff) {
+			ready = append(ready, conn)
+		}
+	}
+	return ready
+}
+
 // MarkConnectionMessaged marks a connection as having been messaged
 func (t *Tracker) MarkConnectionMessaged(profileURL string) {
-	normalized := normalizeURL(profileURL)
+	normalized := linkedinurl.Canonicalize(profileURL)
 	for i, conn := range t.Connections {
-		if normalizeURL(conn.ProfileURL) == normalized {
+		if linkedinurl.Canonicalize(conn.ProfileURL) == normalized {
 			t.Connections[i].HasMessaged = true
 			t.Connections[i].LastMessageAt = time.Now()
 			return
@@ -174,12 +188,3 @@ func (t *Tracker) GetStats() MessageStats {
 		Remaining:     t.RemainingToday(),
 	}
 }
-
-// normalizeURL normalizes LinkedIn URLs for comparison
-func normalizeURL(url string) string {
-	url = strings.TrimSuffix(url, "/")
-	url = strings.TrimPrefix(url, "https://")
-	url = strings.TrimPrefix(url, "http://")
-	url = strings.TrimPrefix(url, "www.")
-	return strings.ToLower(url)
-}
@@ -10,8 +10,10 @@ type Message struct {
 	Content        string    `json:"content"`
 	TemplateName   string    `json:"template_name,omitempty"`
 	SentAt         time.Time `json:"sent_at"`
-	Status         string    `json:"status"`       // "sent", "delivered", "read", "failed"
-	MessageType    string    `json:"message_type"` // "follow_up", "initial", "reply"
+	Status         string    `json:"status"`                // "sent", "delivered", "read", "failed"
+	MessageType    string    `json:"message_type"`          // "follow_up", "initial", "reply"
+	SenderName     string    `json:"sender_name,omitempty"` // Set on messages read back from a thread
+	TimeText       string    `json:"time_text,omitempty"`   // Timestamp as LinkedIn shows it ("10:42 AM", "Today")
 }
 
 // Connection represents a LinkedIn connection
@@ -0,0 +1,191 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
+)
+
+// BlocklistEntry is a profile or company that must never be contacted
+type BlocklistEntry struct {
+	ID         int64     `json:"id"`
+	ProfileURL string    `json:"profile_url,omitempty"`
+	Company    string    `json:"company,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// profileKey normalizes a profile URL for storage and lookups (blocklist,
+// profile viewers) so any form of the link matches
+// Falls back to the canonical form for URLs that aren't /in/ profiles
+func profileKey(profileURL string) string {
+	if canonical, err := canonicalProfileURL(profileURL); err == nil {
+		return canonical
+	}
+	return linkedinurl.Canonicalize(profileURL)
+}
+
+// AddToBlocklist adds a profile to the do-not-contact list
+// Any matching search result is marked processed so it isn't picked up again
+func (s *Store) AddToBlocklist(profileURL, reason string) error {
+	key := profileKey(profileURL)
+	if key == "" {
+		return fmt.Errorf("empty profile URL")
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO blocklist (profile_url, reason) VALUES (?, ?)
+		ON CONFLICT(profile_url) DO UPDATE SET reason = excluded.reason
+	`, key, reason)
+	if err != nil {
+		return fmt.Errorf("failed to add to blocklist: %w", err)
+	}
+
+	s.MarkPersonProcessed(profileURL)
+	if key != profileURL {
+		s.MarkPersonProcessed(key)
+	}
+	return nil
+}
+
+// AddCompanyToBlocklist blocks everyone whose company matches (case-insensitive)
+func (s *Store) AddCompanyToBlocklist(company, reason string) error {
+	company = strings.TrimSpace(company)
+	if company == "" {
+		return fmt.Errorf("empty company name")
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO blocklist (company, reason) VALUES (?, ?)
+		ON CONFLICT(company) DO UPDATE SET reason = excluded.reason
+	`, strings.ToLower(company), reason)
+	if err != nil {
+		return fmt.Errorf("failed to add company to blocklist: %w", err)
+	}
+
+	// Mark pending search results from that company as processed
+	_, err = s.db.Exec(`
+		UPDATE people_search_results
+		SET processed = TRUE, processed_at = CURRENT_TIMESTAMP
+		WHERE LOWER(company) = ? AND processed = FALSE
+	`, strings.ToLower(company))
+	return err
+}
+
+// RemoveFromBlocklist removes a profile URL or company from the blocklist
+func (s *Store) RemoveFromBlocklist(profileURLOrCompany string) error {
+	_, err := s.db.Exec(`
+		DELETE FROM blocklist WHERE profile_url = ? OR company = ?
+	`, profileKey(profileURLOrCompany), strings.ToLower(strings.TrimSpace(profileURLOrCompany)))
+	return err
+}
+
+// IsBlocked returns true if the profile, or the company recorded for it, is blocked
+func (s *Store) IsBlocked(profileURL string) (bool, error) {
+	_, blocked, err := s.blockReason(profileURL, "")
+	return blocked, err
+}
+
+// BlockReason reports whether a profile/company is blocked and why
+// company may be empty, in which case the company stored for the profile
+// (connections, connection requests or search results) is used
+func (s *Store) BlockReason(profileURL, company string) (string, bool) {
+	reason, blocked, err := s.blockReason(profileURL, company)
+	if err != nil {
+		fmt.Printf("⚠️ Blocklist lookup failed: %v\n", err)
+	}
+	return reason, blocked
+}
+
+// blockReason looks up a profile by URL first, then by company
+func (s *Store) blockReason(profileURL, company string) (string, bool, error) {
+	key := profileKey(profileURL)
+
+	var reason sql.NullString
+	err := s.db.QueryRow(`SELECT reason FROM blocklist WHERE profile_url = ?`, key).Scan(&reason)
+	if err == nil {
+		return reason.String, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", false, err
+	}
+
+	companies := []string{}
+	if company != "" {
+		companies = append(companies, company)
+	}
+	known, err := s.companiesForProfile(profileURL, key)
+	if err != nil {
+		return "", false, err
+	}
+	companies = append(companies, known...)
+
+	for _, c := range companies {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		err := s.db.QueryRow(`SELECT reason FROM blocklist WHERE company = ?`, c).Scan(&reason)
+		if err == nil {
+			return reason.String, true, nil
+		}
+		if err != sql.ErrNoRows {
+			return "", false, err
+		}
+	}
+
+	return "", false, nil
+}
+
+// companiesForProfile returns the company names recorded for a profile
+func (s *Store) companiesForProfile(profileURL, key string) ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT company FROM connections WHERE profile_url IN (?, ?)
+		UNION SELECT company FROM connection_requests WHERE profile_url IN (?, ?)
+		UNION SELECT company FROM people_search_results WHERE profile_url IN (?, ?)
+	`, profileURL, key, profileURL, key, profileURL, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var companies []string
+	for rows.Next() {
+		var company sql.NullString
+		if err := rows.Scan(&company); err != nil {
+			return nil, err
+		}
+		if company.Valid && company.String != "" {
+			companies = append(companies, company.String)
+		}
+	}
+	return companies, rows.Err()
+}
+
+// GetBlocklist returns all blocklist entries
+func (s *Store) GetBlocklist() ([]BlocklistEntry, error) {
+	rows, err := s.db.Query(`
+		SELECT id, profile_url, company, reason, created_at FROM blocklist ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []BlocklistEntry
+	for rows.Next() {
+		var e BlocklistEntry
+		var profileURL, company, reason sql.NullString
+		if err := rows.Scan(&e.ID, &profileURL, &company, &reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.ProfileURL = profileURL.String
+		e.Company = company.String
+		e.Reason = reason.String
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
 )
 
 // ConnectionRequest represents a sent connection request
@@ -31,6 +33,10 @@ const (
 	StatusWithdrawn = "withdrawn"
 )
 
+// WithdrawResendCooldown is how long LinkedIn blocks re-inviting someone
+// after their invitation was withdrawn
+const WithdrawResendCooldown = 21 * 24 * time.Hour
+
 // SaveConnectionRequest saves or updates a connection request
 func (s *Store) SaveConnectionRequest(req *ConnectionRequest) error {
 	if req.Status == "" {
@@ -40,7 +46,18 @@ func (s *Store) SaveConnectionRequest(req *ConnectionRequest) error {
 		req.SentAt = time.Now()
 	}
 
-	result, err := s.db.Exec(`
+	// Only a genuinely new invite counts toward today's stats: re-saving an
+	// existing request (retries, status updates) must not double-count, but
+	// inviting again after a withdrawal is a new send
+	var previous string
+	err := s.db.QueryRow(`SELECT status FROM connection_requests WHERE profile_url = ?`, req.ProfileURL).Scan(&previous)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check existing connection request: %w", err)
+	}
+	inserted := err == sql.ErrNoRows
+	newSend := inserted || (previous == StatusWithdrawn && req.Status == StatusPending)
+
+	id, err := s.db.insertID(`
 		INSERT INTO connection_requests (
 			profile_url, name, headline, company, note, status, 
 			sent_at, source, search_keyword
@@ -49,6 +66,10 @@ func (s *Store) SaveConnectionRequest(req *ConnectionRequest) error {
 			name = COALESCE(excluded.name, connection_requests.name),
 			headline = COALESCE(excluded.headline, connection_requests.headline),
 			company = COALESCE(excluded.company, connection_requests.company),
+			note = CASE WHEN connection_requests.status = 'withdrawn'
+				THEN excluded.note ELSE connection_requests.note END,
+			sent_at = CASE WHEN connection_requests.status = 'withdrawn'
+				THEN excluded.sent_at ELSE connection_requests.sent_at END,
 			status = excluded.status,
 			updated_at = CURRENT_TIMESTAMP
 	`, req.ProfileURL, req.Name, req.Headline, req.Company, req.Note,
@@ -58,20 +79,22 @@ func (s *Store) SaveConnectionRequest(req *ConnectionRequest) error {
 		return fmt.Errorf("failed to save connection request: %w", err)
 	}
 
-	if req.ID == 0 {
-		id, _ := result.LastInsertId()
+	// On the update path SQLite's last insert id belongs to some other row
+	if req.ID == 0 && inserted {
 		req.ID = id
 	}
 
 	// Update daily stats
-	s.incrementDailyStat("connections_sent")
+	if newSend {
+		s.incrementDailyStat("connections_sent")
+	}
 
 	return nil
 }
 
 // GetConnectionRequest retrieves a connection request by profile URL
 func (s *Store) GetConnectionRequest(profileURL string) (*ConnectionRequest, error) {
-	normalized := normalizeURL(profileURL)
+	normalized := linkedinurl.Canonicalize(profileURL)
 
 	row := s.db.QueryRow(`
 		SELECT id, profile_url, name, headline, company, note, status,
@@ -127,12 +150,20 @@ func (s *Store) GetConnectionRequest(profileURL string) (*ConnectionRequest, err
 }
 
 // HasSentRequest checks if a connection request was already sent
+// A request withdrawn more than WithdrawResendCooldown ago no longer counts,
+// so the profile can be invited again
 func (s *Store) HasSentRequest(profileURL string) (bool, error) {
 	req, err := s.GetConnectionRequest(profileURL)
 	if err != nil {
 		return false, err
 	}
-	return req != nil, nil
+	if req == nil {
+		return false, nil
+	}
+	if req.Status == StatusWithdrawn && time.Since(req.UpdatedAt) >= WithdrawResendCooldown {
+		return false, nil
+	}
+	return true, nil
 }
 
 // UpdateRequestStatus updates the status of a connection request
@@ -157,6 +188,13 @@ func (s *Store) GetPendingRequests() ([]ConnectionRequest, error) {
 	return s.getRequestsByStatus(StatusPending)
 }
 
+// CountPendingRequests returns how many connection requests are still pending
+func (s *Store) CountPendingRequests() (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM connection_requests WHERE status = ?`, StatusPending).Scan(&count)
+	return count, err
+}
+
 // GetAcceptedRequests returns all accepted connection requests
 func (s *Store) GetAcceptedRequests() ([]ConnectionRequest, error) {
 	return s.getRequestsByStatus(StatusAccepted)
@@ -191,6 +229,41 @@ func (s *Store) GetTodayRequestCount() (int, error) {
 	return count, err
 }
 
+// CountRequestsToCompany returns how many connection requests were sent to
+// people at a company since the given time. The company is matched case-insensitively
+// against the request itself or the profile's search result, since requests
+// saved from a search usually have no company of their own.
+func (s *Store) CountRequestsToCompany(company string, since time.Time) (int, error) {
+	company = strings.ToLower(strings.TrimSpace(company))
+	if company == "" {
+		return 0, nil
+	}
+
+	var count int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM connection_requests r
+		WHERE r.sent_at >= ? AND (
+			LOWER(TRIM(r.company)) = ?
+			OR r.profile_url IN (
+				SELECT profile_url FROM people_search_results WHERE LOWER(TRIM(company)) = ?
+			)
+		)
+	`, since, company, company).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count requests to company: %w", err)
+	}
+	return count, nil
+}
+
+// CompanyForProfile returns the company recorded for a profile ("" if unknown)
+func (s *Store) CompanyForProfile(profileURL string) (string, error) {
+	companies, err := s.companiesForProfile(profileURL, profileKey(profileURL))
+	if err != nil || len(companies) == 0 {
+		return "", err
+	}
+	return companies[0], nil
+}
+
 // GetAllConnectionRequests returns all connection requests with optional filters
 func (s *Store) GetAllConnectionRequests(limit, offset int) ([]ConnectionRequest, error) {
 	query := `
@@ -318,12 +391,3 @@ func (s *Store) GetConnectionRequestStats(dailyLimit int) (*ConnectionRequestSta
 
 	return stats, nil
 }
-
-// normalizeURL normalizes LinkedIn URLs for comparison
-func normalizeURL(url string) string {
-	url = strings.TrimSuffix(url, "/")
-	url = strings.TrimPrefix(url, "https://")
-	url = strings.TrimPrefix(url, "http://")
-	url = strings.TrimPrefix(url, "www.")
-	return strings.ToLower(url)
-}
@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DetectionLogEntry is one detection and what matched it
+type DetectionLogEntry struct {
+	ID        int64     `json:"id"`
+	ErrorType string    `json:"error_type"`
+	Source    string    `json:"source"`  // "url", "text" or "dom"
+	Pattern   string    `json:"pattern"` // URL/text pattern or DOM check
+	Snippet   string    `json:"snippet,omitempty"`
+	PageURL   string    `json:"page_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DetectionStat counts how often one pattern fired
+type DetectionStat struct {
+	ErrorType string `json:"error_type"`
+	Source    string `json:"source"`
+	Pattern   string `json:"pattern"`
+	Count     int    `json:"count"`
+}
+
+// LogDetection records a detection (implements stealth.DetectionLogger)
+func (s *Store) LogDetection(errType, source, pattern, snippet, pageURL string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO detection_log (error_type, source, pattern, snippet, page_url, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, errType, source, pattern, snippet, pageURL, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to log detection: %w", err)
+	}
+	return nil
+}
+
+// GetDetectionStats counts detections by type and pattern, most frequent first
+func (s *Store) GetDetectionStats() ([]DetectionStat, error) {
+	rows, err := s.db.Query(`
+		SELECT error_type, source, pattern, COUNT(*)
+		FROM detection_log
+		GROUP BY error_type, source, pattern
+		ORDER BY COUNT(*) DESC, error_type
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []DetectionStat
+	for rows.Next() {
+		var stat DetectionStat
+		var source, pattern sql.NullString
+		if err := rows.Scan(&stat.ErrorType, &source, &pattern, &stat.Count); err != nil {
+			return nil, err
+		}
+		stat.Source = source.String
+		stat.Pattern = pattern.String
+		stats = append(stats, stat)
+	}
+	return stats, rows.Err()
+}
+
+// GetRecentDetections returns the latest detections of errType ("" = any type),
+// newest first, so their snippets can be checked for false positives
+func (s *Store) GetRecentDetections(errType string, limit int) ([]DetectionLogEntry, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	query := `
+		SELECT id, error_type, source, pattern, snippet, page_url, created_at
+		FROM detection_log
+	`
+	args := []interface{}{}
+	if errType != "" {
+		query += " WHERE error_type = ?"
+		args = append(args, errType)
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []DetectionLogEntry
+	for rows.Next() {
+		var e DetectionLogEntry
+		var source, pattern, snippet, pageURL sql.NullString
+		if err := rows.Scan(&e.ID, &e.ErrorType, &source, &pattern, &snippet, &pageURL, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Source = source.String
+		e.Pattern = pattern.String
+		e.Snippet = snippet.String
+		e.PageURL = pageURL.String
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,149 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect identifies the SQL flavour spoken by the underlying database
+type Dialect string
+
+const (
+	DialectSQLite   Dialect = "sqlite"
+	DialectPostgres Dialect = "postgres"
+)
+
+// dialectForDriver maps a database/sql driver name to its dialect
+func dialectForDriver(driver string) (Dialect, error) {
+	switch driver {
+	case "sqlite", "sqlite3":
+		return DialectSQLite, nil
+	case "postgres", "pgx":
+		return DialectPostgres, nil
+	default:
+		return "", fmt.Errorf("unsupported database driver: %s", driver)
+	}
+}
+
+// Rebind converts ? placeholders to the dialect's placeholder style ($1, $2... for Postgres)
+// Placeholders inside quoted string literals are left alone
+func (d Dialect) Rebind(query string) string {
+	if d != DialectPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+
+	n := 0
+	inQuote := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			inQuote = !inQuote
+			b.WriteByte(c)
+		case c == '?' && !inQuote:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// Schema converts SQLite DDL to the dialect's types
+func (d Dialect) Schema(ddl string) string {
+	if d != DialectPostgres {
+		return ddl
+	}
+	ddl = strings.ReplaceAll(ddl, "INTEGER PRIMARY KEY AUTOINCREMENT", "BIGSERIAL PRIMARY KEY")
+	ddl = strings.ReplaceAll(ddl, "DATETIME", "TIMESTAMP")
+	return ddl
+}
+
+// AddColumn returns an idempotent ALTER TABLE ... ADD COLUMN statement where supported
+// SQLite has no IF NOT EXISTS here, so callers ignore its "duplicate column" error
+func (d Dialect) AddColumn(table, columnDef string) string {
+	if d == DialectPostgres {
+		return fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, d.Schema(columnDef))
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, columnDef)
+}
+
+// sqlDB wraps *sql.DB so every query is rebound for the active dialect
+type sqlDB struct {
+	*sql.DB
+	dialect Dialect
+}
+
+// Exec runs a statement with dialect placeholders
+func (db *sqlDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.Exec(db.dialect.Rebind(query), args...)
+}
+
+// Query runs a query with dialect placeholders
+func (db *sqlDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.Query(db.dialect.Rebind(query), args...)
+}
+
+// QueryRow runs a single-row query with dialect placeholders
+func (db *sqlDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRow(db.dialect.Rebind(query), args...)
+}
+
+// Begin starts a dialect-aware transaction
+func (db *sqlDB) Begin() (*Tx, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, dialect: db.dialect}, nil
+}
+
+// Tx wraps *sql.Tx so statements inside transactions are rebound too
+type Tx struct {
+	*sql.Tx
+	dialect Dialect
+}
+
+// Exec runs a statement with dialect placeholders
+func (tx *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.Rebind(query), args...)
+}
+
+// Query runs a query with dialect placeholders
+func (tx *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.dialect.Rebind(query), args...)
+}
+
+// QueryRow runs a single-row query with dialect placeholders
+func (tx *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.Rebind(query), args...)
+}
+
+// Prepare prepares a statement with dialect placeholders
+func (tx *Tx) Prepare(query string) (*sql.Stmt, error) {
+	return tx.Tx.Prepare(tx.dialect.Rebind(query))
+}
+
+// insertID runs an INSERT and returns the new row's id
+// Postgres drivers don't support LastInsertId, so use RETURNING there
+func (db *sqlDB) insertID(query string, args ...interface{}) (int64, error) {
+	if db.dialect == DialectPostgres {
+		var id int64
+		err := db.QueryRow(strings.TrimSpace(query)+" RETURNING id", args...).Scan(&id)
+		return id, err
+	}
+
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	id, _ := result.LastInsertId()
+	return id, nil
+}
@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event is one entry of the audit trail
+type Event struct {
+	ID        int64                  `json:"id"`
+	Category  string                 `json:"category"`
+	Message   string                 `json:"message"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// EventWorkflow is the category of workflow start/pause/complete/fail events
+const EventWorkflow = "workflow"
+
+// LogEvent appends an event to the audit trail
+func (s *Store) LogEvent(category, message string, metadata map[string]interface{}) error {
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		metadataJSON = []byte("{}")
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO events (category, message, metadata, created_at)
+		VALUES (?, ?, ?, ?)
+	`, category, message, string(metadataJSON), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to log event: %w", err)
+	}
+	return nil
+}
+
+// GetRecentEvents returns the latest events, newest first (limit <= 0 = 50)
+func (s *Store) GetRecentEvents(limit int) ([]Event, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, category, message, metadata, created_at
+		FROM events
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		var metadataJSON sql.NullString
+		if err := rows.Scan(&e.ID, &e.Category, &e.Message, &metadataJSON, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if metadataJSON.Valid && metadataJSON.String != "" {
+			json.Unmarshal([]byte(metadataJSON.String), &e.Metadata)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
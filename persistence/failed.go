@@ -0,0 +1,121 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// FailedAction is a connect or message that failed, kept so it can be retried
+//
+// WHY KEEP FAILURES:
+// - A timeout or an overlay stealing a click fails one attempt, not the lead
+// - Without a record the profile is just counted as failed and never tried again
+// - Permanent failures (profile gone, already connected) are kept but never retried
+type FailedAction struct {
+	ID            int64     `json:"id"`
+	ActionType    string    `json:"action_type"` // QueueActionConnect, QueueActionMessage
+	ProfileURL    string    `json:"profile_url"`
+	Payload       string    `json:"payload,omitempty"` // Note or template name
+	Reason        string    `json:"reason"`
+	Retryable     bool      `json:"retryable"`
+	Attempts      int       `json:"attempts"`
+	Status        string    `json:"status"`
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Failed action statuses
+const (
+	FailedStatusPending  = "pending"  // Waiting for a retry (if retryable)
+	FailedStatusResolved = "resolved" // A later attempt succeeded
+	FailedStatusGivenUp  = "given_up" // Permanent failure or out of retries
+)
+
+// RecordFailedAction records a failed attempt at an action
+// Repeated failures for the same profile and action add to its attempt count;
+// a permanent failure is marked given up straight away
+func (s *Store) RecordFailedAction(actionType, profileURL, payload, reason string, retryable bool) error {
+	status := FailedStatusPending
+	if !retryable {
+		status = FailedStatusGivenUp
+	}
+	now := time.Now()
+
+	_, err := s.db.Exec(`
+		INSERT INTO failed_actions (
+			action_type, profile_url, payload, reason, retryable, attempts,
+			status, last_attempt_at, created_at
+		) VALUES (?, ?, ?, ?, ?, 1, ?, ?, ?)
+		ON CONFLICT(action_type, profile_url) DO UPDATE SET
+			payload = excluded.payload,
+			reason = excluded.reason,
+			retryable = excluded.retryable,
+			attempts = failed_actions.attempts + 1,
+			status = excluded.status,
+			last_attempt_at = excluded.last_attempt_at
+	`, actionType, profileURL, payload, reason, retryable, status, now, now)
+	if err != nil {
+		return fmt.Errorf("failed to record failed action: %w", err)
+	}
+	return nil
+}
+
+// GetRetryableActions returns pending retryable failures with fewer than
+// maxAttempts attempts, least recently tried first
+func (s *Store) GetRetryableActions(maxAttempts, limit int) ([]FailedAction, error) {
+	if limit <= 0 {
+		limit = 1000
+	}
+	rows, err := s.db.Query(`
+		SELECT id, action_type, profile_url, payload, reason, retryable, attempts,
+			   status, last_attempt_at, created_at
+		FROM failed_actions
+		WHERE status = ? AND retryable = ? AND attempts < ?
+		ORDER BY last_attempt_at ASC, id ASC
+		LIMIT ?
+	`, FailedStatusPending, true, maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFailedActions(rows)
+}
+
+// GiveUpExhaustedActions marks pending failures that used up maxAttempts as given up
+func (s *Store) GiveUpExhaustedActions(maxAttempts int) (int64, error) {
+	res, err := s.db.Exec(`
+		UPDATE failed_actions SET status = ? WHERE status = ? AND attempts >= ?
+	`, FailedStatusGivenUp, FailedStatusPending, maxAttempts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to give up exhausted actions: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// ResolveFailedAction marks a failure as resolved once the action went through
+func (s *Store) ResolveFailedAction(actionType, profileURL string) error {
+	_, err := s.db.Exec(`
+		UPDATE failed_actions SET status = ?, last_attempt_at = ?
+		WHERE action_type = ? AND profile_url = ? AND status = ?
+	`, FailedStatusResolved, time.Now(), actionType, profileURL, FailedStatusPending)
+	return err
+}
+
+// scanFailedActions scans failed_actions rows
+func scanFailedActions(rows *sql.Rows) ([]FailedAction, error) {
+	var actions []FailedAction
+	for rows.Next() {
+		var a FailedAction
+		var payload, reason sql.NullString
+		if err := rows.Scan(&a.ID, &a.ActionType, &a.ProfileURL, &payload, &reason, &a.Retryable,
+			&a.Attempts, &a.Status, &a.LastAttemptAt, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.Payload = payload.String
+		a.Reason = reason.String
+		actions = append(actions, a)
+	}
+	return actions, rows.Err()
+}
@@ -0,0 +1,156 @@
+package persistence
+
+import (
+	"fmt"
+	"time"
+)
+
+// HealthFactor is one signal contributing to the account health score
+type HealthFactor struct {
+	Name    string `json:"name"`
+	Penalty int    `json:"penalty"` // Points deducted from 100
+	Detail  string `json:"detail"`
+}
+
+// HealthReport summarizes account health from the data we already collect
+type HealthReport struct {
+	Score      int            `json:"score"` // 0-100, higher is healthier
+	Factors    []HealthFactor `json:"factors"`
+	ComputedAt time.Time      `json:"computed_at"`
+}
+
+// Minimum resolved requests before acceptance-based signals are trusted
+const minResolvedForHealth = 5
+
+// ComputeAccountHealth scores the account from acceptance rate, its trend,
+// declined/withdrawn ratio and recent detection errors (failed/paused workflows)
+func (s *Store) ComputeAccountHealth() (*HealthReport, error) {
+	now := time.Now()
+	report := &HealthReport{Score: 100, ComputedAt: now}
+
+	recent, err := s.requestStatusCounts(now.AddDate(0, 0, -30), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute account health: %w", err)
+	}
+
+	// Factor 1: acceptance rate over the last 30 days
+	accepted := recent[StatusAccepted]
+	declined := recent[StatusDeclined]
+	withdrawn := recent[StatusWithdrawn]
+	resolved := accepted + declined + withdrawn
+	if resolved >= minResolvedForHealth {
+		rate := float64(accepted) / float64(resolved) * 100
+		factor := HealthFactor{Name: "acceptance_rate", Detail: fmt.Sprintf("%.1f%% of %d resolved requests accepted", rate, resolved)}
+		switch {
+		case rate < 20:
+			factor.Penalty = 25
+		case rate < 30:
+			factor.Penalty = 10
+		}
+		report.Factors = append(report.Factors, factor)
+	}
+
+	// Factor 2: acceptance trend (last 14 days vs the 28 days before)
+	current, err := s.requestStatusCounts(now.AddDate(0, 0, -14), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute account health: %w", err)
+	}
+	previous, err := s.requestStatusCounts(now.AddDate(0, 0, -42), now.AddDate(0, 0, -14))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute account health: %w", err)
+	}
+	curRate, curOK := acceptanceRate(current)
+	prevRate, prevOK := acceptanceRate(previous)
+	if curOK && prevOK {
+		drop := prevRate - curRate
+		factor := HealthFactor{Name: "acceptance_trend", Detail: fmt.Sprintf("%.1f%% → %.1f%%", prevRate, curRate)}
+		switch {
+		case drop > 20:
+			factor.Penalty = 20
+		case drop > 10:
+			factor.Penalty = 10
+		}
+		report.Factors = append(report.Factors, factor)
+	}
+
+	// Factor 3: declined + withdrawn share of everything sent in the last 30 days
+	total := 0
+	for _, count := range recent {
+		total += count
+	}
+	if total >= minResolvedForHealth {
+		ratio := float64(declined+withdrawn) / float64(total) * 100
+		factor := HealthFactor{Name: "declined_withdrawn", Detail: fmt.Sprintf("%.1f%% of %d requests declined or withdrawn", ratio, total)}
+		switch {
+		case ratio > 40:
+			factor.Penalty = 20
+		case ratio > 25:
+			factor.Penalty = 10
+		}
+		report.Factors = append(report.Factors, factor)
+	}
+
+	// Factor 4: detection errors that stopped workflows in the last 7 days
+	var errorCount int
+	err = s.db.QueryRow(`
+		SELECT COUNT(*) FROM workflow_state
+		WHERE status IN (?, ?) AND error_message IS NOT NULL AND error_message != ''
+		AND started_at >= ?
+	`, WorkflowStatusFailed, WorkflowStatusPaused, now.AddDate(0, 0, -7)).Scan(&errorCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute account health: %w", err)
+	}
+	if errorCount > 0 {
+		penalty := errorCount * 8
+		if penalty > 30 {
+			penalty = 30
+		}
+		report.Factors = append(report.Factors, HealthFactor{
+			Name:    "detection_errors",
+			Penalty: penalty,
+			Detail:  fmt.Sprintf("%d workflows stopped by errors in the last 7 days", errorCount),
+		})
+	}
+
+	for _, f := range report.Factors {
+		report.Score -= f.Penalty
+	}
+	if report.Score < 0 {
+		report.Score = 0
+	}
+
+	return report, nil
+}
+
+// requestStatusCounts counts connection requests by status sent within [from, to)
+func (s *Store) requestStatusCounts(from, to time.Time) (map[string]int, error) {
+	rows, err := s.db.Query(`
+		SELECT status, COUNT(*) FROM connection_requests
+		WHERE sent_at >= ? AND sent_at < ?
+		GROUP BY status
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
+}
+
+// acceptanceRate returns accepted/resolved as a percentage, and whether enough data exists
+func acceptanceRate(counts map[string]int) (float64, bool) {
+	resolved := counts[StatusAccepted] + counts[StatusDeclined] + counts[StatusWithdrawn]
+	if resolved < minResolvedForHealth {
+		return 0, false
+	}
+	return float64(counts[StatusAccepted]) / float64(resolved) * 100, true
+}
@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
+)
+
+// importColumns maps recognised CSV header names to PersonSearchResult fields
+var importColumns = map[string]string{
+	"profile_url":   "profile_url",
+	"profileurl":    "profile_url",
+	"url":           "profile_url",
+	"linkedin":      "profile_url",
+	"profile":       "profile_url",
+	"name":          "name",
+	"full_name":     "name",
+	"headline":      "headline",
+	"title":         "headline",
+	"company":       "company",
+	"location":      "location",
+	"email":         "email",
+	"e-mail":        "email",
+	"email_address": "email",
+}
+
+// ImportTargetsCSV imports target profile URLs from a CSV file as people search results
+// The CSV may have a header row (profile_url/url, name, headline, company, location, email);
+// without one, columns are read as: url, name, headline, company.
+// Rows that aren't LinkedIn profile URLs or already exist are skipped.
+// Returns the number of rows imported.
+func (s *Store) ImportTargetsCSV(path, keyword string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open CSV: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1 // Allow ragged rows
+	reader.TrimLeadingSpace = true
+
+	// Default column order when there's no header
+	columns := map[string]int{"profile_url": 0, "name": 1, "headline": 2, "company": 3}
+
+	var results []PersonSearchResult
+	seen := make(map[string]bool)
+	skipped := 0
+	now := time.Now()
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read CSV line %d: %w", line, err)
+		}
+
+		// Header row: first row whose first cell isn't a URL
+		if line == 1 && !strings.Contains(strings.ToLower(record[0]), "linkedin.com") {
+			if header := parseImportHeader(record); header != nil {
+				columns = header
+				continue
+			}
+		}
+
+		profileURL, err := canonicalProfileURL(field(record, columns, "profile_url"))
+		if err != nil {
+			fmt.Printf("⚠️ Skipping CSV line %d: %v\n", line, err)
+			skipped++
+			continue
+		}
+
+		if seen[profileURL] {
+			continue
+		}
+		seen[profileURL] = true
+
+		exists, _ := s.HasPersonResult(profileURL)
+		if exists {
+			skipped++
+			continue
+		}
+
+		results = append(results, PersonSearchResult{
+			ProfileURL:    profileURL,
+			Name:          field(record, columns, "name"),
+			Headline:      field(record, columns, "headline"),
+			Company:       field(record, columns, "company"),
+			Location:      field(record, columns, "location"),
+			Email:         field(record, columns, "email"),
+			SearchKeyword: keyword,
+			DiscoveredAt:  now,
+		})
+	}
+
+	if len(results) > 0 {
+		if err := s.SavePersonSearchResults(results); err != nil {
+			return 0, fmt.Errorf("failed to import targets: %w", err)
+		}
+	}
+
+	fmt.Printf("📥 Imported %d targets from %s (%d skipped)\n", len(results), path, skipped)
+	return len(results), nil
+}
+
+// parseImportHeader maps header names to column indexes
+// Returns nil if the row doesn't contain a profile URL column
+func parseImportHeader(record []string) map[string]int {
+	columns := make(map[string]int)
+	for i, name := range record {
+		key := strings.ToLower(strings.TrimSpace(name))
+		key = strings.ReplaceAll(key, " ", "_")
+		if fieldName, ok := importColumns[key]; ok {
+			if _, dup := columns[fieldName]; !dup {
+				columns[fieldName] = i
+			}
+		}
+	}
+	if _, ok := columns["profile_url"]; !ok {
+		return nil
+	}
+	return columns
+}
+
+// field returns a trimmed column value, or "" if the column is absent
+func field(record []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// canonicalProfileURL validates a LinkedIn profile URL and returns it as
+// https://www.linkedin.com/in/<slug> (matching search-extracted URLs)
+func canonicalProfileURL(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", fmt.Errorf("empty profile URL")
+	}
+
+	canonical := linkedinurl.Canonicalize(raw)
+	if canonical != "linkedin.com" && !strings.HasPrefix(canonical, "linkedin.com/") {
+		return "", fmt.Errorf("not a LinkedIn URL: %s", raw)
+	}
+	if !strings.HasPrefix(canonical, "linkedin.com/in/") {
+		return "", fmt.Errorf("not a LinkedIn profile URL: %s", raw)
+	}
+
+	return "https://www." + canonical, nil
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
 )
 
 // Message represents a sent message
@@ -46,7 +48,7 @@ func (s *Store) SaveMessage(msg *Message) error {
 		msg.SentAt = time.Now()
 	}
 
-	result, err := s.db.Exec(`
+	id, err := s.db.insertID(`
 		INSERT INTO messages (
 			conversation_id, recipient_url, recipient_name, content,
 			template_name, message_type, status, sent_at, error_message
@@ -58,9 +60,13 @@ func (s *Store) SaveMessage(msg *Message) error {
 		return fmt.Errorf("failed to save message: %w", err)
 	}
 
-	id, _ := result.LastInsertId()
 	msg.ID = id
 
+	// Failed sends are recorded for history but didn't reach anyone
+	if msg.Status == MessageStatusFailed {
+		return nil
+	}
+
 	// Update daily stats
 	s.incrementDailyStat("messages_sent")
 
@@ -121,16 +127,55 @@ func (s *Store) GetRecentMessages(limit int) ([]Message, error) {
 	return scanMessages(rows)
 }
 
-// GetTodayMessageCount returns the number of messages sent today
+// GetTodayMessageCount returns the number of messages sent today (since local midnight)
 func (s *Store) GetTodayMessageCount() (int, error) {
+	y, m, d := time.Now().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+
 	var count int
 	err := s.db.QueryRow(`
 		SELECT COUNT(*) FROM messages
-		WHERE date(sent_at) = date('now') AND status != ?
-	`, MessageStatusFailed).Scan(&count)
+		WHERE sent_at >= ? AND status != ?
+	`, today, MessageStatusFailed).Scan(&count)
 	return count, err
 }
 
+// GetLastMessageSentAt returns when the most recent message to anyone went out
+// (zero time if none has)
+func (s *Store) GetLastMessageSentAt() (time.Time, error) {
+	var sentAt time.Time
+	err := s.db.QueryRow(`
+		SELECT sent_at FROM messages
+		WHERE status != ?
+		ORDER BY sent_at DESC
+		LIMIT 1
+	`, MessageStatusFailed).Scan(&sentAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return sentAt, err
+}
+
+// CanSendMessageNow reports whether at least minIntervalSec seconds have passed
+// since the last message to anyone, and if not how long is left.
+// Unlike the rate limiter it reads the messages table, so a fresh process with
+// lost limiter state still can't send two messages seconds apart
+func (s *Store) CanSendMessageNow(minIntervalSec int) (bool, time.Duration, error) {
+	last, err := s.GetLastMessageSentAt()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to read last message time: %w", err)
+	}
+	if last.IsZero() {
+		return true, 0, nil
+	}
+
+	wait := time.Duration(minIntervalSec)*time.Second - time.Since(last)
+	if wait > 0 {
+		return false, wait, nil
+	}
+	return true, 0, nil
+}
+
 // HasMessaged checks if we've already messaged this person
 func (s *Store) HasMessaged(profileURL string) (bool, error) {
 	var count int
@@ -214,15 +259,15 @@ func (s *Store) GetMessageStats(dailyLimit int) (*MessageStats, error) {
 }
 
 // GetUnmessagedConnections returns connections we haven't messaged yet
-func (s *Store) GetUnmessagedConnections() ([]Connection, error) {
-	// Return all unmessaged connections regardless of age
+// that connected at least minAge ago (0 = regardless of age)
+func (s *Store) GetUnmessagedConnections(minAge time.Duration) ([]Connection, error) {
 	rows, err := s.db.Query(`
 				SELECT id, profile_url, name, headline, company, connected_at,
 								has_messaged, last_message_at, message_count, notes
 				FROM connections
-				WHERE has_messaged = FALSE
+				WHERE has_messaged = FALSE AND connected_at <= ?
 				ORDER BY connected_at DESC
-		 `)
+		 `, time.Now().Add(-minAge))
 	if err != nil {
 		return nil, err
 	}
@@ -230,6 +275,68 @@ func (s *Store) GetUnmessagedConnections() ([]Connection, error) {
 	return scanConnections(rows)
 }
 
+// GetUnmessagedInitiatedConnections is GetUnmessagedConnections limited to connections
+// that came from one of our own requests sent within maxRequestAge (0 = any age).
+// Pending requests count too: acceptances are only marked by a reconcile run,
+// so a pending request whose member already shows up as a connection was accepted.
+func (s *Store) GetUnmessagedInitiatedConnections(minAge, maxRequestAge time.Duration) ([]Connection, error) {
+	sentAfter := time.Time{}
+	if maxRequestAge > 0 {
+		sentAfter = time.Now().Add(-maxRequestAge)
+	}
+
+	// Requests and synced connections store the URL as scraped (trailing
+	// slash, locale subdomain...), so match them on the canonical URL in Go
+	rows, err := s.db.Query(`
+		SELECT profile_url FROM connection_requests
+		WHERE status IN (?, ?) AND sent_at >= ?
+	`, StatusAccepted, StatusPending, sentAfter)
+	if err != nil {
+		return nil, err
+	}
+	initiated := make(map[string]bool)
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		initiated[linkedinurl.Canonicalize(url)] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	unmessaged, err := s.GetUnmessagedConnections(minAge)
+	if err != nil {
+		return nil, err
+	}
+	var connections []Connection
+	for _, conn := range unmessaged {
+		if initiated[linkedinurl.Canonicalize(conn.ProfileURL)] {
+			connections = append(connections, conn)
+		}
+	}
+	return connections, nil
+}
+
+// InitiatedByUs reports whether we sent the connection request to this profile
+// within maxRequestAge (0 = any age) and it wasn't declined or withdrawn
+func (s *Store) InitiatedByUs(profileURL string, maxRequestAge time.Duration) (bool, error) {
+	req, err := s.GetConnectionRequest(profileURL)
+	if err != nil || req == nil {
+		return false, err
+	}
+	if req.Status != StatusAccepted && req.Status != StatusPending {
+		return false, nil
+	}
+	if maxRequestAge > 0 && req.SentAt.Before(time.Now().Add(-maxRequestAge)) {
+		return false, nil
+	}
+	return true, nil
+}
+
 // Connection represents an accepted LinkedIn connection
 type Connection struct {
 	ID            int64      `json:"id"`
@@ -250,7 +357,7 @@ func (s *Store) SaveConnection(conn *Connection) error {
 		conn.ConnectedAt = time.Now()
 	}
 
-	result, err := s.db.Exec(`
+	id, err := s.db.insertID(`
 		INSERT INTO connections (
 			profile_url, name, headline, company, connected_at,
 			has_messaged, last_message_at, message_count, notes
@@ -272,7 +379,6 @@ func (s *Store) SaveConnection(conn *Connection) error {
 	}
 
 	if conn.ID == 0 {
-		id, _ := result.LastInsertId()
 		conn.ID = id
 	}
 
@@ -160,6 +160,11 @@ func (s *Store) migrateMessageTracker() (int, error) {
 
 	// Migrate messages
 	for _, msg := range oldTracker.Messages {
+		// Messages sent since the last migration are already stored as they went out
+		if exists, _ := s.hasMessage(msg.RecipientURL, msg.Content); exists {
+			continue
+		}
+
 		newMsg := &Message{
 			ConversationID: msg.ConversationID,
 			RecipientURL:   msg.RecipientURL,
@@ -258,3 +263,12 @@ func (s *Store) ExportToJSON(outputPath string) error {
 	fmt.Printf("📤 Exported data to %s\n", outputPath)
 	return nil
 }
+
+// hasMessage checks whether a message with this recipient and content is already stored
+func (s *Store) hasMessage(recipientURL, content string) (bool, error) {
+	var count int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM messages WHERE recipient_url = ? AND content = ?
+	`, recipientURL, content).Scan(&count)
+	return count > 0, err
+}
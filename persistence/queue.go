@@ -0,0 +1,177 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// QueuedAction is a planned write action waiting to be executed
+//
+// WHY A QUEUE:
+// - Workflow loops discover, decide and execute in one pass - nothing to review first
+// - Planning into a table lets the day's work be inspected and reordered up front
+// - Queued rows survive restarts; rows left running by a crash are requeued
+type QueuedAction struct {
+	ID          int64      `json:"id"`
+	ActionType  string     `json:"action_type"` // QueueActionConnect, QueueActionMessage
+	ProfileURL  string     `json:"profile_url"`
+	Payload     string     `json:"payload,omitempty"` // Note or template name
+	ScheduledAt time.Time  `json:"scheduled_at"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExecutedAt  *time.Time `json:"executed_at,omitempty"`
+}
+
+// Queue action types
+const (
+	QueueActionConnect = "connect"
+	QueueActionMessage = "message"
+)
+
+// Queue statuses
+const (
+	QueueStatusQueued    = "queued"
+	QueueStatusRunning   = "running"
+	QueueStatusDone      = "done"
+	QueueStatusFailed    = "failed"
+	QueueStatusCancelled = "cancelled"
+)
+
+// EnqueueAction adds an action to the queue
+// An action already queued for the same profile and type is not duplicated
+func (s *Store) EnqueueAction(action *QueuedAction) error {
+	var existing int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM action_queue
+		WHERE action_type = ? AND profile_url = ? AND status IN (?, ?)
+	`, action.ActionType, action.ProfileURL, QueueStatusQueued, QueueStatusRunning).Scan(&existing)
+	if err != nil {
+		return fmt.Errorf("failed to check queue: %w", err)
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	if action.ScheduledAt.IsZero() {
+		action.ScheduledAt = time.Now()
+	}
+	action.Status = QueueStatusQueued
+	action.CreatedAt = time.Now()
+
+	id, err := s.db.insertID(`
+		INSERT INTO action_queue (action_type, profile_url, payload, scheduled_at, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, action.ActionType, action.ProfileURL, action.Payload, action.ScheduledAt, action.Status, action.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue action: %w", err)
+	}
+
+	action.ID = id
+	return nil
+}
+
+// GetQueuedActions returns pending actions in execution order
+func (s *Store) GetQueuedActions(limit int) ([]QueuedAction, error) {
+	if limit <= 0 {
+		limit = 1000
+	}
+	rows, err := s.db.Query(`
+		SELECT id, action_type, profile_url, payload, scheduled_at, status,
+			   attempts, last_error, created_at, executed_at
+		FROM action_queue
+		WHERE status = ?
+		ORDER BY scheduled_at ASC, id ASC
+		LIMIT ?
+	`, QueueStatusQueued, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanQueuedActions(rows)
+}
+
+// NextQueuedAction returns the earliest queued action (due or not), or nil if the queue is empty
+func (s *Store) NextQueuedAction() (*QueuedAction, error) {
+	actions, err := s.GetQueuedActions(1)
+	if err != nil || len(actions) == 0 {
+		return nil, err
+	}
+	return &actions[0], nil
+}
+
+// MarkActionRunning marks an action as being executed
+func (s *Store) MarkActionRunning(id int64) error {
+	_, err := s.db.Exec(`
+		UPDATE action_queue SET status = ?, attempts = attempts + 1 WHERE id = ?
+	`, QueueStatusRunning, id)
+	return err
+}
+
+// MarkActionDone marks an action as executed successfully
+func (s *Store) MarkActionDone(id int64) error {
+	_, err := s.db.Exec(`
+		UPDATE action_queue SET status = ?, executed_at = ?, last_error = NULL WHERE id = ?
+	`, QueueStatusDone, time.Now(), id)
+	return err
+}
+
+// MarkActionFailed records a failed execution
+func (s *Store) MarkActionFailed(id int64, errMsg string) error {
+	_, err := s.db.Exec(`
+		UPDATE action_queue SET status = ?, executed_at = ?, last_error = ? WHERE id = ?
+	`, QueueStatusFailed, time.Now(), errMsg, id)
+	return err
+}
+
+// RescheduleAction moves a queued (or interrupted) action to a new time
+// Use it to reorder the plan or to retry later
+func (s *Store) RescheduleAction(id int64, at time.Time) error {
+	_, err := s.db.Exec(`
+		UPDATE action_queue SET status = ?, scheduled_at = ? WHERE id = ? AND status IN (?, ?)
+	`, QueueStatusQueued, at, id, QueueStatusQueued, QueueStatusRunning)
+	return err
+}
+
+// CancelAction removes an action from the plan without deleting its history
+func (s *Store) CancelAction(id int64) error {
+	_, err := s.db.Exec(`
+		UPDATE action_queue SET status = ? WHERE id = ? AND status = ?
+	`, QueueStatusCancelled, id, QueueStatusQueued)
+	return err
+}
+
+// RequeueInterruptedActions puts actions left "running" by a crash back in the queue
+func (s *Store) RequeueInterruptedActions() (int64, error) {
+	res, err := s.db.Exec(`
+		UPDATE action_queue SET status = ? WHERE status = ?
+	`, QueueStatusQueued, QueueStatusRunning)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue interrupted actions: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// scanQueuedActions scans action_queue rows
+func scanQueuedActions(rows *sql.Rows) ([]QueuedAction, error) {
+	var actions []QueuedAction
+	for rows.Next() {
+		var a QueuedAction
+		var payload, lastError sql.NullString
+		var executedAt sql.NullTime
+		if err := rows.Scan(&a.ID, &a.ActionType, &a.ProfileURL, &payload, &a.ScheduledAt,
+			&a.Status, &a.Attempts, &lastError, &a.CreatedAt, &executedAt); err != nil {
+			return nil, err
+		}
+		a.Payload = payload.String
+		a.LastError = lastError.String
+		if executedAt.Valid {
+			a.ExecutedAt = &executedAt.Time
+		}
+		actions = append(actions, a)
+	}
+	return actions, rows.Err()
+}
@@ -0,0 +1,156 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// KeywordPerformance summarizes requests sent for one search keyword
+type KeywordPerformance struct {
+	Keyword  string `json:"keyword"`
+	Sent     int    `json:"sent"`
+	Accepted int    `json:"accepted"`
+}
+
+// Incident is a workflow that was stopped by an error (usually a LinkedIn detection)
+type Incident struct {
+	WorkflowType string    `json:"workflow_type"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error"`
+	StartedAt    time.Time `json:"started_at"`
+}
+
+// WeeklyReport aggregates the last 7 days of activity into one reviewable artifact
+type WeeklyReport struct {
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// Week totals
+	ConnectionsSent     int     `json:"connections_sent"`
+	ConnectionsAccepted int     `json:"connections_accepted"`
+	MessagesSent        int     `json:"messages_sent"`
+	MessagesRead        int     `json:"messages_read"`
+	AcceptanceRate      float64 `json:"acceptance_rate"` // Accepted / resolved requests sent this week, %
+
+	Days        []DailyStats            `json:"days"`
+	TopKeywords []KeywordPerformance    `json:"top_keywords"`
+	Incidents   []Incident              `json:"incidents"`
+	Requests    *ConnectionRequestStats `json:"requests"` // All-time
+	Messages    *MessageStats           `json:"messages"` // All-time
+}
+
+// Number of keywords listed in the weekly report
+const reportTopKeywords = 5
+
+// GenerateWeeklyReport aggregates the past 7 days of stats
+func (s *Store) GenerateWeeklyReport() (*WeeklyReport, error) {
+	now := time.Now()
+	from := now.AddDate(0, 0, -7)
+	report := &WeeklyReport{From: from, To: now, GeneratedAt: now}
+
+	var err error
+	if report.Days, err = s.GetWeeklyStats(); err != nil {
+		return nil, fmt.Errorf("failed to load weekly stats: %w", err)
+	}
+	if report.Requests, err = s.GetConnectionRequestStats(0); err != nil {
+		return nil, fmt.Errorf("failed to load request stats: %w", err)
+	}
+	if report.Messages, err = s.GetMessageStats(0); err != nil {
+		return nil, fmt.Errorf("failed to load message stats: %w", err)
+	}
+
+	// Requests sent this week and how they resolved so far
+	counts, err := s.requestStatusCounts(from, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count requests: %w", err)
+	}
+	for _, count := range counts {
+		report.ConnectionsSent += count
+	}
+	if resolved := counts[StatusAccepted] + counts[StatusDeclined] + counts[StatusWithdrawn]; resolved > 0 {
+		report.AcceptanceRate = float64(counts[StatusAccepted]) / float64(resolved) * 100
+	}
+
+	// Acceptances that landed this week (including older requests)
+	err = s.db.QueryRow(`
+		SELECT COUNT(*) FROM connection_requests
+		WHERE status = ? AND accepted_at >= ?
+	`, StatusAccepted, from).Scan(&report.ConnectionsAccepted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count acceptances: %w", err)
+	}
+
+	err = s.db.QueryRow(`
+		SELECT
+			COALESCE(SUM(CASE WHEN status != ? THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = ? OR read_at IS NOT NULL THEN 1 ELSE 0 END), 0)
+		FROM messages WHERE sent_at >= ?
+	`, MessageStatusFailed, MessageStatusRead, from).Scan(&report.MessagesSent, &report.MessagesRead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count messages: %w", err)
+	}
+
+	if report.TopKeywords, err = s.topKeywords(from, reportTopKeywords); err != nil {
+		return nil, fmt.Errorf("failed to rank keywords: %w", err)
+	}
+	if report.Incidents, err = s.incidentsSince(from); err != nil {
+		return nil, fmt.Errorf("failed to load incidents: %w", err)
+	}
+
+	return report, nil
+}
+
+// topKeywords ranks search keywords by requests sent since a time
+func (s *Store) topKeywords(since time.Time, limit int) ([]KeywordPerformance, error) {
+	rows, err := s.db.Query(`
+		SELECT COALESCE(search_keyword, ''), COUNT(*),
+			COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0)
+		FROM connection_requests
+		WHERE sent_at >= ?
+		GROUP BY search_keyword
+		ORDER BY COUNT(*) DESC
+		LIMIT ?
+	`, StatusAccepted, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keywords []KeywordPerformance
+	for rows.Next() {
+		var k KeywordPerformance
+		if err := rows.Scan(&k.Keyword, &k.Sent, &k.Accepted); err != nil {
+			return nil, err
+		}
+		keywords = append(keywords, k)
+	}
+	return keywords, rows.Err()
+}
+
+// incidentsSince returns workflows stopped by errors since a time
+func (s *Store) incidentsSince(since time.Time) ([]Incident, error) {
+	rows, err := s.db.Query(`
+		SELECT workflow_type, status, error_message, started_at FROM workflow_state
+		WHERE status IN (?, ?) AND error_message IS NOT NULL AND error_message != ''
+		AND started_at >= ?
+		ORDER BY started_at DESC
+	`, WorkflowStatusFailed, WorkflowStatusPaused, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var incidents []Incident
+	for rows.Next() {
+		var inc Incident
+		var errMsg sql.NullString
+		if err := rows.Scan(&inc.WorkflowType, &inc.Status, &errMsg, &inc.StartedAt); err != nil {
+			return nil, err
+		}
+		inc.Error = errMsg.String
+		incidents = append(incidents, inc)
+	}
+	return incidents, rows.Err()
+}
@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Markdown renders the weekly report as a Markdown document
+// Rendering is kept apart from GenerateWeeklyReport so other outputs
+// (e.g. an email body) can reuse the same report data
+func (r *WeeklyReport) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# LinkedIn Weekly Report\n\n")
+	fmt.Fprintf(&b, "%s – %s\n\n", r.From.Format("Jan 2, 2006"), r.To.Format("Jan 2, 2006"))
+
+	b.WriteString("## This week\n\n")
+	b.WriteString("| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Connection requests sent | %d |\n", r.ConnectionsSent)
+	fmt.Fprintf(&b, "| Connections accepted | %d |\n", r.ConnectionsAccepted)
+	fmt.Fprintf(&b, "| Acceptance rate | %.1f%% |\n", r.AcceptanceRate)
+	fmt.Fprintf(&b, "| Messages sent | %d |\n", r.MessagesSent)
+	fmt.Fprintf(&b, "| Messages read | %d |\n\n", r.MessagesRead)
+
+	if len(r.Days) > 0 {
+		b.WriteString("## Daily activity\n\n")
+		b.WriteString("| Date | Connects | Accepted | Messages | Searched | Notes skipped |\n|---|---|---|---|---|---|\n")
+		for _, d := range r.Days {
+			fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %d |\n",
+				d.Date, d.ConnectionsSent, d.ConnectionsAccepted, d.MessagesSent, d.ProfilesSearched, d.NotesSkipped)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.TopKeywords) > 0 {
+		b.WriteString("## Top keywords\n\n")
+		b.WriteString("| Keyword | Sent | Accepted |\n|---|---|---|\n")
+		for _, k := range r.TopKeywords {
+			keyword := k.Keyword
+			if keyword == "" {
+				keyword = "(none)"
+			}
+			fmt.Fprintf(&b, "| %s | %d | %d |\n", keyword, k.Sent, k.Accepted)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Detection incidents\n\n")
+	if len(r.Incidents) == 0 {
+		b.WriteString("None 🎉\n\n")
+	} else {
+		for _, inc := range r.Incidents {
+			fmt.Fprintf(&b, "- %s — %s workflow %s: %s\n",
+				inc.StartedAt.Format("Mon Jan 2 15:04"), inc.WorkflowType, inc.Status, inc.Error)
+		}
+		b.WriteString("\n")
+	}
+
+	if r.Requests != nil && r.Messages != nil {
+		b.WriteString("## All time\n\n")
+		fmt.Fprintf(&b, "- Requests: %d sent, %d pending, %d accepted, %d declined (%.1f%% acceptance)\n",
+			r.Requests.TotalSent, r.Requests.Pending, r.Requests.Accepted, r.Requests.Declined, r.Requests.AcceptanceRate)
+		fmt.Fprintf(&b, "- Messages: %d sent (%d follow-ups), %d failed\n",
+			r.Messages.TotalSent, r.Messages.FollowUpsSent, r.Messages.FailedMessages)
+	}
+
+	fmt.Fprintf(&b, "\n_Generated %s_\n", r.GeneratedAt.Format("2006-01-02 15:04"))
+	return b.String()
+}
+
+// WriteMarkdown writes the Markdown report to a file
+func (r *WeeklyReport) WriteMarkdown(path string) error {
+	if err := os.WriteFile(path, []byte(r.Markdown()), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
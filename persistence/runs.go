@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RunSummary records what one run of the program did
+type RunSummary struct {
+	ID                  int64     `json:"id"`
+	Workflow            string    `json:"workflow"`
+	SafetyLevel         string    `json:"safety_level"`
+	DryRun              bool      `json:"dry_run"`
+	StartedAt           time.Time `json:"started_at"`
+	EndedAt             time.Time `json:"ended_at"`
+	ProfilesSearched    int       `json:"profiles_searched"`
+	ConnectionsSent     int       `json:"connections_sent"`
+	ConnectionsAccepted int       `json:"connections_accepted"`
+	MessagesSent        int       `json:"messages_sent"`
+	NotesSkipped        int       `json:"notes_skipped"`
+	Error               string    `json:"error,omitempty"` // Why the run stopped early ("" = completed)
+}
+
+// Duration is how long the run took
+func (r *RunSummary) Duration() time.Duration {
+	return r.EndedAt.Sub(r.StartedAt)
+}
+
+// SaveRunSummary stores a finished run
+func (s *Store) SaveRunSummary(run *RunSummary) error {
+	if run.EndedAt.IsZero() {
+		run.EndedAt = time.Now()
+	}
+
+	id, err := s.db.insertID(`
+		INSERT INTO run_summaries (
+			workflow, safety_level, dry_run, started_at, ended_at,
+			profiles_searched, connections_sent, connections_accepted,
+			messages_sent, notes_skipped, error
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, run.Workflow, run.SafetyLevel, run.DryRun, run.StartedAt, run.EndedAt,
+		run.ProfilesSearched, run.ConnectionsSent, run.ConnectionsAccepted,
+		run.MessagesSent, run.NotesSkipped, run.Error)
+	if err != nil {
+		return fmt.Errorf("failed to save run summary: %w", err)
+	}
+
+	run.ID = id
+	return nil
+}
+
+// GetRunHistory returns the most recent runs, newest first
+func (s *Store) GetRunHistory(limit int) ([]RunSummary, error) {
+	query := `
+		SELECT id, workflow, safety_level, dry_run, started_at, ended_at,
+			   profiles_searched, connections_sent, connections_accepted,
+			   messages_sent, notes_skipped, error
+		FROM run_summaries
+		ORDER BY started_at DESC
+	`
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []RunSummary
+	for rows.Next() {
+		var run RunSummary
+		var safetyLevel, runErr sql.NullString
+		err := rows.Scan(
+			&run.ID, &run.Workflow, &safetyLevel, &run.DryRun, &run.StartedAt, &run.EndedAt,
+			&run.ProfilesSearched, &run.ConnectionsSent, &run.ConnectionsAccepted,
+			&run.MessagesSent, &run.NotesSkipped, &runErr,
+		)
+		if err != nil {
+			return nil, err
+		}
+		run.SafetyLevel = safetyLevel.String
+		run.Error = runErr.String
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConnectionSnapshot is the absolute network size on a given day
+// daily_stats tracks what we did; snapshots track where the account is,
+// including acceptances that happened between runs
+type ConnectionSnapshot struct {
+	Date             string    `json:"date"`
+	TotalConnections int       `json:"total_connections"`
+	PendingRequests  int       `json:"pending_requests"`
+	Growth           int       `json:"growth"` // Change in TotalConnections since the previous snapshot
+	TakenAt          time.Time `json:"taken_at"`
+}
+
+// SnapshotConnections records today's accepted connection and pending request counts
+// Safe to call on every run - later calls on the same day overwrite the earlier snapshot
+func (s *Store) SnapshotConnections() (*ConnectionSnapshot, error) {
+	snap := &ConnectionSnapshot{
+		Date:    getTodayDate(),
+		TakenAt: time.Now(),
+	}
+
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM connections`).Scan(&snap.TotalConnections); err != nil {
+		return nil, fmt.Errorf("failed to count connections: %w", err)
+	}
+	if err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM connection_requests WHERE status = ?
+	`, StatusPending).Scan(&snap.PendingRequests); err != nil {
+		return nil, fmt.Errorf("failed to count pending requests: %w", err)
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO connection_snapshots (date, total_connections, pending_requests, taken_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(date) DO UPDATE SET
+			total_connections = excluded.total_connections,
+			pending_requests = excluded.pending_requests,
+			taken_at = excluded.taken_at
+	`, snap.Date, snap.TotalConnections, snap.PendingRequests, snap.TakenAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save connection snapshot: %w", err)
+	}
+
+	return snap, nil
+}
+
+// GetConnectionGrowth returns the daily snapshots for the last N days, oldest first
+// Days without a run have no snapshot; Growth spans the gap to the previous one
+func (s *Store) GetConnectionGrowth(days int) ([]ConnectionSnapshot, error) {
+	if days <= 0 {
+		days = 30
+	}
+	since := time.Now().AddDate(0, 0, -(days - 1)).Format("2006-01-02")
+
+	// Include the snapshot just before the window so the first Growth is meaningful
+	rows, err := s.db.Query(`
+		SELECT date, total_connections, pending_requests, taken_at
+		FROM connection_snapshots
+		WHERE date >= (
+			SELECT COALESCE(MAX(date), ?) FROM connection_snapshots WHERE date < ?
+		)
+		ORDER BY date ASC
+	`, since, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var series []ConnectionSnapshot
+	for rows.Next() {
+		var snap ConnectionSnapshot
+		var date interface{}
+		if err := rows.Scan(&date, &snap.TotalConnections, &snap.PendingRequests, &snap.TakenAt); err != nil {
+			return nil, err
+		}
+		snap.Date = formatSnapshotDate(date)
+		series = append(series, snap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(series); i++ {
+		series[i].Growth = series[i].TotalConnections - series[i-1].TotalConnections
+	}
+
+	// Drop the lookback snapshot
+	if len(series) > 0 && series[0].Date < since {
+		series = series[1:]
+	}
+
+	return series, nil
+}
+
+// formatSnapshotDate normalizes a DATE column (string in SQLite, time in Postgres)
+func formatSnapshotDate(v interface{}) string {
+	switch d := v.(type) {
+	case time.Time:
+		return d.Format("2006-01-02")
+	case []byte:
+		return string(d)
+	case string:
+		return d
+	default:
+		return fmt.Sprint(d)
+	}
+}
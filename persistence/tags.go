@@ -0,0 +1,180 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
+)
+
+// Tag sources
+const (
+	TagSourceManual = "manual"
+	TagSourceAuto   = "auto"
+)
+
+// TagRule tags connections whose headline contains one of the keywords
+type TagRule struct {
+	Tag      string
+	Keywords []string
+}
+
+// AutoTagRules are the headline keyword rules AutoTagConnection applies
+// A connection gets every tag whose rule matches
+var AutoTagRules = []TagRule{
+	{Tag: "investor", Keywords: []string{"investor", "venture capital", "vc", "angel", "general partner", "limited partner"}},
+	{Tag: "founder", Keywords: []string{"founder", "co-founder", "cofounder", "ceo", "owner"}},
+	{Tag: "recruiter", Keywords: []string{"recruiter", "recruiting", "talent acquisition", "headhunter", "sourcer"}},
+	{Tag: "candidate", Keywords: []string{"open to work", "looking for", "seeking", "job seeker", "student", "graduate"}},
+	{Tag: "partner", Keywords: []string{"partnerships", "business development", "alliances", "channel"}},
+}
+
+// tagWords splits headlines into lowercase words for keyword matching
+var tagWords = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// normalizeTag lowercases and trims a tag so "Investor " and "investor" are one segment
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// TagConnection adds tag to a connection (no-op if it already has it)
+func (s *Store) TagConnection(profileURL, tag string) error {
+	return s.tagConnection(profileURL, tag, TagSourceManual)
+}
+
+// tagConnection stores tags under the canonical profile URL so any form of
+// the link (trailing slash, locale subdomain, query) finds them
+func (s *Store) tagConnection(profileURL, tag, source string) error {
+	tag = normalizeTag(tag)
+	key := linkedinurl.Canonicalize(profileURL)
+	if key == "" || tag == "" {
+		return fmt.Errorf("profile URL and tag are required")
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO connection_tags (profile_url, tag, source) VALUES (?, ?, ?)
+		ON CONFLICT(profile_url, tag) DO NOTHING
+	`, key, tag, source)
+	if err != nil {
+		return fmt.Errorf("failed to tag connection: %w", err)
+	}
+	return nil
+}
+
+// UntagConnection removes tag from a connection
+func (s *Store) UntagConnection(profileURL, tag string) error {
+	_, err := s.db.Exec(`DELETE FROM connection_tags WHERE profile_url = ? AND tag = ?`,
+		linkedinurl.Canonicalize(profileURL), normalizeTag(tag))
+	if err != nil {
+		return fmt.Errorf("failed to untag connection: %w", err)
+	}
+	return nil
+}
+
+// GetConnectionTags returns a connection's tags in alphabetical order
+func (s *Store) GetConnectionTags(profileURL string) ([]string, error) {
+	rows, err := s.db.Query(`SELECT tag FROM connection_tags WHERE profile_url = ? ORDER BY tag`,
+		linkedinurl.Canonicalize(profileURL))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// HasTag reports whether a connection is tagged with tag
+func (s *Store) HasTag(profileURL, tag string) (bool, error) {
+	var one int
+	err := s.db.QueryRow(`SELECT 1 FROM connection_tags WHERE profile_url = ? AND tag = ?`,
+		linkedinurl.Canonicalize(profileURL), normalizeTag(tag)).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetConnectionsByTag returns every connection in a segment, newest tag first
+// Tagged profiles that aren't in the connections table (the messaging
+// tracker only syncs to it on migration) come back with just their URL
+func (s *Store) GetConnectionsByTag(tag string) ([]Connection, error) {
+	rows, err := s.db.Query(`
+		SELECT profile_url FROM connection_tags
+		WHERE tag = ?
+		ORDER BY created_at DESC
+	`, normalizeTag(tag))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return []Connection{}, nil
+	}
+
+	// The connections table holds URLs as scraped, so match on the canonical form
+	all, err := s.GetAllConnections(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]Connection, len(all))
+	for _, conn := range all {
+		byKey[linkedinurl.Canonicalize(conn.ProfileURL)] = conn
+	}
+
+	connections := make([]Connection, 0, len(keys))
+	for _, key := range keys {
+		conn, ok := byKey[key]
+		if !ok {
+			conn = Connection{ProfileURL: "https://www." + key}
+		}
+		connections = append(connections, conn)
+	}
+	return connections, nil
+}
+
+// AutoTagConnection tags a connection from its headline using AutoTagRules
+// Returns the tags that matched (already-present tags included)
+func (s *Store) AutoTagConnection(profileURL, headline string) ([]string, error) {
+	head := " " + strings.Join(tagWords.FindAllString(strings.ToLower(headline), -1), " ") + " "
+
+	var matched []string
+	for _, rule := range AutoTagRules {
+		for _, keyword := range rule.Keywords {
+			phrase := strings.Join(tagWords.FindAllString(strings.ToLower(keyword), -1), " ")
+			if phrase != "" && strings.Contains(head, " "+phrase+" ") {
+				matched = append(matched, rule.Tag)
+				break
+			}
+		}
+	}
+
+	for _, tag := range matched {
+		if err := s.tagConnection(profileURL, tag, TagSourceAuto); err != nil {
+			return matched, err
+		}
+	}
+	return matched, nil
+}
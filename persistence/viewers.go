@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ProfileViewer is a member who viewed our profile
+type ProfileViewer struct {
+	ID          int64     `json:"id"`
+	ProfileURL  string    `json:"profile_url"`
+	Name        string    `json:"name,omitempty"`
+	Headline    string    `json:"headline,omitempty"`
+	ViewedAt    time.Time `json:"viewed_at"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+}
+
+// SaveProfileViewer records a profile view under the canonical profile URL,
+// keeping the most recent view time
+func (s *Store) SaveProfileViewer(viewer *ProfileViewer) error {
+	if viewer.ViewedAt.IsZero() {
+		viewer.ViewedAt = time.Now()
+	}
+	viewer.ProfileURL = profileKey(viewer.ProfileURL)
+
+	_, err := s.db.Exec(`
+		INSERT INTO profile_viewers (profile_url, name, headline, viewed_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(profile_url) DO UPDATE SET
+			name = COALESCE(excluded.name, profile_viewers.name),
+			headline = COALESCE(excluded.headline, profile_viewers.headline),
+			viewed_at = CASE WHEN excluded.viewed_at > profile_viewers.viewed_at
+				THEN excluded.viewed_at ELSE profile_viewers.viewed_at END
+	`, viewer.ProfileURL, viewer.Name, viewer.Headline, viewer.ViewedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save profile viewer: %w", err)
+	}
+	return nil
+}
+
+// GetRecentProfileViewers returns members who viewed our profile within the
+// last `within` (0 = ever), most recent first
+func (s *Store) GetRecentProfileViewers(within time.Duration) ([]ProfileViewer, error) {
+	since := time.Time{}
+	if within > 0 {
+		since = time.Now().Add(-within)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, profile_url, name, headline, viewed_at, first_seen_at
+		FROM profile_viewers
+		WHERE viewed_at >= ?
+		ORDER BY viewed_at DESC
+	`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var viewers []ProfileViewer
+	for rows.Next() {
+		var v ProfileViewer
+		var name, headline sql.NullString
+		if err := rows.Scan(&v.ID, &v.ProfileURL, &name, &headline, &v.ViewedAt, &v.FirstSeenAt); err != nil {
+			return nil, err
+		}
+		v.Name = name.String
+		v.Headline = headline.String
+		viewers = append(viewers, v)
+	}
+	return viewers, rows.Err()
+}
+
+// IsRecentProfileViewer reports whether the member viewed our profile within
+// the last `within` (0 = ever), whatever form of their profile URL is given
+func (s *Store) IsRecentProfileViewer(profileURL string, within time.Duration) (bool, error) {
+	since := time.Time{}
+	if within > 0 {
+		since = time.Now().Add(-within)
+	}
+
+	var one int
+	err := s.db.QueryRow(`SELECT 1 FROM profile_viewers WHERE profile_url = ? AND viewed_at >= ?`,
+		profileKey(profileURL), since).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
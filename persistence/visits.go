@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"fmt"
+	"time"
+)
+
+// Profile visit purposes
+const (
+	VisitPurposeWarmup = "warmup"
+)
+
+// RecordProfileVisit records that we opened someone's profile
+func (s *Store) RecordProfileVisit(profileURL, purpose string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO profile_visits (profile_url, purpose, visited_at) VALUES (?, ?, ?)
+	`, profileURL, purpose, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to record profile visit: %w", err)
+	}
+	return nil
+}
+
+// GetWarmupProfiles returns up to limit random stored search results that
+// weren't visited within revisitAfter, so warmup views don't keep landing
+// on the same few profiles
+func (s *Store) GetWarmupProfiles(limit int, revisitAfter time.Duration) ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT profile_url FROM people_search_results r
+		WHERE r.stale = FALSE AND NOT EXISTS (
+			SELECT 1 FROM profile_visits v
+			WHERE v.profile_url = r.profile_url AND v.visited_at >= ?
+		)
+		ORDER BY RANDOM()
+		LIMIT ?
+	`, time.Now().Add(-revisitAfter), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var urls []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+	return urls, rows.Err()
+}
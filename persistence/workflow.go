@@ -22,6 +22,13 @@ type PersonSearchResult struct {
 	DiscoveredAt  time.Time  `json:"discovered_at"`
 	Processed     bool       `json:"processed"`
 	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+
+	// ConnectionState is what the search card showed: "pending", "connected" or "" (connectable/unknown)
+	ConnectionState string `json:"connection_state,omitempty"`
+
+	// Email is the member's address if known (imported); some members only
+	// accept invites from people who can enter it
+	Email string `json:"email,omitempty"`
 }
 
 // SavePersonSearchResult saves a person search result
@@ -30,26 +37,29 @@ func (s *Store) SavePersonSearchResult(result *PersonSearchResult) error {
 		result.DiscoveredAt = time.Now()
 	}
 
-	res, err := s.db.Exec(`
+	id, err := s.db.insertID(`
 		INSERT INTO people_search_results (
 			profile_url, name, headline, company, location,
-			search_keyword, page_number, discovered_at, processed
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			search_keyword, page_number, discovered_at, processed, connection_state, email
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(profile_url, search_keyword) DO UPDATE SET
 			name = COALESCE(excluded.name, people_search_results.name),
 			headline = COALESCE(excluded.headline, people_search_results.headline),
 			company = COALESCE(excluded.company, people_search_results.company),
-			location = COALESCE(excluded.location, people_search_results.location)
+			location = COALESCE(excluded.location, people_search_results.location),
+			connection_state = COALESCE(NULLIF(excluded.connection_state, ''), people_search_results.connection_state),
+			email = COALESCE(NULLIF(excluded.email, ''), people_search_results.email),
+			discovered_at = CASE WHEN people_search_results.stale THEN excluded.discovered_at ELSE people_search_results.discovered_at END,
+			stale = FALSE
 	`, result.ProfileURL, result.Name, result.Headline, result.Company,
 		result.Location, result.SearchKeyword, result.PageNumber,
-		result.DiscoveredAt, result.Processed)
+		result.DiscoveredAt, result.Processed, result.ConnectionState, result.Email)
 
 	if err != nil {
 		return fmt.Errorf("failed to save person search result: %w", err)
 	}
 
 	if result.ID == 0 {
-		id, _ := res.LastInsertId()
 		result.ID = id
 	}
 
@@ -59,17 +69,21 @@ func (s *Store) SavePersonSearchResult(result *PersonSearchResult) error {
 
 // SavePersonSearchResults saves multiple person search results in a batch
 func (s *Store) SavePersonSearchResults(results []PersonSearchResult) error {
-	return s.Transaction(func(tx *sql.Tx) error {
+	return s.Transaction(func(tx *Tx) error {
 		stmt, err := tx.Prepare(`
 			INSERT INTO people_search_results (
 				profile_url, name, headline, company, location,
-				search_keyword, page_number, discovered_at, processed
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+				search_keyword, page_number, discovered_at, processed, connection_state, email
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			ON CONFLICT(profile_url, search_keyword) DO UPDATE SET
 				name = COALESCE(excluded.name, people_search_results.name),
 				headline = COALESCE(excluded.headline, people_search_results.headline),
 				company = COALESCE(excluded.company, people_search_results.company),
-				location = COALESCE(excluded.location, people_search_results.location)
+				location = COALESCE(excluded.location, people_search_results.location),
+			connection_state = COALESCE(NULLIF(excluded.connection_state, ''), people_search_results.connection_state),
+			email = COALESCE(NULLIF(excluded.email, ''), people_search_results.email),
+			discovered_at = CASE WHEN people_search_results.stale THEN excluded.discovered_at ELSE people_search_results.discovered_at END,
+			stale = FALSE
 		`)
 		if err != nil {
 			return err
@@ -85,6 +99,7 @@ func (s *Store) SavePersonSearchResults(results []PersonSearchResult) error {
 				results[i].ProfileURL, results[i].Name, results[i].Headline,
 				results[i].Company, results[i].Location, results[i].SearchKeyword,
 				results[i].PageNumber, results[i].DiscoveredAt, results[i].Processed,
+				results[i].ConnectionState, results[i].Email,
 			)
 			if err != nil {
 				return err
@@ -95,12 +110,13 @@ func (s *Store) SavePersonSearchResults(results []PersonSearchResult) error {
 }
 
 // GetUnprocessedPeopleResults returns people search results that haven't been processed
-func (s *Store) GetUnprocessedPeopleResults(searchKeyword string, limit int) ([]PersonSearchResult, error) {
+// maxAgeDays excludes results discovered more than that many days ago (0 = no limit)
+func (s *Store) GetUnprocessedPeopleResults(searchKeyword string, limit int, maxAgeDays int) ([]PersonSearchResult, error) {
 	query := `
 		SELECT id, profile_url, name, headline, company, location,
-			   search_keyword, page_number, discovered_at, processed, processed_at
+			   search_keyword, page_number, discovered_at, processed, processed_at, connection_state, email
 		FROM people_search_results
-		WHERE processed = FALSE
+		WHERE processed = FALSE AND stale = FALSE
 	`
 	args := []interface{}{}
 
@@ -109,6 +125,11 @@ func (s *Store) GetUnprocessedPeopleResults(searchKeyword string, limit int) ([]
 		args = append(args, searchKeyword)
 	}
 
+	if maxAgeDays > 0 {
+		query += " AND discovered_at >= ?"
+		args = append(args, time.Now().AddDate(0, 0, -maxAgeDays))
+	}
+
 	query += " ORDER BY discovered_at ASC"
 
 	if limit > 0 {
@@ -124,6 +145,62 @@ func (s *Store) GetUnprocessedPeopleResults(searchKeyword string, limit int) ([]
 	return scanPersonResults(rows)
 }
 
+// RequeueStaleResults marks unprocessed results older than maxAgeDays as stale
+// Stale rows are skipped by the connect workflow and treated as new when a
+// search finds them again, which refreshes their discovered_at
+func (s *Store) RequeueStaleResults(maxAgeDays int) (int64, error) {
+	if maxAgeDays <= 0 {
+		return 0, nil
+	}
+
+	res, err := s.db.Exec(`
+		UPDATE people_search_results
+		SET stale = TRUE
+		WHERE processed = FALSE AND stale = FALSE AND discovered_at < ?
+	`, time.Now().AddDate(0, 0, -maxAgeDays))
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue stale results: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+// ResetProcessed marks processed people results unprocessed again so the
+// connect workflow revisits them (e.g. after changing the note strategy)
+// keyword limits the reset to one search keyword ("" = every keyword).
+// People already connected or with an accepted request are never reset;
+// with onlyFailed, neither is anyone with a pending request - only profiles
+// whose invite never went out or was declined/withdrawn come back.
+func (s *Store) ResetProcessed(keyword string, onlyFailed bool) (int64, error) {
+	query := `
+		UPDATE people_search_results
+		SET processed = FALSE, processed_at = NULL
+		WHERE processed = TRUE
+			AND profile_url NOT IN (SELECT profile_url FROM connections)
+			AND profile_url NOT IN (
+				SELECT profile_url FROM connection_requests WHERE status IN (?, ?)
+			)
+	`
+	// Without onlyFailed, pending profiles are reset too; HasSentRequest still
+	// keeps the connect workflow from inviting them twice.
+	blocking := StatusAccepted
+	if onlyFailed {
+		blocking = StatusPending
+	}
+	args := []interface{}{StatusAccepted, blocking}
+
+	if keyword != "" {
+		query += " AND search_keyword = ?"
+		args = append(args, keyword)
+	}
+
+	res, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset processed results: %w", err)
+	}
+	return res.RowsAffected()
+}
+
 // MarkPersonProcessed marks a person search result as processed
 func (s *Store) MarkPersonProcessed(profileURL string) error {
 	_, err := s.db.Exec(`
@@ -134,11 +211,41 @@ func (s *Store) MarkPersonProcessed(profileURL string) error {
 	return err
 }
 
+// UpdatePersonDetails stores what a profile visit showed on the lead's search
+// results; empty values keep what was stored before
+func (s *Store) UpdatePersonDetails(profileURL, name, headline, company, location string) error {
+	_, err := s.db.Exec(`
+		UPDATE people_search_results SET
+			name = COALESCE(NULLIF(?, ''), name),
+			headline = COALESCE(NULLIF(?, ''), headline),
+			company = COALESCE(NULLIF(?, ''), company),
+			location = COALESCE(NULLIF(?, ''), location)
+		WHERE profile_url = ?
+	`, name, headline, company, location, profileURL)
+	if err != nil {
+		return fmt.Errorf("failed to update person details: %w", err)
+	}
+	return nil
+}
+
+// SetConnectionState stores the connection state a search card showed for a
+// lead ("" clears it, e.g. once its invite was withdrawn)
+func (s *Store) SetConnectionState(profileURL, state string) error {
+	_, err := s.db.Exec(`
+		UPDATE people_search_results SET connection_state = NULLIF(?, '')
+		WHERE profile_url = ?
+	`, state, profileURL)
+	if err != nil {
+		return fmt.Errorf("failed to update connection state: %w", err)
+	}
+	return nil
+}
+
 // GetPeopleByKeyword returns all people results for a search keyword
 func (s *Store) GetPeopleByKeyword(keyword string) ([]PersonSearchResult, error) {
 	rows, err := s.db.Query(`
 		SELECT id, profile_url, name, headline, company, location,
-			   search_keyword, page_number, discovered_at, processed, processed_at
+			   search_keyword, page_number, discovered_at, processed, processed_at, connection_state, email
 		FROM people_search_results
 		WHERE search_keyword = ?
 		ORDER BY page_number ASC, discovered_at ASC
@@ -151,11 +258,11 @@ func (s *Store) GetPeopleByKeyword(keyword string) ([]PersonSearchResult, error)
 	return scanPersonResults(rows)
 }
 
-// HasPersonResult checks if a profile URL exists in people search results
+// HasPersonResult checks if a profile URL exists in people search results (stale rows don't count)
 func (s *Store) HasPersonResult(profileURL string) (bool, error) {
 	var count int
 	err := s.db.QueryRow(`
-		SELECT COUNT(*) FROM people_search_results WHERE profile_url = ?
+		SELECT COUNT(*) FROM people_search_results WHERE profile_url = ? AND stale = FALSE
 	`, profileURL).Scan(&count)
 	return count > 0, err
 }
@@ -174,6 +281,28 @@ func (s *Store) GetPeopleSearchProgress(keyword string) (int, error) {
 	return int(maxPage.Int64), nil
 }
 
+// GetPersonResult returns the most recent search result for a profile (nil if never found)
+func (s *Store) GetPersonResult(profileURL string) (*PersonSearchResult, error) {
+	rows, err := s.db.Query(`
+		SELECT id, profile_url, name, headline, company, location,
+			   search_keyword, page_number, discovered_at, processed, processed_at, connection_state, email
+		FROM people_search_results
+		WHERE profile_url = ?
+		ORDER BY discovered_at DESC
+		LIMIT 1
+	`, profileURL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, err := scanPersonResults(rows)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
 // GetPeopleSearchStats returns statistics for people search
 func (s *Store) GetPeopleSearchStats(keyword string) (total int, processed int, err error) {
 	query := `SELECT COUNT(*), COALESCE(SUM(CASE WHEN processed THEN 1 ELSE 0 END), 0) FROM people_search_results`
@@ -194,12 +323,12 @@ func scanPersonResults(rows *sql.Rows) ([]PersonSearchResult, error) {
 	for rows.Next() {
 		var result PersonSearchResult
 		var processedAt sql.NullTime
-		var name, headline, company, location sql.NullString
+		var name, headline, company, location, connectionState, email sql.NullString
 
 		err := rows.Scan(
 			&result.ID, &result.ProfileURL, &name, &headline, &company, &location,
 			&result.SearchKeyword, &result.PageNumber,
-			&result.DiscoveredAt, &result.Processed, &processedAt,
+			&result.DiscoveredAt, &result.Processed, &processedAt, &connectionState, &email,
 		)
 		if err != nil {
 			return nil, err
@@ -220,6 +349,8 @@ func scanPersonResults(rows *sql.Rows) ([]PersonSearchResult, error) {
 		if processedAt.Valid {
 			result.ProcessedAt = &processedAt.Time
 		}
+		result.ConnectionState = connectionState.String
+		result.Email = email.String
 
 		results = append(results, result)
 	}
@@ -251,17 +382,17 @@ func (s *Store) SaveCompanySearchResult(result *CompanySearchResult) error {
 		result.DiscoveredAt = time.Now()
 	}
 
-	res, err := s.db.Exec(`
+	id, err := s.db.insertID(`
 		INSERT INTO company_search_results (
 			company_url, name, industry, location, employee_count,
 			description, search_keyword, page_number, discovered_at, processed
 		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(company_url, search_keyword) DO UPDATE SET
-			name = COALESCE(excluded.name, company_search_results.name),
-			industry = COALESCE(excluded.industry, company_search_results.industry),
-			location = COALESCE(excluded.location, company_search_results.location),
-			employee_count = COALESCE(excluded.employee_count, company_search_results.employee_count),
-			description = COALESCE(excluded.description, company_search_results.description)
+			name = COALESCE(NULLIF(excluded.name, ''), company_search_results.name),
+			industry = COALESCE(NULLIF(excluded.industry, ''), company_search_results.industry),
+			location = COALESCE(NULLIF(excluded.location, ''), company_search_results.location),
+			employee_count = COALESCE(NULLIF(excluded.employee_count, ''), company_search_results.employee_count),
+			description = COALESCE(NULLIF(excluded.description, ''), company_search_results.description)
 	`, result.CompanyURL, result.Name, result.Industry, result.Location,
 		result.EmployeeCount, result.Description, result.SearchKeyword,
 		result.PageNumber, result.DiscoveredAt, result.Processed)
@@ -271,7 +402,6 @@ func (s *Store) SaveCompanySearchResult(result *CompanySearchResult) error {
 	}
 
 	if result.ID == 0 {
-		id, _ := res.LastInsertId()
 		result.ID = id
 	}
 
@@ -280,18 +410,18 @@ func (s *Store) SaveCompanySearchResult(result *CompanySearchResult) error {
 
 // SaveCompanySearchResults saves multiple company search results in a batch
 func (s *Store) SaveCompanySearchResults(results []CompanySearchResult) error {
-	return s.Transaction(func(tx *sql.Tx) error {
+	return s.Transaction(func(tx *Tx) error {
 		stmt, err := tx.Prepare(`
 			INSERT INTO company_search_results (
 				company_url, name, industry, location, employee_count,
 				description, search_keyword, page_number, discovered_at, processed
 			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			ON CONFLICT(company_url, search_keyword) DO UPDATE SET
-				name = COALESCE(excluded.name, company_search_results.name),
-				industry = COALESCE(excluded.industry, company_search_results.industry),
-				location = COALESCE(excluded.location, company_search_results.location),
-				employee_count = COALESCE(excluded.employee_count, company_search_results.employee_count),
-				description = COALESCE(excluded.description, company_search_results.description)
+				name = COALESCE(NULLIF(excluded.name, ''), company_search_results.name),
+				industry = COALESCE(NULLIF(excluded.industry, ''), company_search_results.industry),
+				location = COALESCE(NULLIF(excluded.location, ''), company_search_results.location),
+				employee_count = COALESCE(NULLIF(excluded.employee_count, ''), company_search_results.employee_count),
+				description = COALESCE(NULLIF(excluded.description, ''), company_search_results.description)
 		`)
 		if err != nil {
 			return err
@@ -411,6 +541,31 @@ func (s *Store) GetCompanySearchStats(keyword string) (total int, processed int,
 	return
 }
 
+// GetCompanyForPerson returns the company search result matching the company of a
+// discovered person (case-insensitive name match), or nil when the company was never searched
+func (s *Store) GetCompanyForPerson(profileURL string) (*CompanySearchResult, error) {
+	rows, err := s.db.Query(`
+		SELECT c.id, c.company_url, c.name, c.industry, c.location, c.employee_count,
+			   c.description, c.search_keyword, c.page_number, c.discovered_at,
+			   c.processed, c.processed_at
+		FROM people_search_results p
+		JOIN company_search_results c ON LOWER(TRIM(c.name)) = LOWER(TRIM(p.company))
+		WHERE p.profile_url = ? AND p.company IS NOT NULL AND p.company != ''
+		ORDER BY c.discovered_at DESC
+		LIMIT 1
+	`, profileURL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, err := scanCompanyResults(rows)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
 func scanCompanyResults(rows *sql.Rows) ([]CompanySearchResult, error) {
 	var results []CompanySearchResult
 
@@ -537,8 +692,8 @@ func (s *Store) SaveSearchResults(results []SearchResult) error {
 }
 
 // GetUnprocessedSearchResults returns unprocessed people results (backward compatibility)
-func (s *Store) GetUnprocessedSearchResults(searchKeyword string, limit int) ([]SearchResult, error) {
-	people, err := s.GetUnprocessedPeopleResults(searchKeyword, limit)
+func (s *Store) GetUnprocessedSearchResults(searchKeyword string, limit int, maxAgeDays int) ([]SearchResult, error) {
+	people, err := s.GetUnprocessedPeopleResults(searchKeyword, limit, maxAgeDays)
 	if err != nil {
 		return nil, err
 	}
@@ -610,6 +765,7 @@ const (
 	WorkflowTypeSearch  = "search"
 	WorkflowTypeConnect = "connect"
 	WorkflowTypeMessage = "message"
+	WorkflowTypeSession = "session"
 )
 
 // SaveWorkflowState saves or updates workflow state
@@ -628,7 +784,7 @@ func (s *Store) SaveWorkflowState(state *WorkflowState) error {
 
 	if state.ID == 0 {
 		// Insert new
-		result, err := s.db.Exec(`
+		id, err := s.db.insertID(`
 			INSERT INTO workflow_state (
 				workflow_type, status, current_step, current_index,
 				total_items, started_at, error_message, metadata
@@ -640,8 +796,11 @@ func (s *Store) SaveWorkflowState(state *WorkflowState) error {
 			return fmt.Errorf("failed to save workflow state: %w", err)
 		}
 
-		id, _ := result.LastInsertId()
 		state.ID = id
+		s.LogEvent(EventWorkflow, state.WorkflowType+" started", map[string]interface{}{
+			"workflow_id": id,
+			"total_items": state.TotalItems,
+		})
 	} else {
 		// Update existing
 		_, err := s.db.Exec(`
@@ -699,6 +858,9 @@ func (s *Store) PauseWorkflow(workflowID int64) error {
 		SET status = ?, paused_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`, WorkflowStatusPaused, workflowID)
+	if err == nil {
+		s.logWorkflowEvent(workflowID, "paused", "")
+	}
 	return err
 }
 
@@ -709,6 +871,9 @@ func (s *Store) CompleteWorkflow(workflowID int64) error {
 		SET status = ?, completed_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`, WorkflowStatusCompleted, workflowID)
+	if err == nil {
+		s.logWorkflowEvent(workflowID, "completed", "")
+	}
 	return err
 }
 
@@ -719,9 +884,31 @@ func (s *Store) FailWorkflow(workflowID int64, errMsg string) error {
 		SET status = ?, error_message = ?, completed_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`, WorkflowStatusFailed, errMsg, workflowID)
+	if err == nil {
+		s.logWorkflowEvent(workflowID, "failed", errMsg)
+	}
 	return err
 }
 
+// logWorkflowEvent records a workflow status change with its progress
+func (s *Store) logWorkflowEvent(workflowID int64, status, errMsg string) {
+	var workflowType string
+	var currentIndex, totalItems int
+	s.db.QueryRow(`
+		SELECT workflow_type, current_index, total_items FROM workflow_state WHERE id = ?
+	`, workflowID).Scan(&workflowType, &currentIndex, &totalItems)
+
+	metadata := map[string]interface{}{
+		"workflow_id":   workflowID,
+		"current_index": currentIndex,
+		"total_items":   totalItems,
+	}
+	if errMsg != "" {
+		metadata["error"] = errMsg
+	}
+	s.LogEvent(EventWorkflow, fmt.Sprintf("%s %s", workflowType, status), metadata)
+}
+
 // UpdateWorkflowProgress updates the current progress of a workflow
 func (s *Store) UpdateWorkflowProgress(workflowID int64, currentIndex int, currentStep string) error {
 	_, err := s.db.Exec(`
@@ -780,6 +967,7 @@ type DailyStats struct {
 	ConnectionsAccepted int    `json:"connections_accepted"`
 	MessagesSent        int    `json:"messages_sent"`
 	ProfilesSearched    int    `json:"profiles_searched"`
+	NotesSkipped        int    `json:"notes_skipped"`
 }
 
 // GetDailyStats returns statistics for a specific date
@@ -790,7 +978,7 @@ func (s *Store) GetDailyStats(date string) (*DailyStats, error) {
 
 	row := s.db.QueryRow(`
 		SELECT date, connections_sent, connections_accepted, 
-			   messages_sent, profiles_searched
+			   messages_sent, profiles_searched, notes_skipped
 		FROM daily_stats
 		WHERE date = ?
 	`, date)
@@ -798,7 +986,7 @@ func (s *Store) GetDailyStats(date string) (*DailyStats, error) {
 	stats := &DailyStats{}
 	err := row.Scan(
 		&stats.Date, &stats.ConnectionsSent, &stats.ConnectionsAccepted,
-		&stats.MessagesSent, &stats.ProfilesSearched,
+		&stats.MessagesSent, &stats.ProfilesSearched, &stats.NotesSkipped,
 	)
 
 	if err == sql.ErrNoRows {
@@ -811,11 +999,11 @@ func (s *Store) GetDailyStats(date string) (*DailyStats, error) {
 func (s *Store) GetWeeklyStats() ([]DailyStats, error) {
 	rows, err := s.db.Query(`
 		SELECT date, connections_sent, connections_accepted, 
-			   messages_sent, profiles_searched
+			   messages_sent, profiles_searched, notes_skipped
 		FROM daily_stats
-		WHERE date >= date('now', '-7 days')
+		WHERE date >= ?
 		ORDER BY date DESC
-	`)
+	`, time.Now().AddDate(0, 0, -7).Format("2006-01-02"))
 	if err != nil {
 		return nil, err
 	}
@@ -825,7 +1013,7 @@ func (s *Store) GetWeeklyStats() ([]DailyStats, error) {
 	for rows.Next() {
 		var s DailyStats
 		if err := rows.Scan(&s.Date, &s.ConnectionsSent, &s.ConnectionsAccepted,
-			&s.MessagesSent, &s.ProfilesSearched); err != nil {
+			&s.MessagesSent, &s.ProfilesSearched, &s.NotesSkipped); err != nil {
 			return nil, err
 		}
 		stats = append(stats, s)
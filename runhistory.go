@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// runRecorder remembers the daily stats at the start of a run, so the run's
+// own counts can be told apart from earlier runs the same day
+type runRecorder struct {
+	summary    persistence.RunSummary
+	startStats persistence.DailyStats
+	finished   bool
+}
+
+// startRun begins recording a run of workflow
+func startRun(workflow string) *runRecorder {
+	r := &runRecorder{
+		summary: persistence.RunSummary{
+			Workflow:    workflow,
+			SafetyLevel: string(stealth.GetConfig().SafetyLevel),
+			DryRun:      DryRunMode,
+			StartedAt:   time.Now(),
+		},
+	}
+	if stats, err := store.GetDailyStats(""); err == nil {
+		r.startStats = *stats
+	}
+	return r
+}
+
+// finish works out the run's counts and saves its summary
+// runErr is the error that ended the run early (nil = completed). Only the
+// first call counts, so every exit path can call it
+func (r *runRecorder) finish(runErr error) {
+	if r.finished {
+		return
+	}
+	r.finished = true
+
+	r.summary.EndedAt = time.Now()
+	if runErr != nil {
+		r.summary.Error = runErr.Error()
+	}
+
+	// A run past midnight adds the rest of its first day to today's counts
+	var delta persistence.DailyStats
+	if today, err := store.GetDailyStats(""); err == nil {
+		delta = *today
+		if today.Date != r.startStats.Date && r.startStats.Date != "" {
+			if firstDay, err := store.GetDailyStats(r.startStats.Date); err == nil {
+				addStats(&delta, *firstDay, 1)
+			}
+		}
+		addStats(&delta, r.startStats, -1)
+	}
+	r.summary.ProfilesSearched = delta.ProfilesSearched
+	r.summary.ConnectionsSent = delta.ConnectionsSent
+	r.summary.ConnectionsAccepted = delta.ConnectionsAccepted
+	r.summary.MessagesSent = delta.MessagesSent
+	r.summary.NotesSkipped = delta.NotesSkipped
+
+	if err := store.SaveRunSummary(&r.summary); err != nil {
+		fmt.Printf("⚠️ Could not save run summary: %v\n", err)
+	}
+}
+
+// addStats adds sign * other's counts to stats
+func addStats(stats *persistence.DailyStats, other persistence.DailyStats, sign int) {
+	stats.ProfilesSearched += sign * other.ProfilesSearched
+	stats.ConnectionsSent += sign * other.ConnectionsSent
+	stats.ConnectionsAccepted += sign * other.ConnectionsAccepted
+	stats.MessagesSent += sign * other.MessagesSent
+	stats.NotesSkipped += sign * other.NotesSkipped
+}
+
+// printRunHistory prints the last limit runs, newest first
+func printRunHistory(limit int) {
+	runs, err := store.GetRunHistory(limit)
+	if err != nil {
+		fmt.Printf("⚠️ Could not load run history: %v\n", err)
+		return
+	}
+
+	fmt.Println("\n==================================================")
+	fmt.Printf("🗂️ LAST %d RUNS\n", len(runs))
+	fmt.Println("==================================================")
+	if len(runs) == 0 {
+		fmt.Println("ℹ️ No runs recorded yet")
+		return
+	}
+
+	for _, run := range runs {
+		status := "✅"
+		if run.Error != "" {
+			status = "🛑"
+		}
+		dryRun := ""
+		if run.DryRun {
+			dryRun = " [dry run]"
+		}
+		fmt.Printf("%s %s  %-11s %-18s %6v%s\n", status, run.StartedAt.Local().Format("2006-01-02 15:04"),
+			run.Workflow, run.SafetyLevel, run.Duration().Round(time.Minute), dryRun)
+		fmt.Printf("   🔍 %d discovered  🔗 %d invites  ✅ %d accepted  📬 %d messages\n",
+			run.ProfilesSearched, run.ConnectionsSent, run.ConnectionsAccepted, run.MessagesSent)
+		if run.Error != "" {
+			fmt.Printf("   ❌ %s\n", run.Error)
+		}
+	}
+}
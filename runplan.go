@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/message"
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// RunPlan is the effective behavior of a run, worked out from the config
+// constants, the rate limiter's remaining budget and the leads in the database
+type RunPlan struct {
+	// Search
+	SearchSource     string // Keyword or saved search URL the crawl uses
+	SearchPages      int
+	SearchCompanies  string
+	SearchBudgetLeft int
+	SearchInCooldown bool
+	MaxResults       int
+
+	// Connect
+	ConnectKeyword    string
+	ConnectLeads      int // Unprocessed, unsent, unblocked leads for the keyword
+	ConnectBudgetLeft int
+	ConnectInCooldown bool
+	Connects          int
+	NoteTemplate      string
+
+	// Follow-up messages
+	MessageTemplate   string
+	MessageSegment    string // Tag follow-ups are limited to ("" = everyone)
+	MessageLeads      int    // Connections old enough to message
+	MessageBudgetLeft int
+	MessageInCooldown bool
+	Messages          int
+
+	QueuedActions int
+}
+
+// BuildPlan works out what this run would do, without touching the browser
+func BuildPlan(store *persistence.Store, rl *stealth.RateLimiter) *RunPlan {
+	searchStats := rl.GetStats(stealth.ActionSearch)
+	connectStats := rl.GetStats(stealth.ActionConnection)
+	messageStats := rl.GetStats(stealth.ActionMessage)
+
+	plan := &RunPlan{
+		SearchSource:      SearchKeywordPeople,
+		SearchPages:       SearchMaxPages,
+		SearchCompanies:   SearchKeywordCompanies,
+		SearchBudgetLeft:  max(searchStats.DailyRemaining, 0),
+		SearchInCooldown:  searchStats.InCooldown,
+		MaxResults:        MaxResultsPerKeyword,
+		ConnectKeyword:    SearchKeywordPeople,
+		ConnectBudgetLeft: max(connectStats.DailyRemaining, 0),
+		ConnectInCooldown: connectStats.InCooldown,
+		NoteTemplate:      ConnectNoteTemplate,
+		MessageTemplate:   followUpTemplate(),
+		MessageSegment:    MessageSegment,
+		MessageBudgetLeft: max(messageStats.DailyRemaining, 0),
+		MessageInCooldown: messageStats.InCooldown,
+	}
+	if SavedSearchURL != "" {
+		plan.SearchSource = SavedSearchURL
+	}
+	plan.SearchPages = min(plan.SearchPages, plan.SearchBudgetLeft)
+
+	// Same selection the connect workflow starts from, minus profiles it would skip
+	unprocessed, err := store.GetUnprocessedSearchResults(SearchKeywordPeople, stealth.GetConnectionDailyLimit(), MaxLeadAgeDays)
+	if err != nil {
+		fmt.Printf("⚠️ Could not count connection leads: %v\n", err)
+	}
+	for _, r := range unprocessed {
+		if sent, _ := store.HasSentRequest(r.ProfileURL); sent {
+			continue
+		}
+		if err := stealth.CheckBlocked(r.ProfileURL, r.Company); err != nil {
+			continue
+		}
+		plan.ConnectLeads++
+	}
+	if !plan.ConnectInCooldown {
+		plan.Connects = min(plan.ConnectLeads, plan.ConnectBudgetLeft)
+	}
+
+	minAge := time.Duration(MinHoursSinceConnected) * time.Hour
+	var unmessaged []persistence.Connection
+	if OnlyMessageOurConnections {
+		unmessaged, err = store.GetUnmessagedInitiatedConnections(minAge, ourRequestMaxAge())
+	} else {
+		unmessaged, err = store.GetUnmessagedConnections(minAge)
+	}
+	if err != nil {
+		fmt.Printf("⚠️ Could not count follow-up leads: %v\n", err)
+	}
+	for _, conn := range unmessaged {
+		if MessageSegment != "" && !inMessageSegment(conn.ProfileURL) {
+			continue
+		}
+		plan.MessageLeads++
+	}
+	if !plan.MessageInCooldown {
+		plan.Messages = min(plan.MessageLeads, plan.MessageBudgetLeft)
+	}
+
+	if queued, err := store.GetQueuedActions(0); err == nil {
+		plan.QueuedActions = len(queued)
+	}
+
+	return plan
+}
+
+// Print shows the parts of the plan the given workflow acts on
+func (p *RunPlan) Print(workflow string) {
+	fmt.Println("\n==================================================")
+	fmt.Printf("🧭 TODAY'S PLAN (%s)\n", workflow)
+	fmt.Println("==================================================")
+
+	switch workflow {
+	case "search":
+		p.printSearch()
+	case "connect":
+		p.printConnects()
+	case "followup", "nurture":
+		p.printMessages()
+	case "session":
+		p.printConnects()
+		p.printMessages()
+	case "queue":
+		fmt.Printf("   ▶️ Execute %d queued actions (rate limits permitting)\n", p.QueuedActions)
+	default:
+		fmt.Println("   ℹ️ No preview for this workflow")
+	}
+
+	if DryRunMode {
+		fmt.Println("   🧪 Dry run - nothing will actually be sent")
+	}
+}
+
+func (p *RunPlan) printSearch() {
+	if p.SearchInCooldown {
+		fmt.Println("   ⏸️ Search is in cooldown - no pages will be crawled")
+		return
+	}
+	fmt.Printf("   🔍 Search %d pages of %q (%d searches left today)\n", p.SearchPages, p.SearchSource, p.SearchBudgetLeft)
+	if p.MaxResults > 0 {
+		fmt.Printf("      stopping after %d profiles\n", p.MaxResults)
+	}
+	fmt.Printf("   🏢 Search companies for %q\n", p.SearchCompanies)
+}
+
+func (p *RunPlan) printConnects() {
+	if p.ConnectInCooldown {
+		fmt.Println("   ⏸️ Connections are in cooldown - no requests will be sent")
+		return
+	}
+	fmt.Printf("   🔗 Send up to %d connection requests from keyword %q\n", p.Connects, p.ConnectKeyword)
+	fmt.Printf("      %d leads available, %d requests left today\n", p.ConnectLeads, p.ConnectBudgetLeft)
+	fmt.Printf("      note: %q\n", p.NoteTemplate)
+}
+
+func (p *RunPlan) printMessages() {
+	if p.MessageInCooldown {
+		fmt.Println("   ⏸️ Messaging is in cooldown - no follow-ups will be sent")
+		return
+	}
+	template := p.MessageTemplate
+	if template == message.AutoTemplate {
+		template = "auto (picked per headline)"
+	}
+	fmt.Printf("   📬 Send up to %d follow-ups using template %s\n", p.Messages, template)
+	if p.MessageSegment != "" {
+		fmt.Printf("      segment: %q\n", p.MessageSegment)
+	}
+	fmt.Printf("      %d connections to message, %d messages left today\n", p.MessageLeads, p.MessageBudgetLeft)
+}
@@ -0,0 +1,65 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// GetLatestPostSnippet opens a profile's recent activity and returns the text of
+// their most recent post. Returns "" (and no error) when there is no recent activity.
+func GetLatestPostSnippet(page *rod.Page, profileURL string) (string, error) {
+	activityURL := strings.TrimSuffix(strings.Split(profileURL, "?")[0], "/") + "/recent-activity/all/"
+
+	timeoutPage := page.Timeout(stealth.GetNavigationTimeout())
+	err := timeoutPage.Navigate(activityURL)
+	if err == nil {
+		err = timeoutPage.WaitStable(time.Second)
+	}
+	timeoutPage.CancelTimeout()
+	if err != nil {
+		return "", fmt.Errorf("failed to open recent activity: %w", err)
+	}
+
+	stealth.Sleep(2, 4)
+
+	result := stealth.CheckPage(page)
+	if result.HasError {
+		stealth.PrintDetectionStatus(result)
+		return "", result.Error
+	}
+
+	// Glance at the feed like a person would before reading
+	stealth.ScrollDown(page)
+	stealth.SleepMillis(500, 1200)
+
+	res, err := page.Eval(`() => {
+		const posts = document.querySelectorAll(
+			'.feed-shared-update-v2, [data-urn*="activity"], .profile-creator-shared-feed-update__container'
+		);
+		for (const post of posts) {
+			// Skip reposts/comments - only the member's own words are worth quoting
+			const header = post.querySelector('.update-components-header, .feed-shared-header');
+			if (header && /reposted|commented|liked|celebrates/i.test(header.innerText || '')) continue;
+
+			const text = post.querySelector(
+				'.update-components-text, .feed-shared-update-v2__description, .feed-shared-text'
+			);
+			if (text && text.innerText.trim()) return text.innerText.trim();
+		}
+		return '';
+	}`)
+	if err != nil {
+		return "", fmt.Errorf("failed to read recent activity: %w", err)
+	}
+
+	snippet := strings.Join(strings.Fields(res.Value.Str()), " ")
+	if snippet == "" {
+		fmt.Println("ℹ️ No recent posts on this profile")
+	}
+	return snippet, nil
+}
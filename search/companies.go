@@ -8,18 +8,21 @@ import (
 	"github.com/Nehilsa2/linkedin_automation/stealth"
 )
 
-func FindCompanies(browser *rod.Browser, keyword string, maxPages int) ([]string, error) {
+// FindCompanies crawls company search results, returning the company URLs
+// and what each result card shows about the company
+func FindCompanies(browser *rod.Browser, keyword string, maxPages int) ([]string, map[string]CompanyCard, error) {
 
 	page, err := OpenSearchPage(browser, "companies", keyword, 1)
 	if err != nil {
 		// Check if error is recoverable
 		if linkedInErr, ok := err.(*stealth.LinkedInError); ok && !linkedInErr.Recoverable {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	var allLinks []string
 	seen := make(map[string]bool)
+	cards := make(map[string]CompanyCard)
 
 	for pageNum := 1; pageNum <= maxPages; pageNum++ {
 		fmt.Println("waiting for company results")
@@ -33,6 +36,9 @@ func FindCompanies(browser *rod.Browser, keyword string, maxPages int) ([]string
 		scrollAndBrowse(page)
 
 		links, _ := ExtractCompanyProfiles(page)
+		for url, card := range ExtractCompanyCardDetails(page) {
+			cards[url] = card
+		}
 
 		for _, l := range links {
 			if !seen[l] {
@@ -49,5 +55,5 @@ func FindCompanies(browser *rod.Browser, keyword string, maxPages int) ([]string
 		}
 	}
 
-	return allLinks, nil
+	return allLinks, cards, nil
 }
@@ -1,11 +1,99 @@
 package search
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
 )
 
+// DumpHTMLOnSelectorMismatch saves the page's HTML to a search_debug_*.html
+// file whenever result cards render but none match the extraction selectors
+var DumpHTMLOnSelectorMismatch = false
+
+// ErrSelectorMismatch means LinkedIn rendered search results but none of the
+// extraction selectors matched them - the layout has probably changed
+var ErrSelectorMismatch = errors.New("search results rendered but no result cards matched - layout may have changed")
+
+// extractWithRetry runs extract and, when nothing matched, reloads the page
+// once (results sometimes render late) before deciding whether LinkedIn
+// genuinely has no results or the selectors no longer fit the layout
+func extractWithRetry(page *rod.Page, kind string, extract func(*rod.Page) ([]string, error)) ([]string, error) {
+	links, _ := extract(page)
+	if len(links) > 0 {
+		return links, nil
+	}
+
+	fmt.Println("   ⚠️ No results extracted - reloading the page once")
+	if err := page.Reload(); err == nil {
+		page.Timeout(stealth.GetNavigationTimeout()).WaitLoad()
+		stealth.Sleep(2, 4)
+		scrollAndBrowse(page)
+		if links, _ = extract(page); len(links) > 0 {
+			return links, nil
+		}
+	}
+
+	return nil, checkEmptyResults(page, kind)
+}
+
+// checkEmptyResults tells a genuinely empty search apart from selector drift
+// Returns ErrSelectorMismatch when a results container is on the page but
+// no "no results" message is shown, nil otherwise
+func checkEmptyResults(page *rod.Page, kind string) error {
+	res, err := page.Timeout(stealth.GetEvalTimeout()).Eval(`() => {
+		const noResults = document.querySelector(
+			'.search-reusables__no-results-message, .search-no-results, [data-test-search-no-results]'
+		);
+		const container = document.querySelector([
+			'.search-results-container',
+			'.reusable-search__entity-result-list',
+			'li.reusable-search__result-container',
+			'div[data-view-name="search-entity-result-universal-template"]',
+		].join(', '));
+		return { noResults: !!noResults, container: !!container };
+	}`)
+	if err != nil {
+		return nil
+	}
+
+	switch {
+	case res.Value.Get("noResults").Bool():
+		fmt.Printf("ℹ️ LinkedIn shows no %s results for this search\n", kind)
+		return nil
+	case !res.Value.Get("container").Bool():
+		fmt.Printf("⚠️ No %s results container on the page - it may not have loaded\n", kind)
+		return nil
+	}
+
+	fmt.Printf("⚠️ Selector mismatch - %s results are on the page but no cards matched; layout may have changed\n", kind)
+	if DumpHTMLOnSelectorMismatch {
+		dumpPageHTML(page, kind)
+	}
+	return ErrSelectorMismatch
+}
+
+// dumpPageHTML writes the page's HTML next to the binary for selector debugging
+func dumpPageHTML(page *rod.Page, kind string) {
+	html, err := page.HTML()
+	if err != nil {
+		fmt.Printf("   ⚠️ Could not read page HTML: %v\n", err)
+		return
+	}
+
+	path := fmt.Sprintf("search_debug_%s_%s.html", kind, time.Now().Format("20060102_150405"))
+	if err := os.WriteFile(path, []byte(html), 0644); err != nil {
+		fmt.Printf("   ⚠️ Could not write %s: %v\n", path, err)
+		return
+	}
+	fmt.Printf("   📄 Page HTML saved to %s\n", path)
+}
+
 // ExtractPeopleProfiles extracts LinkedIn people profile URLs
 func ExtractPeopleProfiles(page *rod.Page) ([]string, error) {
 	var results []string
@@ -29,6 +117,148 @@ func ExtractPeopleProfiles(page *rod.Page) ([]string, error) {
 	return results, nil
 }
 
+// Connection states read from a people result card's action button
+const (
+	CardStatePending     = "pending"   // Invite already sent
+	CardStateConnected   = "connected" // Card offers Message instead of Connect
+	CardStateConnectable = ""          // Card offers Connect; clears a stored state
+)
+
+// ExtractPeopleCardStates reads the action button of each people result card
+// and returns the connection state per profile URL. Cards whose button can't
+// be read are left out
+func ExtractPeopleCardStates(page *rod.Page) map[string]string {
+	loc := stealth.DetectLocale(page)
+	res, err := page.Timeout(stealth.GetEvalTimeout()).Eval(`(loc) => {
+		const states = {};
+		for (const link of document.querySelectorAll('a[href^="https://www.linkedin.com/in/"]')) {
+			const href = link.href.split('?')[0];
+			if (href in states) continue;
+
+			// Walk up to the card that holds both the link and its buttons
+			let card = link;
+			for (let i = 0; i < 8 && card; i++) {
+				card = card.parentElement;
+				if (card && card.querySelector('button')) break;
+			}
+			if (!card) continue;
+
+			for (const btn of card.querySelectorAll('button')) {
+				const text = btn.innerText.trim().toLowerCase();
+				if (loc.pending.includes(text)) { states[href] = 'pending'; break; }
+				if (loc.message.includes(text)) { states[href] = 'connected'; break; }
+				if (loc.connect.includes(text)) { states[href] = ''; break; }
+			}
+		}
+		return states;
+	}`, loc)
+	if err != nil {
+		return nil
+	}
+
+	states := make(map[string]string)
+	for url, state := range res.Value.Map() {
+		states[url] = state.Str()
+	}
+	return states
+}
+
+// PeopleCard is the text of a people result card
+type PeopleCard struct {
+	Name     string
+	Headline string
+	Location string
+	Summary  string // "Current: Engineer at Acme", when LinkedIn shows it
+}
+
+// ExtractPeopleCardDetails reads the name, headline, location and summary
+// line of each people result card, by profile URL
+func ExtractPeopleCardDetails(page *rod.Page) map[string]PeopleCard {
+	res, err := page.Timeout(stealth.GetEvalTimeout()).Eval(`() => {
+		const cards = {};
+		for (const link of document.querySelectorAll('a[href^="https://www.linkedin.com/in/"]')) {
+			const href = link.href.split('?')[0];
+			if (href in cards) continue;
+
+			const card = link.closest('li, .entity-result, [data-chameleon-result-urn]');
+			if (!card) continue;
+
+			const text = (selector) => {
+				const el = card.querySelector(selector);
+				return el ? el.innerText.trim() : '';
+			};
+			cards[href] = {
+				name: text('.entity-result__title-text a span[aria-hidden="true"]') || text('span[aria-hidden="true"]'),
+				headline: text('.entity-result__primary-subtitle'),
+				location: text('.entity-result__secondary-subtitle'),
+				summary: text('.entity-result__summary'),
+			};
+		}
+		return cards;
+	}`)
+	if err != nil {
+		return nil
+	}
+
+	cards := make(map[string]PeopleCard)
+	for url, v := range res.Value.Map() {
+		cards[url] = PeopleCard{
+			Name:     v.Get("name").Str(),
+			Headline: v.Get("headline").Str(),
+			Location: v.Get("location").Str(),
+			Summary:  v.Get("summary").Str(),
+		}
+	}
+	return cards
+}
+
+// CompanyCard is the text of a company result card
+type CompanyCard struct {
+	Name     string
+	Industry string
+	Location string
+}
+
+// ExtractCompanyCardDetails reads the name, industry and location of each
+// company result card, by company URL
+// The subtitle reads "Industry • Location"; search cards don't show the company size
+func ExtractCompanyCardDetails(page *rod.Page) map[string]CompanyCard {
+	res, err := page.Timeout(stealth.GetEvalTimeout()).Eval(`() => {
+		const cards = {};
+		for (const card of document.querySelectorAll('div[data-view-name="search-entity-result-universal-template"]')) {
+			const link = card.querySelector('a[href^="https://www.linkedin.com/company/"]');
+			if (!link) continue;
+			const href = link.href.split('?')[0];
+			if (href in cards) continue;
+
+			const text = (selector) => {
+				const el = card.querySelector(selector);
+				return el ? el.innerText.trim() : '';
+			};
+			const subtitle = text('.entity-result__primary-subtitle').split(/\s+[•·]\s+/);
+			cards[href] = {
+				name: text('.entity-result__title-text a') || link.innerText.trim(),
+				industry: subtitle[0] || '',
+				location: subtitle.slice(1).join(', '),
+			};
+		}
+		return cards;
+	}`)
+	if err != nil {
+		return nil
+	}
+
+	cards := make(map[string]CompanyCard)
+	for url, v := range res.Value.Map() {
+		cards[url] = CompanyCard{
+			Name:     v.Get("name").Str(),
+			Industry: v.Get("industry").Str(),
+			Location: v.Get("location").Str(),
+		}
+	}
+	return cards
+}
+
 func ExtractCompanyProfiles(page *rod.Page) ([]string, error) {
 
 	var results []string
@@ -12,12 +12,12 @@ import (
 // ClickNextPage clicks LinkedIn pagination "Next" button
 // Returns (hasMorePages bool, error)
 // - hasMorePages: true if successfully clicked and more pages exist
-// - error: any error that occurred during the operation
+// - error: any error that occurred during the operation (a search limit returns its LinkedInError)
 func ClickNextPage(page *rod.Page) (bool, error) {
 	fmt.Println("🔍 Looking for Next button...")
 
 	// Set timeout to prevent hanging
-	page = page.Timeout(10 * time.Second)
+	page = page.Timeout(stealth.GetEvalTimeout())
 	defer page.CancelTimeout()
 
 	// Human-like scroll to bottom to ensure pagination is loaded
@@ -27,9 +27,14 @@ func ClickNextPage(page *rod.Page) (bool, error) {
 	stealth.SleepMillis(300, 600)
 
 	// Execute JavaScript to find and click the Next button
-	result := page.MustEval(`() => {
-		// Check for LinkedIn search limit message first
-		const pageText = document.body.innerText || '';
+	res, err := page.Eval(`() => {
+		// Check for LinkedIn search limit messages first
+		// The commercial-use throttle also says "You've reached the", so it goes first
+		const pageText = (document.body.innerText || '').toLowerCase();
+		if (pageText.includes("commercial use limit")) {
+			return { found: false, disabled: false, clicked: false, limitReached: 'commercial' };
+		}
+
 		const limitPhrases = [
 			"reached the monthly limit",
 			"reached your monthly limit",
@@ -40,8 +45,8 @@ func ClickNextPage(page *rod.Page) (bool, error) {
 		];
 		
 		for (const phrase of limitPhrases) {
-			if (pageText.toLowerCase().includes(phrase.toLowerCase())) {
-				return { found: false, disabled: false, clicked: false, limitReached: true };
+			if (pageText.includes(phrase.toLowerCase())) {
+				return { found: false, disabled: false, clicked: false, limitReached: 'monthly' };
 			}
 		}
 		
@@ -146,19 +151,23 @@ func ClickNextPage(page *rod.Page) (bool, error) {
 			}
 		}
 
-		return { found: false, disabled: false, clicked: false, limitReached: false };
+		return { found: false, disabled: false, clicked: false, limitReached: '' };
 	}`)
+	if err != nil {
+		return false, fmt.Errorf("failed to find next button: %w", err)
+	}
+	result := res.Value
 
 	// Parse result using Get method for gson.JSON
 	found := result.Get("found").Bool()
 	disabled := result.Get("disabled").Bool()
 	clicked := result.Get("clicked").Bool()
-	limitReached := result.Get("limitReached").Bool()
+	limit := searchLimitType(result.Get("limitReached").Str())
 
-	// Check if LinkedIn search limit was reached
-	if limitReached {
-		fmt.Println("⚠️ LinkedIn monthly search limit reached - cannot continue pagination")
-		return false, nil
+	// Check if a LinkedIn search limit was reached
+	if limit != "" {
+		printSearchLimit(limit)
+		return false, stealth.NewError(limit)
 	}
 
 	// No button found - end of results
@@ -181,7 +190,9 @@ func ClickNextPage(page *rod.Page) (bool, error) {
 	// Success
 	fmt.Println("✅ Clicked Next button")
 	stealth.Sleep(2, 4) // Random wait for page to load
-	page.MustWaitStable()
+	if err := page.WaitStable(time.Second); err != nil {
+		fmt.Println("⚠️ Page stability wait timed out, continuing...")
+	}
 
 	return true, nil
 }
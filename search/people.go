@@ -10,7 +10,9 @@ import (
 	"github.com/Nehilsa2/linkedin_automation/stealth"
 )
 
-func FindPeople(browser *rod.Browser, keyword string, maxPages int) ([]string, error) {
+// FindPeople crawls up to maxPages of people results for keyword
+// and stops once maxResults profiles were collected (0 = no cap)
+func FindPeople(browser *rod.Browser, keyword string, maxPages int, maxResults int) ([]string, error) {
 
 	searchURL := "https://www.linkedin.com/search/results/people/?keywords=" +
 		url.QueryEscape(keyword)
@@ -24,8 +26,8 @@ func FindPeople(browser *rod.Browser, keyword string, maxPages int) ([]string, e
 	result := stealth.CheckPage(page)
 	if result.HasError {
 		stealth.PrintDetectionStatus(result)
-		if result.Error.Type == stealth.ErrorMonthlySearchLimit {
-			fmt.Println("⚠️ Monthly search limit detected on initial load. Attempting to extract any visible profiles...")
+		if stealth.IsMonthlySearchLimit(result.Error) || stealth.IsCommercialUseLimit(result.Error) {
+			fmt.Printf("⚠️ %s on initial load. Attempting to extract any visible profiles...\n", result.Error.Message)
 			anchors, _ := page.Elements(`a[href^="https://www.linkedin.com/in/"]`)
 			for _, a := range anchors {
 				href, _ := a.Attribute("href")
@@ -53,28 +55,36 @@ func FindPeople(browser *rod.Browser, keyword string, maxPages int) ([]string, e
 
 		// ALWAYS extract profiles FIRST (even if limit reached, we want current page)
 		pageLinks := 0
-		anchors, _ := page.Elements(`a[href^="https://www.linkedin.com/in/"]`)
-
-		for _, a := range anchors {
-			href, _ := a.Attribute("href")
-			if href == nil {
-				continue
-			}
+		links, err := extractWithRetry(page, "people", ExtractPeopleProfiles)
+		if err != nil {
+			return allLinks, err
+		}
+		if len(links) == 0 {
+			break
+		}
 
-			link := strings.Split(*href, "?")[0]
+		for _, link := range links {
 			if !seen[link] {
 				seen[link] = true
 				allLinks = append(allLinks, link)
 				pageLinks++
 			}
+			if maxResults > 0 && len(allLinks) >= maxResults {
+				break
+			}
 		}
 
 		fmt.Printf("👤 Page %d → %d profiles (total: %d)\n", pageNum, pageLinks, len(allLinks))
 
-		// Check if LinkedIn monthly search limit reached AFTER extracting current page
-		limitReached := checkSearchLimitReached(page)
-		if limitReached {
-			fmt.Println("⚠️ LinkedIn monthly search limit reached - extracted current page profiles before stopping")
+		if maxResults > 0 && len(allLinks) >= maxResults {
+			fmt.Printf("🎯 Result cap reached (%d profiles)\n", maxResults)
+			break
+		}
+
+		// Check if a LinkedIn search limit was reached AFTER extracting current page
+		if limit := checkSearchLimitReached(page); limit != "" {
+			printSearchLimit(limit)
+			fmt.Println("   Extracted current page profiles before stopping")
 			fmt.Println("🔎 Extracted profiles on last page:")
 			for _, link := range allLinks {
 				fmt.Println("   ", link)
@@ -96,23 +106,35 @@ func FindPeople(browser *rod.Browser, keyword string, maxPages int) ([]string, e
 	return allLinks, nil
 }
 
-// checkSearchLimitReached checks if LinkedIn's monthly search limit message is shown
-func checkSearchLimitReached(page *rod.Page) bool {
+// checkSearchLimitReached reports which search limit the page shows ("" if none)
+// The commercial-use throttle is told apart from the monthly cap because it
+// only needs a long cooldown, not a stop until next month
+func checkSearchLimitReached(page *rod.Page) stealth.ErrorType {
 	result := page.MustEval(`() => {
-		const pageText = document.body.innerText || '';
-		const limitPhrases = [
+		const pageText = (document.body.innerText || '').toLowerCase();
+
+		// Checked first: this banner also carries the Premium upsell wording
+		const commercialPhrases = [
+			"you've reached the commercial use limit",
+			"commercial use limit"
+		];
+		for (const phrase of commercialPhrases) {
+			if (pageText.includes(phrase)) {
+				return 'commercial';
+			}
+		}
+
+		const monthlyPhrases = [
 			"reached the monthly limit",
 			"reached your monthly limit", 
-			"you've reached the commercial use limit",
-			"commercial use limit",
 			"Upgrade to Premium",
 			"Get unlimited searches",
 			"unlimited search"
 		];
 		
-		for (const phrase of limitPhrases) {
-			if (pageText.toLowerCase().includes(phrase.toLowerCase())) {
-				return true;
+		for (const phrase of monthlyPhrases) {
+			if (pageText.includes(phrase.toLowerCase())) {
+				return 'monthly';
 			}
 		}
 		
@@ -124,12 +146,32 @@ func checkSearchLimitReached(page *rod.Page) bool {
 			// Check if there's a premium upsell visible
 			const premiumUpsell = document.querySelector('[class*="premium"], [class*="upsell"]');
 			if (premiumUpsell) {
-				return true;
+				return 'monthly';
 			}
 		}
 		
-		return false;
+		return '';
 	}`)
 
-	return result.Bool()
+	return searchLimitType(result.Str())
+}
+
+// searchLimitType maps the page scripts' limit kind to its error type
+func searchLimitType(kind string) stealth.ErrorType {
+	switch kind {
+	case "commercial":
+		return stealth.ErrorCommercialUseLimit
+	case "monthly":
+		return stealth.ErrorMonthlySearchLimit
+	}
+	return ""
+}
+
+// printSearchLimit explains what a detected search limit means for the crawl
+func printSearchLimit(limit stealth.ErrorType) {
+	if limit == stealth.ErrorCommercialUseLimit {
+		fmt.Printf("⚠️ LinkedIn commercial use limit reached - searches throttled, cooling down for %v\n", stealth.CommercialUseCooldown)
+		return
+	}
+	fmt.Println("⚠️ LinkedIn monthly search limit reached - no more searches this month")
 }
@@ -0,0 +1,324 @@
+package search
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+	"github.com/go-rod/rod"
+)
+
+// PaginationState tracks how far a keyword crawl got so it can resume
+// It is JSON-friendly so workflows can persist it in workflow metadata
+type PaginationState struct {
+	Keyword        string     `json:"keyword"`
+	NextPage       int        `json:"next_page"` // 1-based page to crawl next
+	MaxPages       int        `json:"max_pages"`
+	MaxResults     int        `json:"max_results"`              // Stop after this many profiles, even mid-page (0 = no cap)
+	Captured       int        `json:"captured"`                 // Profiles captured so far for the keyword
+	LastSeenURLs   []string   `json:"last_seen_urls,omitempty"` // Results of the last crawled page
+	LimitReachedAt *time.Time `json:"limit_reached_at,omitempty"`
+	ThrottledUntil *time.Time `json:"throttled_until,omitempty"` // Commercial-use throttle cooldown end
+	Done           bool       `json:"done"`
+
+	// OnPage is called after every crawled page so callers can persist progress
+	OnPage func(state *PaginationState, pageLinks []string) `json:"-"`
+
+	// OnResults is called with the tab still on each crawled results page
+	// (after OnPage) so callers can act on its cards, e.g. connect inline
+	OnResults func(page *rod.Page, pageLinks []string) `json:"-"`
+
+	// CardStates holds the connection state (CardStatePending, CardStateConnected,
+	// CardStateConnectable) shown on the last crawled page's result cards, by profile URL
+	CardStates map[string]string `json:"-"`
+
+	// CardDetails holds the name, headline and location shown on the last
+	// crawled page's result cards, by profile URL
+	CardDetails map[string]PeopleCard `json:"-"`
+
+	// Known holds profiles already stored for the keyword and KnownThrough the
+	// highest page they came from. A page of nothing but known profiles means
+	// the crawl is retreading old ground: it jumps past KnownThrough, or stops
+	// if there is nothing beyond it, instead of paying search budget page by page
+	Known        map[string]bool `json:"-"`
+	KnownThrough int             `json:"-"`
+}
+
+// NewPaginationState creates a fresh crawl state for a keyword
+func NewPaginationState(keyword string, maxPages int) *PaginationState {
+	return &PaginationState{
+		Keyword:  keyword,
+		NextPage: 1,
+		MaxPages: maxPages,
+	}
+}
+
+// CapReached reports whether MaxResults profiles were already captured
+func (ps *PaginationState) CapReached() bool {
+	return ps.MaxResults > 0 && ps.Captured >= ps.MaxResults
+}
+
+// LimitActive reports whether the monthly search limit was hit this calendar month
+func (ps *PaginationState) LimitActive() bool {
+	if ps.LimitReachedAt == nil {
+		return false
+	}
+	now := time.Now()
+	return ps.LimitReachedAt.Year() == now.Year() && ps.LimitReachedAt.Month() == now.Month()
+}
+
+// Throttled reports whether the commercial-use throttle cooldown is still running
+func (ps *PaginationState) Throttled() bool {
+	return ps.ThrottledUntil != nil && time.Now().Before(*ps.ThrottledUntil)
+}
+
+// FindPeopleResumable crawls people results starting at state.NextPage
+// Jumps straight to the stored page (via &page=N) instead of re-crawling
+// earlier pages, so a crash on page 3 of 5 doesn't re-spend search budget
+// on pages 1-2. If the monthly limit was already hit this month, no
+// request is made at all.
+func FindPeopleResumable(browser *rod.Browser, keyword string, state *PaginationState) ([]string, error) {
+	if state == nil {
+		state = NewPaginationState(keyword, 1)
+	}
+	if state.Keyword != keyword {
+		// Different keyword - old progress doesn't apply
+		*state = PaginationState{Keyword: keyword, NextPage: 1, MaxPages: state.MaxPages, MaxResults: state.MaxResults, OnPage: state.OnPage, OnResults: state.OnResults}
+	}
+	if state.NextPage < 1 {
+		state.NextPage = 1
+	}
+
+	if state.LimitActive() {
+		fmt.Printf("⚠️ Monthly search limit was reached on %s - not searching again this month\n",
+			state.LimitReachedAt.Format("2006-01-02"))
+		return nil, stealth.NewError(stealth.ErrorMonthlySearchLimit)
+	}
+	state.LimitReachedAt = nil
+
+	if state.Throttled() {
+		fmt.Printf("⚠️ Commercial use limit cooldown runs until %s - not searching yet\n",
+			state.ThrottledUntil.Format("2006-01-02 15:04"))
+		return nil, stealth.NewError(stealth.ErrorCommercialUseLimit)
+	}
+	state.ThrottledUntil = nil
+
+	if state.Done || state.NextPage > state.MaxPages {
+		fmt.Printf("ℹ️ Search for %q already crawled %d pages\n", keyword, state.MaxPages)
+		state.Done = true
+		return nil, nil
+	}
+
+	if state.CapReached() {
+		fmt.Printf("ℹ️ Search for %q already captured %d/%d profiles\n", keyword, state.Captured, state.MaxResults)
+		state.Done = true
+		return nil, nil
+	}
+
+	if state.NextPage > 1 {
+		fmt.Printf("📌 Resuming people search %q at page %d/%d\n", keyword, state.NextPage, state.MaxPages)
+	}
+
+	page, err := OpenSearchPage(browser, "people", keyword, state.NextPage)
+	if err != nil {
+		if stealth.IsCritical(err) || !stealth.IsRecoverable(err) {
+			markLimit(state, err)
+			notify(state, nil)
+			return nil, err
+		}
+	}
+
+	var allLinks []string
+	seen := make(map[string]bool)
+	lastSeen := make(map[string]bool)
+	for _, l := range state.LastSeenURLs {
+		lastSeen[l] = true
+	}
+
+	for state.NextPage <= state.MaxPages {
+		scrollAndBrowse(page)
+
+		// A selector mismatch leaves NextPage alone so a fixed build re-crawls the page
+		links, err := extractWithRetry(page, "people", ExtractPeopleProfiles)
+		if err != nil {
+			notify(state, nil)
+			return allLinks, err
+		}
+		if len(links) == 0 {
+			state.Done = true
+			notify(state, nil)
+			break
+		}
+		state.CardStates = ExtractPeopleCardStates(page)
+		state.CardDetails = ExtractPeopleCardDetails(page)
+
+		var pageLinks []string
+		repeat := true // links is non-empty here
+		for _, l := range links {
+			if !lastSeen[l] {
+				repeat = false
+			}
+			if !seen[l] {
+				seen[l] = true
+				pageLinks = append(pageLinks, l)
+			}
+		}
+
+		// LinkedIn sometimes serves the previous page again on resume
+		if repeat {
+			fmt.Printf("ℹ️ Page %d repeats the last crawled page - skipping\n", state.NextPage)
+			pageLinks = nil
+		}
+
+		// A page we already have: skip ahead rather than re-crawl stored pages,
+		// and stop if nothing past this page could be new
+		if !repeat && state.allKnown(links) && state.KnownThrough != state.NextPage {
+			if state.KnownThrough > state.NextPage && state.KnownThrough < state.MaxPages {
+				fmt.Printf("⏩ Page %d is already stored - jumping to page %d (pages up to %d were captured before)\n",
+					state.NextPage, state.KnownThrough+1, state.KnownThrough)
+				state.LastSeenURLs = links
+				state.NextPage = state.KnownThrough + 1
+				notify(state, nil)
+				if err := jumpToPage(page, keyword, state.NextPage); err != nil {
+					markLimit(state, err)
+					notify(state, nil)
+					return allLinks, err
+				}
+				continue
+			}
+			fmt.Printf("⏹️ Page %d holds only profiles already stored - stopping to save search budget\n", state.NextPage)
+			state.LastSeenURLs = links
+			state.NextPage++
+			state.Done = true
+			notify(state, nil)
+			break
+		}
+
+		// Stop at exactly MaxResults, even mid-page
+		if state.MaxResults > 0 && state.Captured+len(pageLinks) > state.MaxResults {
+			pageLinks = pageLinks[:state.MaxResults-state.Captured]
+		}
+		state.Captured += len(pageLinks)
+
+		allLinks = append(allLinks, pageLinks...)
+		fmt.Printf("👤 Page %d → %d profiles (total: %d)\n", state.NextPage, len(pageLinks), len(allLinks))
+
+		state.LastSeenURLs = links
+		state.NextPage++
+
+		if limit := checkSearchLimitReached(page); limit != "" {
+			printSearchLimit(limit)
+			fmt.Println("   Progress saved")
+			limitErr := stealth.NewError(limit)
+			markLimit(state, limitErr)
+			notify(state, pageLinks)
+			return allLinks, limitErr
+		}
+
+		if state.CapReached() {
+			fmt.Printf("🎯 Result cap reached (%d profiles)\n", state.MaxResults)
+			state.Done = true
+			notify(state, pageLinks)
+			visitResults(page, state, pageLinks)
+			break
+		}
+
+		if state.NextPage > state.MaxPages {
+			state.Done = true
+			notify(state, pageLinks)
+			visitResults(page, state, pageLinks)
+			break
+		}
+
+		notify(state, pageLinks)
+		visitResults(page, state, pageLinks)
+
+		hasNext, err := ClickNextPage(page)
+		if stealth.IsMonthlySearchLimit(err) || stealth.IsCommercialUseLimit(err) {
+			markLimit(state, err)
+			notify(state, nil)
+			return allLinks, err
+		}
+		if !hasNext {
+			fmt.Println("ℹ️ No more pages available")
+			state.Done = true
+			notify(state, nil)
+			break
+		}
+	}
+
+	fmt.Printf("✅ Search complete: found %d new profiles\n", len(allLinks))
+	return allLinks, nil
+}
+
+// allKnown reports whether every link on a page is already stored for the keyword
+func (ps *PaginationState) allKnown(links []string) bool {
+	if len(ps.Known) == 0 || len(links) == 0 {
+		return false
+	}
+	for _, l := range links {
+		if !ps.Known[l] {
+			return false
+		}
+	}
+	return true
+}
+
+// jumpToPage loads results page pageNum of a people search in the current tab
+func jumpToPage(page *rod.Page, keyword string, pageNum int) error {
+	if err := page.Navigate(searchPageURL("people", keyword, pageNum)); err != nil {
+		return fmt.Errorf("failed to open page %d: %w", pageNum, err)
+	}
+	page.WaitLoad()
+	stealth.Sleep(2, 4)
+
+	result := stealth.CheckPage(page)
+	if result.HasError {
+		stealth.PrintDetectionStatus(result)
+		return result.Error
+	}
+	return nil
+}
+
+// markLimit records a search-limit error in the state: the monthly cap
+// blocks the rest of the month, the commercial-use throttle only a cooldown
+func markLimit(state *PaginationState, err error) {
+	now := time.Now()
+	switch {
+	case stealth.IsMonthlySearchLimit(err):
+		state.LimitReachedAt = &now
+	case stealth.IsCommercialUseLimit(err):
+		until := now.Add(stealth.CommercialUseCooldown)
+		state.ThrottledUntil = &until
+	}
+}
+
+// visitResults calls the state's OnResults callback if set, bringing the tab
+// back to the results page if the callback navigated away
+func visitResults(page *rod.Page, state *PaginationState, pageLinks []string) {
+	if state.OnResults == nil || len(pageLinks) == 0 {
+		return
+	}
+	info, err := page.Info()
+	if err != nil {
+		return
+	}
+
+	state.OnResults(page, pageLinks)
+
+	if after, err := page.Info(); err == nil && after.URL != info.URL {
+		if err := page.Navigate(info.URL); err != nil {
+			fmt.Printf("⚠️ Failed to return to the results page: %v\n", err)
+			return
+		}
+		page.WaitLoad()
+		stealth.Sleep(2, 4)
+	}
+}
+
+// notify calls the state's OnPage callback if set
+func notify(state *PaginationState, pageLinks []string) {
+	if state.OnPage != nil {
+		state.OnPage(state, pageLinks)
+	}
+}
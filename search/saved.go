@@ -0,0 +1,130 @@
+package search
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+// ParseSearchURL validates a LinkedIn search results URL and returns its search type
+// e.g. "https://www.linkedin.com/search/results/people/?keywords=go" -> "people"
+func ParseSearchURL(searchURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(searchURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid search URL: %w", err)
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("invalid search URL: unsupported scheme %q", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return "", fmt.Errorf("not a LinkedIn URL: %s", u.Hostname())
+	}
+
+	const prefix = "/search/results/"
+	if !strings.HasPrefix(u.Path, prefix) {
+		return "", fmt.Errorf("not a LinkedIn search results URL: %s", u.Path)
+	}
+
+	searchType := strings.Trim(strings.TrimPrefix(u.Path, prefix), "/")
+	if searchType == "" {
+		searchType = "all"
+	}
+
+	return searchType, nil
+}
+
+// FindFromSearchURL scrapes results from a saved LinkedIn search results URL
+// Lets power users reuse searches built in the LinkedIn UI (filters, boolean keywords)
+// without modeling every facet in Go. Company searches return company URLs,
+// everything else returns profile URLs, with the connection state their cards
+// showed (see ExtractPeopleCardStates). Stops after maxResults results (0 = no cap).
+func FindFromSearchURL(browser *rod.Browser, searchURL string, maxPages int, maxResults int) ([]string, map[string]string, error) {
+	searchType, err := ParseSearchURL(searchURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fmt.Printf("🔗 Opening saved %s search: %s\n", searchType, searchURL)
+
+	page := browser.MustPage(searchURL)
+	page.MustWaitLoad()
+	stealth.Sleep(2, 4) // Random page load delay
+
+	// Check for LinkedIn errors on initial load
+	result := stealth.CheckPage(page)
+	if result.HasError {
+		stealth.PrintDetectionStatus(result)
+		if !result.Error.Recoverable {
+			return nil, nil, result.Error
+		}
+	}
+
+	extract := ExtractPeopleProfiles
+	if searchType == "companies" {
+		extract = ExtractCompanyProfiles
+	}
+
+	var allLinks []string
+	seen := make(map[string]bool)
+	states := make(map[string]string)
+
+	for pageNum := 1; pageNum <= maxPages; pageNum++ {
+		// Human-like browsing: scroll through results naturally
+		scrollAndBrowse(page)
+
+		links, err := extractWithRetry(page, searchType, extract)
+		if err != nil {
+			return allLinks, states, err
+		}
+		if len(links) == 0 {
+			break
+		}
+		if searchType != "companies" {
+			for url, state := range ExtractPeopleCardStates(page) {
+				states[url] = state
+			}
+		}
+
+		pageLinks := 0
+		for _, l := range links {
+			if maxResults > 0 && len(allLinks) >= maxResults {
+				break
+			}
+			if !seen[l] {
+				seen[l] = true
+				allLinks = append(allLinks, l)
+				pageLinks++
+			}
+		}
+
+		fmt.Printf("🔎 Page %d → %d results (total: %d)\n", pageNum, pageLinks, len(allLinks))
+
+		if maxResults > 0 && len(allLinks) >= maxResults {
+			fmt.Printf("🎯 Result cap reached (%d results)\n", maxResults)
+			break
+		}
+
+		if limit := checkSearchLimitReached(page); limit != "" {
+			printSearchLimit(limit)
+			break
+		}
+
+		if pageNum < maxPages {
+			hasNext, _ := ClickNextPage(page)
+			if !hasNext {
+				fmt.Println("ℹ️ No more pages available")
+				break
+			}
+		}
+	}
+
+	fmt.Printf("✅ Saved search complete: found %d total results\n", len(allLinks))
+	return allLinks, states, nil
+}
@@ -1,8 +1,6 @@
 package search
 
 import (
-	"math/rand"
-
 	"github.com/go-rod/rod"
 
 	"github.com/Nehilsa2/linkedin_automation/stealth"
@@ -12,11 +10,11 @@ import (
 // It scrolls through the page with variable speeds, pauses, and occasional scroll-backs
 func scrollAndBrowse(page *rod.Page) {
 	// Random number of scroll actions (3-6 times)
-	scrollActions := 3 + rand.Intn(4)
+	scrollActions := 3 + stealth.Rand().Intn(4)
 
 	for i := 0; i < scrollActions; i++ {
 		// Random action type
-		action := rand.Float64()
+		action := stealth.Rand().Float64()
 
 		switch {
 		case action < 0.6:
@@ -56,5 +54,5 @@ func scrollToElement(page *rod.Page, selector string) error {
 // Good for pages where you want to appear like you're actually reading
 func browseResults(page *rod.Page) {
 	// Simulate natural reading pattern
-	stealth.BrowseScroll(page, 4+rand.Intn(3)) // 4-6 browse actions
+	stealth.BrowseScroll(page, 4+stealth.Rand().Intn(3)) // 4-6 browse actions
 }
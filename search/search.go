@@ -11,19 +11,7 @@ import (
 )
 
 func OpenSearchPage(browser *rod.Browser, searchType, keyword string, pageNum int) (*rod.Page, error) {
-	encoded := url.QueryEscape(keyword)
-
-	searchURL := fmt.Sprintf(
-		"https://www.linkedin.com/search/results/%s/?keywords=%s",
-		searchType,
-		encoded,
-	)
-
-	if pageNum > 1 {
-		searchURL += fmt.Sprintf("&page=%d", pageNum)
-	}
-
-	page := browser.MustPage(searchURL)
+	page := browser.MustPage(searchPageURL(searchType, keyword, pageNum))
 	page.MustWaitLoad()
 	stealth.Sleep(2, 4) // Random page load delay
 
@@ -36,3 +24,17 @@ func OpenSearchPage(browser *rod.Browser, searchType, keyword string, pageNum in
 
 	return page, nil
 }
+
+// searchPageURL builds the results URL for one page of a keyword search
+func searchPageURL(searchType, keyword string, pageNum int) string {
+	searchURL := fmt.Sprintf(
+		"https://www.linkedin.com/search/results/%s/?keywords=%s",
+		searchType,
+		url.QueryEscape(keyword),
+	)
+
+	if pageNum > 1 {
+		searchURL += fmt.Sprintf("&page=%d", pageNum)
+	}
+	return searchURL
+}
@@ -0,0 +1,180 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+const (
+	// SuggestionsURL is LinkedIn's "People you may know" page
+	SuggestionsURL = "https://www.linkedin.com/mynetwork/"
+
+	// SuggestionsSource tags leads that came from the suggestions grid
+	// (stored as their search keyword and as the connection request source)
+	SuggestionsSource = "suggestions"
+)
+
+// Suggestion is a profile card from the "People you may know" grid
+type Suggestion struct {
+	ProfileURL string
+	Name       string
+	Headline   string
+}
+
+// FindSuggestions opens the suggestions page in a new tab and scrapes suggested profiles
+// maxPages is the number of times the grid is scrolled/expanded to load more cards
+func FindSuggestions(browser *rod.Browser, maxPages int) ([]Suggestion, error) {
+	page, err := browser.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a tab for suggestions: %w", err)
+	}
+	defer page.Close()
+
+	return ScrapeSuggestions(page, maxPages)
+}
+
+// ScrapeSuggestions navigates page to the suggestions grid and scrapes it
+// The page is left on the grid so cards can be connected from directly
+func ScrapeSuggestions(page *rod.Page, maxPages int) ([]Suggestion, error) {
+	fmt.Println("👥 Opening \"People you may know\"...")
+
+	if err := page.Navigate(SuggestionsURL); err != nil {
+		return nil, fmt.Errorf("failed to open suggestions page: %w", err)
+	}
+	page.WaitLoad()
+	stealth.Sleep(3, 5)
+
+	result := stealth.CheckPage(page)
+	if result.HasError {
+		stealth.PrintDetectionStatus(result)
+		if !result.Error.Recoverable {
+			return nil, result.Error
+		}
+	}
+
+	var suggestions []Suggestion
+	seen := make(map[string]bool)
+
+	for pageNum := 1; pageNum <= maxPages; pageNum++ {
+		browseResults(page)
+
+		cards, err := extractSuggestionCards(page)
+		if err != nil {
+			return suggestions, err
+		}
+
+		added := 0
+		for _, s := range cards {
+			if seen[s.ProfileURL] {
+				continue
+			}
+			seen[s.ProfileURL] = true
+			suggestions = append(suggestions, s)
+			added++
+		}
+
+		fmt.Printf("👤 Suggestions batch %d → %d profiles (total: %d)\n", pageNum, added, len(suggestions))
+
+		if added == 0 || !showMoreSuggestions(page) {
+			break
+		}
+		stealth.Sleep(2, 4)
+	}
+
+	return suggestions, nil
+}
+
+// extractSuggestionCards reads profile link, name and headline from every suggestion card
+func extractSuggestionCards(page *rod.Page) ([]Suggestion, error) {
+	res, err := page.Eval(`() => {
+		const cards = document.querySelectorAll(
+			'[data-view-name*="cohort-card"], li.discover-entity-type-card, .discover-fluid-entity-list--item, section[class*="mn-discovery"] li'
+		);
+		const out = [];
+		for (const card of cards) {
+			const link = card.querySelector('a[href*="/in/"]');
+			if (!link) continue;
+			const nameEl = card.querySelector('[class*="person-card__name"], [class*="entity-card__name"]');
+			const headlineEl = card.querySelector('[class*="person-card__occupation"], [class*="entity-card__occupation"]');
+			out.push({
+				url: link.href.split('?')[0],
+				name: (nameEl ? nameEl.innerText : (link.innerText || '').split('\n')[0]).trim(),
+				headline: headlineEl ? headlineEl.innerText.trim() : '',
+			});
+		}
+		return out;
+	}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suggestion cards: %w", err)
+	}
+
+	var cards []Suggestion
+	for _, c := range res.Value.Arr() {
+		profileURL := strings.TrimSuffix(c.Get("url").Str(), "/")
+		if profileURL == "" {
+			continue
+		}
+		cards = append(cards, Suggestion{
+			ProfileURL: profileURL,
+			Name:       c.Get("name").Str(),
+			Headline:   c.Get("headline").Str(),
+		})
+	}
+	return cards, nil
+}
+
+// ExpandSuggestionsTo loads more of the grid until the card linking to
+// profileURL is on the page, expanding it at most maxPages-1 times like
+// ScrapeSuggestions does. Returns false if the card never shows up
+func ExpandSuggestionsTo(page *rod.Page, profileURL string, maxPages int) bool {
+	want := linkedinurl.Canonicalize(profileURL)
+	for pageNum := 1; ; pageNum++ {
+		cards, _ := extractSuggestionCards(page)
+		for _, c := range cards {
+			if linkedinurl.Canonicalize(c.ProfileURL) == want {
+				return true
+			}
+		}
+		if pageNum >= maxPages || !showMoreSuggestions(page) {
+			return false
+		}
+		stealth.Sleep(2, 4)
+	}
+}
+
+// showMoreSuggestions clicks "Show all"/"See all" to load more cards, or
+// scrolls to the bottom for infinite scroll when there is no such button
+// Returns false when there was neither a button nor room left to scroll
+func showMoreSuggestions(page *rod.Page) bool {
+	res, err := page.Eval(`() => {
+		for (const btn of document.querySelectorAll('main button')) {
+			const text = btn.innerText.trim().toLowerCase();
+			if (text === 'show all' || text === 'see all' || text === 'show more results') {
+				btn.scrollIntoView({ block: "center" });
+				btn.click();
+				return 'clicked';
+			}
+		}
+		const before = window.scrollY;
+		window.scrollTo(0, document.body.scrollHeight);
+		return window.scrollY > before ? 'scrolled' : '';
+	}`)
+	if err != nil {
+		return false
+	}
+	switch res.Value.Str() {
+	case "clicked":
+		fmt.Println("   ➕ Loading more suggestions...")
+		return true
+	case "scrolled":
+		// Infinite scroll may still load more; an empty batch ends the loop
+		return true
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+
+	"github.com/Nehilsa2/linkedin_automation/stealth"
+)
+
+const (
+	// ProfileViewersURL is LinkedIn's "Who viewed your profile" page
+	ProfileViewersURL = "https://www.linkedin.com/me/profile-views/"
+
+	// ProfileViewersSource tags leads that came from profile viewers
+	// (stored as their search keyword and as the connection request source)
+	ProfileViewersSource = "profile_viewers"
+)
+
+// ProfileViewer is a named member from "Who viewed your profile"
+// Anonymous ("LinkedIn Member", "Someone at ...") viewers have no profile link and are skipped
+type ProfileViewer struct {
+	ProfileURL string
+	Name       string
+	Headline   string
+	ViewedAt   time.Time
+}
+
+// ScrapeProfileViewers navigates page to "Who viewed your profile" and scrapes
+// the named viewers. maxPages is how many times the list is expanded
+func ScrapeProfileViewers(page *rod.Page, maxPages int) ([]ProfileViewer, error) {
+	fmt.Println("👀 Opening \"Who viewed your profile\"...")
+
+	if err := page.Navigate(ProfileViewersURL); err != nil {
+		return nil, fmt.Errorf("failed to open profile viewers page: %w", err)
+	}
+	page.WaitLoad()
+	stealth.Sleep(3, 5)
+
+	result := stealth.CheckPage(page)
+	if result.HasError {
+		stealth.PrintDetectionStatus(result)
+		if !result.Error.Recoverable {
+			return nil, result.Error
+		}
+	}
+
+	var viewers []ProfileViewer
+	seen := make(map[string]bool)
+
+	for pageNum := 1; pageNum <= maxPages; pageNum++ {
+		browseResults(page)
+
+		cards, err := extractViewerCards(page)
+		if err != nil {
+			return viewers, err
+		}
+
+		added := 0
+		for _, v := range cards {
+			if seen[v.ProfileURL] {
+				continue
+			}
+			seen[v.ProfileURL] = true
+			viewers = append(viewers, v)
+			added++
+		}
+
+		fmt.Printf("👀 Viewers batch %d → %d profiles (total: %d)\n", pageNum, added, len(viewers))
+
+		if added == 0 || !showMoreViewers(page) {
+			break
+		}
+		stealth.Sleep(2, 4)
+	}
+
+	return viewers, nil
+}
+
+// extractViewerCards reads profile link, name, headline and "viewed ... ago" from every viewer card
+func extractViewerCards(page *rod.Page) ([]ProfileViewer, error) {
+	res, err := page.Eval(`() => {
+		const cards = document.querySelectorAll(
+			'li.pv-profile-views-list__item, [data-view-name*="profile-views"] li, main section ul > li'
+		);
+		const out = [];
+		for (const card of cards) {
+			const link = card.querySelector('a[href*="/in/"]');
+			if (!link) continue;
+			const nameEl = card.querySelector('[class*="entity-lockup__title"], [class*="member-name"], span[aria-hidden="true"]');
+			const headlineEl = card.querySelector('[class*="entity-lockup__subtitle"], [class*="member-headline"]');
+			const timeEl = card.querySelector('time, [class*="entity-lockup__caption"], [class*="time-ago"]');
+			out.push({
+				url: link.href.split('?')[0],
+				name: (nameEl ? nameEl.innerText : (link.innerText || '').split('\n')[0]).trim(),
+				headline: headlineEl ? headlineEl.innerText.trim() : '',
+				viewed: timeEl ? timeEl.innerText.trim() : '',
+			});
+		}
+		return out;
+	}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read viewer cards: %w", err)
+	}
+
+	var cards []ProfileViewer
+	for _, c := range res.Value.Arr() {
+		profileURL := strings.TrimSuffix(c.Get("url").Str(), "/")
+		if profileURL == "" {
+			continue
+		}
+		cards = append(cards, ProfileViewer{
+			ProfileURL: profileURL,
+			Name:       c.Get("name").Str(),
+			Headline:   c.Get("headline").Str(),
+			ViewedAt:   parseViewedAgo(c.Get("viewed").Str(), time.Now()),
+		})
+	}
+	return cards, nil
+}
+
+// showMoreViewers clicks "Show more results" to load more viewers
+// Returns false if there was nothing left to load
+func showMoreViewers(page *rod.Page) bool {
+	res, err := page.Eval(`() => {
+		for (const btn of document.querySelectorAll('main button')) {
+			const text = btn.innerText.trim().toLowerCase();
+			if (text === 'show more results' || text === 'show more' || text === 'see more') {
+				btn.scrollIntoView({ block: "center" });
+				btn.click();
+				return true;
+			}
+		}
+		return false;
+	}`)
+	if err != nil || !res.Value.Bool() {
+		return false
+	}
+	fmt.Println("   ➕ Loading more viewers...")
+	return true
+}
+
+// viewedAgoPattern matches "Viewed 3h ago", "2d", "1 week ago", "5mo" and the like
+var viewedAgoPattern = regexp.MustCompile(`(\d+)\s*(mo|month|m|min|h|hour|d|day|w|week)`)
+
+// parseViewedAgo turns a relative "viewed ... ago" caption into a time
+// Captions it can't read are treated as just now - the page only lists recent views
+func parseViewedAgo(text string, now time.Time) time.Time {
+	match := viewedAgoPattern.FindStringSubmatch(strings.ToLower(text))
+	if match == nil {
+		return now
+	}
+	n, _ := strconv.Atoi(match[1])
+
+	switch match[2] {
+	case "m", "min":
+		return now.Add(-time.Duration(n) * time.Minute)
+	case "h", "hour":
+		return now.Add(-time.Duration(n) * time.Hour)
+	case "d", "day":
+		return now.AddDate(0, 0, -n)
+	case "w", "week":
+		return now.AddDate(0, 0, -7*n)
+	default:
+		return now.AddDate(0, -n, 0)
+	}
+}
@@ -0,0 +1,85 @@
+package stealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AlertNotifier is told right away about detections that need a human to
+// look at the account now, before LinkedIn escalates to a restriction
+type AlertNotifier interface {
+	Notify(err *LinkedInError, pageURL string) error
+}
+
+// ConsoleAlertNotifier prints a banner and rings the terminal bell
+type ConsoleAlertNotifier struct{}
+
+// Notify prints the alert
+func (ConsoleAlertNotifier) Notify(err *LinkedInError, pageURL string) error {
+	fmt.Println("\a\n🚨🚨🚨 ACCOUNT ALERT 🚨🚨🚨")
+	fmt.Printf("   %s\n", err.Message)
+	if pageURL != "" {
+		fmt.Printf("   Page: %s\n", pageURL)
+	}
+	fmt.Println("   All automation stopped - check the account by hand before running again")
+	return nil
+}
+
+// WebhookAlertNotifier posts alerts as JSON to a webhook URL
+// The payload carries a "text" field, so Slack/Discord-style incoming
+// webhooks show it as-is. Alerts are printed to the console as well
+type WebhookAlertNotifier struct {
+	URL    string
+	client *http.Client
+}
+
+// NewWebhookAlertNotifier creates a notifier posting to url
+func NewWebhookAlertNotifier(url string) *WebhookAlertNotifier {
+	return &WebhookAlertNotifier{URL: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify prints the alert and posts it to the webhook
+func (n *WebhookAlertNotifier) Notify(err *LinkedInError, pageURL string) error {
+	ConsoleAlertNotifier{}.Notify(err, pageURL)
+
+	payload, _ := json.Marshal(map[string]string{
+		"text":     fmt.Sprintf("🚨 LinkedIn automation stopped: %s (%s)", err.Message, pageURL),
+		"type":     string(err.Type),
+		"page_url": pageURL,
+	})
+	resp, postErr := n.client.Post(n.URL, "application/json", bytes.NewReader(payload))
+	if postErr != nil {
+		return fmt.Errorf("failed to post alert: %w", postErr)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Global alert notifier (console only unless replaced)
+var alertNotifier AlertNotifier = ConsoleAlertNotifier{}
+
+// SetAlertNotifier sets who is told about account alerts (nil = console)
+func SetAlertNotifier(n AlertNotifier) {
+	if n == nil {
+		n = ConsoleAlertNotifier{}
+	}
+	alertNotifier = n
+}
+
+// NotifyAlert reports an account alert and records it in the audit trail
+// Notification failures are printed, never returned - the stop must go ahead
+func NotifyAlert(err *LinkedInError, pageURL string) {
+	LogEvent(EventAlert, err.Message, map[string]interface{}{
+		"type":     string(err.Type),
+		"page_url": pageURL,
+	})
+	if notifyErr := alertNotifier.Notify(err, pageURL); notifyErr != nil {
+		fmt.Printf("⚠️ Could not send alert: %v\n", notifyErr)
+	}
+}
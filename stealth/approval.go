@@ -0,0 +1,84 @@
+package stealth
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrNotApproved is returned when the approval gate rejects an action
+var ErrNotApproved = errors.New("action rejected by approval gate")
+
+// ApprovalGate decides whether a write action may proceed
+//
+// WHY A GATE:
+// - Dry run sends nothing, full auto sends everything
+// - Risky campaigns benefit from a human glancing at each note/message first
+// - The gate only blocks the final send; navigation and delays stay automatic
+type ApprovalGate interface {
+	Approve(action, target, content string) bool
+}
+
+// ConsoleApprovalGate asks for approval on stdin
+type ConsoleApprovalGate struct {
+	mu     sync.Mutex
+	reader *bufio.Reader
+}
+
+// NewConsoleApprovalGate creates an approval gate that prompts on the terminal
+func NewConsoleApprovalGate() *ConsoleApprovalGate {
+	return &ConsoleApprovalGate{reader: bufio.NewReader(os.Stdin)}
+}
+
+// Approve prints the pending action and waits for y/n
+// Anything other than an explicit yes is treated as a rejection
+func (g *ConsoleApprovalGate) Approve(action, target, content string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	fmt.Println("\n🙋 APPROVAL REQUIRED")
+	fmt.Printf("   Action: %s\n", action)
+	fmt.Printf("   Target: %s\n", target)
+	if content != "" {
+		fmt.Printf("   Content (%d chars): %s\n", len(content), content)
+	}
+	fmt.Print("   Send? [y/N]: ")
+
+	answer, err := g.reader.ReadString('\n')
+	if err != nil {
+		fmt.Println("\n   ⚠️ Could not read answer - rejecting")
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	approved := answer == "y" || answer == "yes"
+	if approved {
+		fmt.Println("   ✅ Approved")
+	} else {
+		fmt.Println("   ⏭️ Rejected")
+	}
+	return approved
+}
+
+// Global approval gate (nil = approval disabled)
+var approvalGate ApprovalGate
+
+// SetApprovalGate enables confirm-before-send mode (nil disables it)
+func SetApprovalGate(gate ApprovalGate) {
+	approvalGate = gate
+}
+
+// RequireApproval asks the configured gate to approve an action
+// Returns nil when approval is disabled or granted, ErrNotApproved otherwise
+func RequireApproval(action ActionType, target, content string) error {
+	if approvalGate == nil {
+		return nil
+	}
+	if !approvalGate.Approve(string(action), target, content) {
+		return ErrNotApproved
+	}
+	return nil
+}
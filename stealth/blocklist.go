@@ -0,0 +1,39 @@
+package stealth
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBlocked is returned when a target is on the do-not-contact list
+var ErrBlocked = errors.New("target is on the do-not-contact list")
+
+// Blocklist reports whether a profile (or its company) must not be contacted
+// company may be empty when the caller doesn't know it
+type Blocklist interface {
+	BlockReason(profileURL, company string) (reason string, blocked bool)
+}
+
+// Global blocklist (nil = no blocklist)
+var blocklist Blocklist
+
+// SetBlocklist sets the do-not-contact list checked before every write action
+func SetBlocklist(b Blocklist) {
+	blocklist = b
+}
+
+// CheckBlocked returns an error wrapping ErrBlocked if the target is blocked
+func CheckBlocked(profileURL, company string) error {
+	if blocklist == nil {
+		return nil
+	}
+	reason, blocked := blocklist.BlockReason(profileURL, company)
+	if !blocked {
+		return nil
+	}
+	if reason == "" {
+		reason = "no reason given"
+	}
+	fmt.Printf("🚫 Skipping blocked target %s (%s)\n", profileURL, reason)
+	return fmt.Errorf("%w: %s", ErrBlocked, reason)
+}
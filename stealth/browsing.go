@@ -2,7 +2,8 @@ package stealth
 
 import (
 	"fmt"
-	"math/rand"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -47,8 +48,81 @@ func DefaultBrowsingConfig() *BrowsingConfig {
 	}
 }
 
-// Global browsing config
-var BrowseCfg = DefaultBrowsingConfig()
+// browsingPresets scale organic browsing with the safety level:
+// more careful levels spend more time looking around per action
+var browsingPresets = map[SafetyLevel]*BrowsingConfig{
+	SafetyUltraConservative: {
+		ProfileViewMin:    12,
+		ProfileViewMax:    25,
+		FeedScrollMin:     6,
+		FeedScrollMax:     12,
+		FeedScrolls:       5,
+		ViewAboutChance:   0.45,
+		ViewPostsChance:   0.35,
+		LikePostChance:    0.05,
+		CheckNotifyChance: 0.25,
+		BetweenActionsMin: 3,
+		BetweenActionsMax: 8,
+	},
+	SafetyConservative: DefaultBrowsingConfig(),
+	SafetyModerate: {
+		ProfileViewMin:    6,
+		ProfileViewMax:    12,
+		FeedScrollMin:     3,
+		FeedScrollMax:     6,
+		FeedScrolls:       2,
+		ViewAboutChance:   0.2,
+		ViewPostsChance:   0.15,
+		LikePostChance:    0.04,
+		CheckNotifyChance: 0.1,
+		BetweenActionsMin: 2,
+		BetweenActionsMax: 4,
+	},
+	SafetyAggressive: {
+		ProfileViewMin:    4,
+		ProfileViewMax:    8,
+		FeedScrollMin:     2,
+		FeedScrollMax:     4,
+		FeedScrolls:       1,
+		ViewAboutChance:   0.1,
+		ViewPostsChance:   0.05,
+		LikePostChance:    0.02,
+		CheckNotifyChance: 0.05,
+		BetweenActionsMin: 1,
+		BetweenActionsMax: 3,
+	},
+}
+
+// BrowsingConfigFor returns a copy of the browsing preset for a safety level
+// Unknown levels fall back to the defaults
+func BrowsingConfigFor(level SafetyLevel) *BrowsingConfig {
+	if preset, ok := browsingPresets[level]; ok {
+		cfg := *preset
+		return &cfg
+	}
+	return DefaultBrowsingConfig()
+}
+
+// Global browsing config - swapped by SetSafetyLevel, read it with GetBrowsingConfig
+var (
+	BrowseCfg   = DefaultBrowsingConfig()
+	browseCfgMu sync.RWMutex
+)
+
+// GetBrowsingConfig returns the browsing config of the current safety level
+func GetBrowsingConfig() *BrowsingConfig {
+	GetConfig()
+	browseCfgMu.RLock()
+	defer browseCfgMu.RUnlock()
+	return BrowseCfg
+}
+
+// setBrowsingConfig swaps in the browsing config for a new safety level
+func setBrowsingConfig(cfg *BrowsingConfig) {
+	browseCfgMu.Lock()
+	defer browseCfgMu.Unlock()
+	BrowseCfg = cfg
+}
 
 // OrganicBrowser handles human-like browsing behavior
 type OrganicBrowser struct {
@@ -59,7 +133,7 @@ type OrganicBrowser struct {
 // NewOrganicBrowser creates a new organic browser
 func NewOrganicBrowser(page *rod.Page) *OrganicBrowser {
 	return &OrganicBrowser{
-		config: BrowseCfg,
+		config: GetBrowsingConfig(),
 		page:   page,
 	}
 }
@@ -83,21 +157,21 @@ func (ob *OrganicBrowser) BrowseProfile(profileURL string) error {
 		return fmt.Errorf("failed to navigate to profile: %w", err)
 	}
 
-	// Wait for page to load
+	// Wait for page to load and the SPA to finish fetching profile data
 	ob.page.MustWaitLoad()
-	SleepMillis(500, 1000)
+	if err := WaitForNetworkIdle(ob.page, 500, GetStabilityTimeout()); err != nil {
+		fmt.Printf("   ⚠️ %v, continuing...\n", err)
+	}
 
 	// Check for LinkedIn errors
 	if result := CheckPage(ob.page); result.HasError {
 		return result.Error
 	}
 
-	// Random view duration
-	viewDuration := rand.Intn(ob.config.ProfileViewMax-ob.config.ProfileViewMin+1) + ob.config.ProfileViewMin
+	// View time and scroll segments scale with how much there is to read
+	viewDuration, segments := ob.profileDwell()
 	fmt.Printf("   📖 Reading profile for %d seconds...\n", viewDuration)
 
-	// Split view time into scroll segments
-	segments := 3 + rand.Intn(3) // 3-5 segments
 	segmentTime := viewDuration / segments
 
 	for i := 0; i < segments; i++ {
@@ -112,12 +186,12 @@ func (ob *OrganicBrowser) BrowseProfile(profileURL string) error {
 	}
 
 	// Maybe expand "About" section
-	if rand.Float64() < ob.config.ViewAboutChance {
+	if rng.Float64() < ob.config.ViewAboutChance {
 		ob.tryExpandAbout()
 	}
 
 	// Maybe scroll to posts/activity
-	if rand.Float64() < ob.config.ViewPostsChance {
+	if rng.Float64() < ob.config.ViewPostsChance {
 		ob.scrollToActivity()
 	}
 
@@ -125,6 +199,50 @@ func (ob *OrganicBrowser) BrowseProfile(profileURL string) error {
 	return nil
 }
 
+// fullProfileText is the amount of profile text (characters) that earns the
+// maximum view time - roughly a profile with an About section and several jobs
+const fullProfileText = 4000
+
+// profileDwell measures the loaded profile and returns the view duration (seconds)
+// and number of scroll segments. A one-line profile gets ProfileViewMin and a
+// single scroll; a long one gets up to ProfileViewMax and one segment per screen.
+// Falls back to a random duration and 3-5 segments if the page can't be measured.
+func (ob *OrganicBrowser) profileDwell() (int, int) {
+	minView, maxView := ob.config.ProfileViewMin, ob.config.ProfileViewMax
+
+	res, err := ob.page.Eval(`() => {
+		const main = document.querySelector('main') || document.body;
+		return {
+			screens: document.documentElement.scrollHeight / Math.max(window.innerHeight, 1),
+			text: (main.innerText || '').length,
+		};
+	}`)
+	if err != nil {
+		return rng.Intn(maxView-minView+1) + minView, 3 + rng.Intn(3)
+	}
+	screens := res.Value.Get("screens").Num()
+	textLen := res.Value.Get("text").Int()
+
+	// Like ThinkTimeForContent: reading time follows text length, with ±20% variance
+	fraction := math.Min(float64(textLen)/fullProfileText, 1)
+	base := float64(minView) + fraction*float64(maxView-minView)
+	viewDuration := int(math.Round(base * (0.8 + rng.Float64()*0.4)))
+	if viewDuration < minView {
+		viewDuration = minView
+	}
+
+	// One scroll per screen below the fold, plus the odd re-read
+	segments := int(math.Ceil(screens)) - 1 + rng.Intn(2)
+	if segments < 1 {
+		segments = 1
+	}
+	if segments > 8 {
+		segments = 8
+	}
+
+	return viewDuration, segments
+}
+
 // BrowseProfileQuick does a shorter profile view (for target before connect)
 func (ob *OrganicBrowser) BrowseProfileQuick(profileURL string) error {
 	fmt.Printf("👀 Quick view: %s\n", truncateURL(profileURL))
@@ -145,14 +263,14 @@ func (ob *OrganicBrowser) BrowseProfileQuick(profileURL string) error {
 	}
 
 	// Shorter view time (3-6 seconds)
-	viewTime := 3 + rand.Intn(4)
+	viewTime := 3 + rng.Intn(4)
 	fmt.Printf("   📖 Quick scan for %d seconds...\n", viewTime)
 
 	// One or two scrolls
 	ScrollDown(ob.page)
 	time.Sleep(time.Duration(viewTime) * time.Second)
 
-	if rand.Float64() < 0.5 {
+	if rng.Float64() < 0.5 {
 		ScrollDown(ob.page)
 		SleepMillis(500, 1500)
 	}
@@ -179,10 +297,10 @@ func (ob *OrganicBrowser) BrowseFeed() error {
 	}
 
 	// Random time on feed
-	feedTime := rand.Intn(ob.config.FeedScrollMax-ob.config.FeedScrollMin+1) + ob.config.FeedScrollMin
+	feedTime := rng.Intn(ob.config.FeedScrollMax-ob.config.FeedScrollMin+1) + ob.config.FeedScrollMin
 	fmt.Printf("   📜 Scrolling feed for %d seconds...\n", feedTime)
 
-	scrollCount := ob.config.FeedScrolls + rand.Intn(2) // 3-4 scrolls
+	scrollCount := ob.config.FeedScrolls + rng.Intn(2) // 3-4 scrolls
 	scrollInterval := feedTime / scrollCount
 
 	for i := 0; i < scrollCount; i++ {
@@ -190,13 +308,13 @@ func (ob *OrganicBrowser) BrowseFeed() error {
 		time.Sleep(time.Duration(scrollInterval) * time.Second)
 
 		// Random pause (reading a post)
-		if rand.Float64() < 0.4 {
+		if rng.Float64() < 0.4 {
 			SleepMillis(500, 1500)
 		}
 	}
 
 	// Very rare: like a post (keep this LOW)
-	if rand.Float64() < ob.config.LikePostChance {
+	if rng.Float64() < ob.config.LikePostChance {
 		ob.tryLikePost()
 	}
 
@@ -206,7 +324,7 @@ func (ob *OrganicBrowser) BrowseFeed() error {
 
 // CheckNotifications visits the notifications page briefly
 func (ob *OrganicBrowser) CheckNotifications() error {
-	if rand.Float64() > ob.config.CheckNotifyChance {
+	if rng.Float64() > ob.config.CheckNotifyChance {
 		return nil // Skip this time
 	}
 
@@ -220,10 +338,10 @@ func (ob *OrganicBrowser) CheckNotifications() error {
 	ob.page.MustWaitLoad()
 
 	// Brief look (2-4 seconds)
-	time.Sleep(time.Duration(2+rand.Intn(3)) * time.Second)
+	time.Sleep(time.Duration(2+rng.Intn(3)) * time.Second)
 
 	// Maybe scroll once
-	if rand.Float64() < 0.5 {
+	if rng.Float64() < 0.5 {
 		ScrollDown(ob.page)
 		SleepMillis(500, 1500)
 	}
@@ -259,7 +377,7 @@ func (ob *OrganicBrowser) scrollToActivity() {
 	}
 
 	// Pause to "read" activity
-	time.Sleep(time.Duration(2+rand.Intn(3)) * time.Second)
+	time.Sleep(time.Duration(2+rng.Intn(3)) * time.Second)
 }
 
 // tryLikePost attempts to like a post on the feed (very rare action)
@@ -267,6 +385,9 @@ func (ob *OrganicBrowser) tryLikePost() {
 	// Find like buttons - but only do this VERY rarely
 	// This is risky behavior, so we keep probability very low
 	fmt.Println("   👍 Considering liking a post...")
+	if BlockWrite("like", "") != nil {
+		return
+	}
 
 	// Just a placeholder - actual implementation would find like buttons
 	// But we keep this minimal to avoid detection
@@ -277,7 +398,7 @@ func (ob *OrganicBrowser) tryLikePost() {
 func (ob *OrganicBrowser) RandomDelay() {
 	min := ob.config.BetweenActionsMin
 	max := ob.config.BetweenActionsMax
-	delay := rand.Intn(max-min+1) + min
+	delay := rng.Intn(max-min+1) + min
 	time.Sleep(time.Duration(delay) * time.Second)
 }
 
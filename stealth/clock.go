@@ -0,0 +1,53 @@
+package stealth
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the scheduler and rate limiter what time it is
+// Swap in a ManualClock to check work-hour boundaries, lunch, limit resets
+// and cooldown expiry without waiting for the wall clock
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock reads the system time
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// SystemClock is the default Clock
+var SystemClock Clock = realClock{}
+
+// ManualClock is a Clock that only moves when told to
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock creates a clock stopped at t
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{now: t}
+}
+
+// Now returns the clock's current time
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to t
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance moves the clock forward by d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
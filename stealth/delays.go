@@ -3,14 +3,9 @@ package stealth
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"time"
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 // DelayConfig holds configuration for different delay types
 type DelayConfig struct {
 	// Action delays (between major actions like sending connection requests)
@@ -52,7 +47,7 @@ func RandomSeconds(min, max int) time.Duration {
 	if min >= max {
 		return time.Duration(min) * time.Second
 	}
-	n := rand.Intn(max-min+1) + min
+	n := rng.Intn(max-min+1) + min
 	return time.Duration(n) * time.Second
 }
 
@@ -61,7 +56,7 @@ func RandomMillis(min, max int) time.Duration {
 	if min >= max {
 		return time.Duration(min) * time.Millisecond
 	}
-	n := rand.Intn(max-min+1) + min
+	n := rng.Intn(max-min+1) + min
 	return time.Duration(n) * time.Millisecond
 }
 
@@ -70,14 +65,14 @@ func RandomSecondsFloat(min, max float64) time.Duration {
 	if min >= max {
 		return time.Duration(min * float64(time.Second))
 	}
-	n := min + rand.Float64()*(max-min)
+	n := min + rng.Float64()*(max-min)
 	return time.Duration(n * float64(time.Second))
 }
 
 // GaussianSeconds returns a normally distributed random duration
 // centered around mean with given standard deviation
 func GaussianSeconds(mean, stdDev float64) time.Duration {
-	n := rand.NormFloat64()*stdDev + mean
+	n := rng.NormFloat64()*stdDev + mean
 	// Clamp to reasonable bounds (mean ± 3*stdDev)
 	minVal := math.Max(0.5, mean-3*stdDev)
 	maxVal := mean + 3*stdDev
@@ -153,7 +148,7 @@ func JitterMillis(baseMs int, jitterPercent int) time.Duration {
 	if jitter == 0 {
 		return time.Duration(baseMs) * time.Millisecond
 	}
-	actual := baseMs + rand.Intn(2*jitter) - jitter
+	actual := baseMs + rng.Intn(2*jitter) - jitter
 	if actual < 50 {
 		actual = 50
 	}
@@ -163,7 +158,7 @@ func JitterMillis(baseMs int, jitterPercent int) time.Duration {
 // MaybeExtraDelay randomly adds an extra delay (simulates distraction)
 // probability is 0-100 (percentage chance of extra delay)
 func MaybeExtraDelay(probability int, minSec, maxSec int) {
-	if rand.Intn(100) < probability {
+	if rng.Intn(100) < probability {
 		fmt.Println("☕ Taking a short break...")
 		Sleep(minSec, maxSec)
 	}
@@ -173,14 +168,19 @@ func MaybeExtraDelay(probability int, minSec, maxSec int) {
 type ActionBurst struct {
 	actionCount int
 	burstSize   int // Actions before taking a break
+	sizeMin     int // Range the next burst size is drawn from
+	sizeMax     int
 	breakMinSec int
 	breakMaxSec int
+	configBreak bool // Break length from the safety level's break config
 }
 
 // NewActionBurst creates a new burst tracker
 func NewActionBurst(burstSize, breakMin, breakMax int) *ActionBurst {
 	return &ActionBurst{
 		burstSize:   burstSize,
+		sizeMin:     3, // 3-5 actions per later burst
+		sizeMax:     5,
 		breakMinSec: breakMin,
 		breakMaxSec: breakMax,
 	}
@@ -189,20 +189,55 @@ func NewActionBurst(burstSize, breakMin, breakMax int) *ActionBurst {
 // DefaultActionBurst returns a burst tracker with default settings
 func DefaultActionBurst() *ActionBurst {
 	return NewActionBurst(
-		3+rand.Intn(3), // 3-5 actions per burst
-		5,              // 5-15 second breaks
+		3+rng.Intn(3), // 3-5 actions per burst
+		5,             // 5-15 second breaks
 		15,
 	)
 }
 
+// NewBatchBurst splits work into batches of about batchSize actions (±1, so
+// batch boundaries don't repeat exactly) with a GetRandomBreakDuration break
+// between batches
+func NewBatchBurst(batchSize int) *ActionBurst {
+	ab := &ActionBurst{
+		sizeMin:     max(batchSize-1, 1),
+		sizeMax:     batchSize + 1,
+		configBreak: true,
+	}
+	ab.burstSize = ab.nextSize()
+	return ab
+}
+
+// nextSize draws a random burst size from the configured range
+func (ab *ActionBurst) nextSize() int {
+	return ab.sizeMin + rng.Intn(ab.sizeMax-ab.sizeMin+1)
+}
+
+// Record counts an action and reports whether the burst is complete
+// The caller takes the break (see BreakDuration); the next burst gets a new size
+func (ab *ActionBurst) Record() bool {
+	ab.actionCount++
+	if ab.actionCount < ab.burstSize {
+		return false
+	}
+	ab.actionCount = 0
+	ab.burstSize = ab.nextSize()
+	return true
+}
+
+// BreakDuration returns how long to pause between bursts
+func (ab *ActionBurst) BreakDuration() time.Duration {
+	if ab.configBreak {
+		return GetRandomBreakDuration()
+	}
+	return RandomSeconds(ab.breakMinSec, ab.breakMaxSec)
+}
+
 // Track records an action and may trigger a break
 func (ab *ActionBurst) Track() {
-	ab.actionCount++
-	if ab.actionCount >= ab.burstSize {
+	if ab.Record() {
 		fmt.Println("🧠 Taking a moment to think...")
-		Sleep(ab.breakMinSec, ab.breakMaxSec)
-		ab.actionCount = 0
-		ab.burstSize = 3 + rand.Intn(3) // Randomize next burst size
+		time.Sleep(ab.BreakDuration())
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-rod/rod"
 )
@@ -35,14 +36,20 @@ const (
 	ErrorWeeklyInviteLimit  ErrorType = "WEEKLY_INVITE_LIMIT"
 	ErrorDailyInviteLimit   ErrorType = "DAILY_INVITE_LIMIT"
 	ErrorMonthlySearchLimit ErrorType = "MONTHLY_SEARCH_LIMIT"
+	ErrorCommercialUseLimit ErrorType = "COMMERCIAL_USE_LIMIT"
 	ErrorMessageLimit       ErrorType = "MESSAGE_LIMIT"
 	ErrorTooManyRequests    ErrorType = "TOO_MANY_REQUESTS"
 
+	// Full-page "you've been browsing for a while, take a break" overlay
+	ErrorBrowsingInterstitial ErrorType = "BROWSING_INTERSTITIAL"
+
 	// Connection errors
 	ErrorAlreadyConnected ErrorType = "ALREADY_CONNECTED"
 	ErrorPendingInvite    ErrorType = "PENDING_INVITE"
 	ErrorInviteDeclined   ErrorType = "INVITE_DECLINED"
 	ErrorCannotConnect    ErrorType = "CANNOT_CONNECT"
+	ErrorNoteLimitReached ErrorType = "NOTE_LIMIT_REACHED"
+	ErrorEmailRequired    ErrorType = "EMAIL_REQUIRED" // Invite needs the member's email (raised by connect, no page pattern)
 
 	// Profile errors
 	ErrorProfileNotFound    ErrorType = "PROFILE_NOT_FOUND"
@@ -54,6 +61,10 @@ const (
 	ErrorAccountSuspended  ErrorType = "ACCOUNT_SUSPENDED"
 	ErrorAccountWarning    ErrorType = "ACCOUNT_WARNING"
 
+	// The "browsing in a way that looks automated" warning LinkedIn shows
+	// right before restricting an account - far more severe than ACCOUNT_WARNING
+	ErrorPreRestrictionWarning ErrorType = "PRE_RESTRICTION_WARNING"
+
 	// Message errors
 	ErrorMessageBlocked ErrorType = "MESSAGE_BLOCKED"
 	ErrorCannotMessage  ErrorType = "CANNOT_MESSAGE"
@@ -83,6 +94,11 @@ type DetectionResult struct {
 	Error     *LinkedInError
 	PageURL   string
 	CheckedAt time.Time
+
+	// What matched, for tuning the patterns
+	Source  string // "url", "text", "dom" or "network"
+	Pattern string // URL/text pattern or DOM check that matched
+	Snippet string // Page text around a text match, or the throttled request URL
 }
 
 // ErrorPatterns defines text patterns to look for on the page
@@ -105,6 +121,10 @@ var errorPatterns = map[ErrorType][]string{
 		"upgrade to premium",
 		"unlimited search",
 	},
+	ErrorCommercialUseLimit: {
+		"reached the commercial use limit",
+		"commercial use limit",
+	},
 	ErrorMessageLimit: {
 		"message limit",
 		"you can't send more messages",
@@ -117,6 +137,13 @@ var errorPatterns = map[ErrorType][]string{
 		"unusual activity",
 		"we've restricted your account",
 	},
+	ErrorPreRestrictionWarning: {
+		// Kept specific: the whole page text is scanned, feed posts included
+		"browsing in a way that looks automated",
+		"activity that looks automated",
+		"detected automated activity",
+		"using software that automates",
+	},
 	ErrorAccountWarning: {
 		"we noticed some activity",
 		"verify it's you",
@@ -156,6 +183,12 @@ var errorPatterns = map[ErrorType][]string{
 		"can't send invitation",
 		"connection request failed",
 	},
+	ErrorNoteLimitReached: {
+		"used all your personalized invitations",
+		"out of personalized invitations",
+		"personalized invitations for the month",
+		"send unlimited personalized invitations",
+	},
 	ErrorCannotMessage: {
 		"unable to send message",
 		"can't send message",
@@ -177,8 +210,24 @@ var errorPatterns = map[ErrorType][]string{
 		"please sign in again",
 		"you've been signed out",
 	},
+	ErrorBrowsingInterstitial: {
+		"you've been browsing for a while",
+		"you’ve been browsing for a while",
+		"time to take a break",
+		"take a break and come back",
+	},
 }
 
+// patternPriority lists error types whose phrases must be checked before the
+// rest: the pre-restriction warning also mentions "unusual activity", and the
+// commercial-use banner carries the Premium upsell wording that would
+// otherwise read as the monthly search limit
+var patternPriority = []ErrorType{ErrorPreRestrictionWarning, ErrorCommercialUseLimit}
+
+// CommercialUseCooldown is how long searching pauses after the commercial-use
+// throttle; unlike the monthly limit it can lift mid-month
+var CommercialUseCooldown = 6 * time.Hour
+
 // URL patterns that indicate specific states
 var urlPatterns = map[ErrorType][]string{
 	ErrorCheckpoint: {
@@ -202,6 +251,22 @@ func CheckPage(page *rod.Page) *DetectionResult {
 		CheckedAt: time.Now(),
 	}
 
+	// Repeated warnings make the whole bot more cautious
+	defer func() {
+		if result.HasError {
+			LogEvent(EventDetection, result.Error.Message, map[string]interface{}{
+				"type":        string(result.Error.Type),
+				"recoverable": result.Error.Recoverable,
+				"action":      string(result.Error.Action),
+			})
+			logDetection(result)
+			GetIncidentTracker().Record(result.Error)
+			if result.Error.Type == ErrorPreRestrictionWarning {
+				NotifyAlert(result.Error, result.PageURL)
+			}
+		}
+	}()
+
 	// Get current URL
 	info, err := page.Info()
 	if err != nil {
@@ -216,24 +281,35 @@ func CheckPage(page *rod.Page) *DetectionResult {
 	}
 	result.PageURL = info.URL
 
+	// Throttling seen on the page's XHRs, which may never render a banner
+	if netErr, throttledURL := checkNetworkThrottle(page); netErr != nil {
+		result.HasError = true
+		result.Error = netErr
+		result.Source, result.Pattern, result.Snippet = "network", "HTTP 429", throttledURL
+		return result
+	}
+
 	// Check URL patterns first (faster)
-	if urlErr := checkURLPatterns(info.URL); urlErr != nil {
+	if urlErr, pattern := checkURLPatterns(info.URL); urlErr != nil {
 		result.HasError = true
 		result.Error = urlErr
+		result.Source, result.Pattern = "url", pattern
 		return result
 	}
 
 	// Check page content for error patterns
-	if contentErr := checkPageContent(page); contentErr != nil {
+	if contentErr, pattern, snippet := checkPageContent(page); contentErr != nil {
 		result.HasError = true
 		result.Error = contentErr
+		result.Source, result.Pattern, result.Snippet = "text", pattern, snippet
 		return result
 	}
 
 	// Check for specific DOM elements that indicate errors
-	if domErr := checkDOMElements(page); domErr != nil {
+	if domErr, check := checkDOMElements(page); domErr != nil {
 		result.HasError = true
 		result.Error = domErr
+		result.Source, result.Pattern = "dom", check
 		return result
 	}
 
@@ -241,50 +317,79 @@ func CheckPage(page *rod.Page) *DetectionResult {
 }
 
 // checkURLPatterns checks the URL for known error patterns
-func checkURLPatterns(url string) *LinkedInError {
+// Returns the error and the pattern that matched
+func checkURLPatterns(url string) (*LinkedInError, string) {
 	urlLower := strings.ToLower(url)
 
 	for errType, patterns := range urlPatterns {
 		for _, pattern := range patterns {
 			if strings.Contains(urlLower, pattern) {
-				return createError(errType)
+				return createError(errType), pattern
 			}
 		}
 	}
 
-	return nil
+	return nil, ""
 }
 
 // checkPageContent checks page text for error messages
-func checkPageContent(page *rod.Page) *LinkedInError {
+// Returns the error, the pattern that matched and the text around it
+func checkPageContent(page *rod.Page) (*LinkedInError, string, string) {
 	// Get page text content (with timeout)
-	page = page.Timeout(5 * time.Second)
+	page = page.Timeout(GetDetectionTimeout())
 	defer page.CancelTimeout()
 
 	textContent, err := page.Eval(`() => {
 		return document.body ? document.body.innerText.toLowerCase() : '';
 	}`)
 	if err != nil {
-		return nil // Don't fail on eval error, just skip this check
+		return nil, "", "" // Don't fail on eval error, just skip this check
 	}
 
 	pageText := textContent.Value.String()
 
+	for _, errType := range patternPriority {
+		for _, pattern := range errorPatterns[errType] {
+			if i := strings.Index(pageText, strings.ToLower(pattern)); i >= 0 {
+				return createError(errType), pattern, snippetAround(pageText, i, len(pattern))
+			}
+		}
+	}
+
 	// Check each error type's patterns
 	for errType, patterns := range errorPatterns {
 		for _, pattern := range patterns {
-			if strings.Contains(pageText, strings.ToLower(pattern)) {
-				return createError(errType)
+			if i := strings.Index(pageText, strings.ToLower(pattern)); i >= 0 {
+				return createError(errType), pattern, snippetAround(pageText, i, len(pattern))
 			}
 		}
 	}
 
-	return nil
+	return nil, "", ""
+}
+
+// snippetContext is how many bytes of page text are kept on each side of a match
+const snippetContext = 80
+
+// snippetAround returns the match at text[start:start+length] with some
+// context on both sides, whitespace collapsed
+func snippetAround(text string, start, length int) string {
+	from := max(start-snippetContext, 0)
+	to := min(start+length+snippetContext, len(text))
+	// Don't cut multi-byte characters in half
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to++
+	}
+	return strings.Join(strings.Fields(text[from:to]), " ")
 }
 
 // checkDOMElements checks for specific DOM elements indicating errors
-func checkDOMElements(page *rod.Page) *LinkedInError {
-	page = page.Timeout(3 * time.Second)
+// Returns the error and the name of the check that fired
+func checkDOMElements(page *rod.Page) (*LinkedInError, string) {
+	page = page.Timeout(GetDetectionTimeout())
 	defer page.CancelTimeout()
 
 	// Check for common error modal/dialog elements
@@ -314,40 +419,53 @@ func checkDOMElements(page *rod.Page) *LinkedInError {
 			
 			// Sign out/session modal
 			sessionExpired: !!document.querySelector('[class*="session-expired"], [class*="sign-out-modal"]'),
+
+			// "Take a break" full-page interstitial
+			browsingInterstitial: Array.from(document.querySelectorAll(
+				'[class*="interstitial"], [class*="take-a-break"], [data-test-id*="take-a-break"]'
+			)).some(el => /break|browsing for a while/i.test(el.innerText || '')),
 		};
 		
 		return checks;
 	}`)
 
 	if err != nil {
-		return nil
+		return nil, ""
 	}
 
 	checks := result.Value.Map()
 
 	if val, ok := checks["captcha"]; ok && val.Bool() {
-		return createError(ErrorCaptcha)
+		return createError(ErrorCaptcha), "captcha"
 	}
 	if val, ok := checks["restricted"]; ok && val.Bool() {
-		return createError(ErrorAccountRestricted)
+		return createError(ErrorAccountRestricted), "restricted"
 	}
 	if val, ok := checks["verify"]; ok && val.Bool() {
-		return createError(ErrorPhoneVerify)
+		return createError(ErrorPhoneVerify), "verify"
 	}
 	if val, ok := checks["weeklyLimit"]; ok && val.Bool() {
-		return createError(ErrorWeeklyInviteLimit)
+		return createError(ErrorWeeklyInviteLimit), "weeklyLimit"
 	}
 	if val, ok := checks["connectionLimit"]; ok && val.Bool() {
-		return createError(ErrorWeeklyInviteLimit)
+		return createError(ErrorWeeklyInviteLimit), "connectionLimit"
 	}
 	if val, ok := checks["profileUnavailable"]; ok && val.Bool() {
-		return createError(ErrorProfileUnavailable)
+		return createError(ErrorProfileUnavailable), "profileUnavailable"
 	}
 	if val, ok := checks["sessionExpired"]; ok && val.Bool() {
-		return createError(ErrorSessionExpired)
+		return createError(ErrorSessionExpired), "sessionExpired"
+	}
+	if val, ok := checks["browsingInterstitial"]; ok && val.Bool() {
+		return createError(ErrorBrowsingInterstitial), "browsingInterstitial"
 	}
 
-	return nil
+	return nil, ""
+}
+
+// NewError creates a LinkedInError of the given type (for errors detected outside CheckPage)
+func NewError(errType ErrorType) *LinkedInError {
+	return createError(errType)
 }
 
 // createError creates a LinkedInError with appropriate metadata
@@ -397,6 +515,11 @@ func createError(errType ErrorType) *LinkedInError {
 		err.Recoverable = false
 		err.Action = ActionStop
 
+	case ErrorCommercialUseLimit:
+		err.Message = "Commercial use limit reached - searches throttled for a while"
+		err.Recoverable = true
+		err.Action = ActionCooldown
+
 	case ErrorMessageLimit:
 		err.Message = "Message limit reached"
 		err.Recoverable = true
@@ -417,6 +540,11 @@ func createError(errType ErrorType) *LinkedInError {
 		err.Recoverable = true
 		err.Action = ActionCooldown
 
+	case ErrorPreRestrictionWarning:
+		err.Message = "LinkedIn warns the account looks automated - a restriction is likely next"
+		err.Recoverable = false
+		err.Action = ActionStop
+
 	case ErrorAlreadyConnected:
 		err.Message = "Already connected with this user"
 		err.Recoverable = true
@@ -437,6 +565,16 @@ func createError(errType ErrorType) *LinkedInError {
 		err.Recoverable = true
 		err.Action = ActionSkip
 
+	case ErrorEmailRequired:
+		err.Message = "Member only accepts invites from people who know their email address"
+		err.Recoverable = true
+		err.Action = ActionSkip
+
+	case ErrorNoteLimitReached:
+		err.Message = "Monthly personalized note limit reached (invites can still be sent without a note)"
+		err.Recoverable = true
+		err.Action = ActionContinue
+
 	case ErrorProfileNotFound:
 		err.Message = "Profile not found"
 		err.Recoverable = true
@@ -472,6 +610,11 @@ func createError(errType ErrorType) *LinkedInError {
 		err.Recoverable = true
 		err.Action = ActionCooldown
 
+	case ErrorBrowsingInterstitial:
+		err.Message = "LinkedIn \"take a break\" interstitial is blocking the page"
+		err.Recoverable = true
+		err.Action = ActionCooldown
+
 	case ErrorPageNotLoaded:
 		err.Message = "Page failed to load"
 		err.Recoverable = true
@@ -506,9 +649,16 @@ func QuickCheck(page *rod.Page) *DetectionResult {
 	}
 	result.PageURL = info.URL
 
-	if urlErr := checkURLPatterns(info.URL); urlErr != nil {
+	if urlErr, pattern := checkURLPatterns(info.URL); urlErr != nil {
 		result.HasError = true
 		result.Error = urlErr
+		result.Source, result.Pattern = "url", pattern
+		logDetection(result)
+	} else if netErr, throttledURL := checkNetworkThrottle(page); netErr != nil {
+		result.HasError = true
+		result.Error = netErr
+		result.Source, result.Pattern, result.Snippet = "network", "HTTP 429", throttledURL
+		logDetection(result)
 	}
 
 	return result
@@ -527,7 +677,31 @@ func CheckAndHandle(page *rod.Page) (bool, error) {
 	fmt.Printf("⚠️ LinkedIn Error Detected: %s\n", result.Error.Error())
 	fmt.Printf("   Suggested Action: %s\n", result.Error.Action)
 
-	switch result.Error.Action {
+	// The "take a break" overlay can sometimes just be dismissed
+	if result.Error.Type == ErrorBrowsingInterstitial && DismissBrowsingInterstitial(page) {
+		result = CheckPage(page)
+		if !result.HasError {
+			fmt.Println("✅ Interstitial dismissed")
+			return true, nil
+		}
+	}
+
+	action := result.Error.Action
+	rule := GetRecoveryPolicy().Rule(action)
+
+	// Retry: wait and re-check the page before applying the action
+	for attempt := 1; attempt <= rule.MaxRetries; attempt++ {
+		fmt.Printf("🔁 Retry %d/%d in %v...\n", attempt, rule.MaxRetries, rule.Wait())
+		time.Sleep(rule.Wait())
+
+		result = CheckPage(page)
+		if !result.HasError {
+			fmt.Println("✅ Error cleared after retry")
+			return true, nil
+		}
+	}
+
+	switch action {
 	case ActionStop:
 		fmt.Println("🛑 Stopping automation...")
 		return false, result.Error
@@ -540,16 +714,17 @@ func CheckAndHandle(page *rod.Page) (bool, error) {
 		fmt.Println("🔐 Re-authentication required.")
 		return false, result.Error
 
-	case ActionCooldown:
-		cooldownTime := 30 * time.Minute
-		fmt.Printf("⏸️ Taking cooldown break for %v...\n", cooldownTime)
-		time.Sleep(cooldownTime)
-		return true, nil
-
-	case ActionWait:
-		waitTime := 5 * time.Second
-		fmt.Printf("⏳ Waiting %v before retry...\n", waitTime)
-		time.Sleep(waitTime)
+	case ActionCooldown, ActionWait:
+		if rule.MaxRetries > 0 {
+			// Retries already waited and the error persists
+			return true, result.Error
+		}
+		if action == ActionCooldown {
+			fmt.Printf("⏸️ Taking cooldown break for %v...\n", rule.Wait())
+		} else {
+			fmt.Printf("⏳ Waiting %v before retry...\n", rule.Wait())
+		}
+		time.Sleep(rule.Wait())
 		return true, nil
 
 	case ActionSkip:
@@ -561,6 +736,35 @@ func CheckAndHandle(page *rod.Page) (bool, error) {
 	}
 }
 
+// DismissBrowsingInterstitial clicks "Continue" on the "take a break" interstitial
+// Returns false when there is no such button (the caller should cool down instead)
+func DismissBrowsingInterstitial(page *rod.Page) bool {
+	res, err := page.Eval(`() => {
+		const labels = ['continue', 'keep browsing', 'got it', 'dismiss', 'close'];
+		const containers = document.querySelectorAll(
+			'[class*="interstitial"], [class*="take-a-break"], [data-test-id*="take-a-break"], div[role="dialog"]'
+		);
+		for (const c of containers) {
+			if (!/break|browsing for a while/i.test(c.innerText || '')) continue;
+			for (const btn of c.querySelectorAll('button, a[role="button"]')) {
+				const text = (btn.innerText || btn.getAttribute('aria-label') || '').trim().toLowerCase();
+				if (labels.some(l => text === l || text.startsWith(l))) {
+					btn.click();
+					return true;
+				}
+			}
+		}
+		return false;
+	}`)
+	if err != nil || !res.Value.Bool() {
+		return false
+	}
+
+	fmt.Println("☕ Dismissed \"take a break\" interstitial")
+	SleepMillis(1000, 2000)
+	return true
+}
+
 // IsRecoverable checks if an error allows automation to continue
 func IsRecoverable(err error) bool {
 	if linkedInErr, ok := err.(*LinkedInError); ok {
@@ -578,6 +782,32 @@ func IsCritical(err error) bool {
 	return false
 }
 
+// IsNoteLimit checks if an error is the personalized-note limit
+func IsNoteLimit(err error) bool {
+	if linkedInErr, ok := err.(*LinkedInError); ok {
+		return linkedInErr.Type == ErrorNoteLimitReached
+	}
+	return false
+}
+
+// IsMonthlySearchLimit checks if an error is the monthly free-search cap
+// (searching stops until the next month)
+func IsMonthlySearchLimit(err error) bool {
+	if linkedInErr, ok := err.(*LinkedInError); ok {
+		return linkedInErr.Type == ErrorMonthlySearchLimit
+	}
+	return false
+}
+
+// IsCommercialUseLimit checks if an error is the commercial-use search throttle
+// (searching resumes after CommercialUseCooldown)
+func IsCommercialUseLimit(err error) bool {
+	if linkedInErr, ok := err.(*LinkedInError); ok {
+		return linkedInErr.Type == ErrorCommercialUseLimit
+	}
+	return false
+}
+
 // WaitForPageStable waits for page to stabilize and checks for errors
 func WaitForPageStable(page *rod.Page) *DetectionResult {
 	// Wait for network to be idle
@@ -588,9 +818,35 @@ func WaitForPageStable(page *rod.Page) *DetectionResult {
 	return CheckPage(page)
 }
 
-// MonitorPage continuously monitors a page for errors (use in goroutine)
+// networkIdleExcludes are long-lived or fire-and-forget requests that never settle
+// (realtime long-polling, tracking beacons) and would keep the page "busy" forever
+var networkIdleExcludes = []string{
+	`/realtime/`,
+	`/li/track`,
+	`/sensorCollect`,
+	`/platform-telemetry/`,
+}
+
+// WaitForNetworkIdle waits until no XHR/document requests were made for idleMs
+// MustWaitLoad only covers the load event - LinkedIn's SPA keeps fetching data
+// after that, so acting right away often finds half-rendered pages
+// Returns an error if the network is still busy when timeout expires
+func WaitForNetworkIdle(page *rod.Page, idleMs int, timeout time.Duration) error {
+	timeoutPage := page.Timeout(timeout)
+	defer timeoutPage.CancelTimeout()
+
+	wait := timeoutPage.WaitRequestIdle(time.Duration(idleMs)*time.Millisecond, nil, networkIdleExcludes, nil)
+	wait()
+
+	if err := timeoutPage.GetContext().Err(); err != nil {
+		return fmt.Errorf("network not idle after %v: %w", timeout, err)
+	}
+	return nil
+}
+
+// MonitorPage continuously monitors a page for errors every MonitorInterval (use in goroutine)
 func MonitorPage(page *rod.Page, errorChan chan<- *LinkedInError, stopChan <-chan struct{}) {
-	ticker := time.NewTicker(10 * time.Second)
+	ticker := time.NewTicker(MonitorInterval)
 	defer ticker.Stop()
 
 	for {
@@ -0,0 +1,24 @@
+package stealth
+
+// DetectionLogger keeps a record of every detection and what matched it, so
+// over-eager patterns can be found and tuned
+type DetectionLogger interface {
+	LogDetection(errType, source, pattern, snippet, pageURL string) error
+}
+
+// Global detection logger (nil = detections are not recorded)
+var detectionLogger DetectionLogger
+
+// SetDetectionLogger sets where detections are recorded
+func SetDetectionLogger(l DetectionLogger) {
+	detectionLogger = l
+}
+
+// logDetection records a detection if a logger is set
+// Failures are ignored - logging must never get in the way of handling it
+func logDetection(result *DetectionResult) {
+	if detectionLogger == nil || !result.HasError {
+		return
+	}
+	detectionLogger.LogDetection(string(result.Error.Type), result.Source, result.Pattern, result.Snippet, result.PageURL)
+}
@@ -0,0 +1,183 @@
+package stealth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/devices"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// DeviceProfileFile stores one device profile per LinkedIn account
+var DeviceProfileFile = "device_profiles.json"
+
+// DeviceProfile is the "device" an account always shows up on
+//
+// WHY PERSIST IT:
+// - Without it every page gets rod's default device: a Mac Chrome/114 UA at 1280x800
+// - A real person keeps the same laptop and screen for months; a changing one looks like several machines
+// - The user agent is the real browser's, so it only moves when Chrome updates
+type DeviceProfile struct {
+	Account          string    `json:"account"`
+	UserAgent        string    `json:"user_agent"`
+	AcceptLanguage   string    `json:"accept_language"`
+	Width            int       `json:"width"`
+	Height           int       `json:"height"`
+	DevicePixelRatio float64   `json:"device_pixel_ratio"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// commonViewports are browser viewport sizes of popular laptop and desktop screens
+var commonViewports = []struct {
+	Width, Height int
+	Ratio         float64
+}{
+	{1280, 720, 1.5},
+	{1366, 657, 1},
+	{1440, 789, 2},
+	{1536, 730, 1.25},
+	{1600, 789, 1},
+	{1680, 939, 1},
+	{1920, 969, 1},
+}
+
+// LoadDeviceProfile returns the stored profile for account, creating one the
+// first time an account is seen. account is matched case-insensitively
+// ("" = a shared default profile)
+func LoadDeviceProfile(account string) (*DeviceProfile, error) {
+	account = deviceProfileKey(account)
+
+	profiles, err := loadDeviceProfiles()
+	if err != nil {
+		return nil, err
+	}
+	if profile, ok := profiles[account]; ok {
+		return profile, nil
+	}
+
+	viewport := commonViewports[Rand().Intn(len(commonViewports))]
+	profile := &DeviceProfile{
+		Account:          account,
+		AcceptLanguage:   "en-US,en;q=0.9",
+		Width:            viewport.Width,
+		Height:           viewport.Height,
+		DevicePixelRatio: viewport.Ratio,
+		CreatedAt:        time.Now(),
+	}
+	profiles[account] = profile
+	if err := saveDeviceProfiles(profiles); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("🖥️ Created device profile for %s: %dx%d @%gx\n", account, profile.Width, profile.Height, profile.DevicePixelRatio)
+	return profile, nil
+}
+
+// WindowSize is the Chrome window size for the profile's viewport
+// (the viewport plus room for tabs and the address bar)
+func (p *DeviceProfile) WindowSize() string {
+	return fmt.Sprintf("%d,%d", p.Width, p.Height+browserChromeHeight)
+}
+
+// browserChromeHeight is roughly how tall Chrome's tab strip and toolbar are
+const browserChromeHeight = 85
+
+// Apply makes every new page of browser use the profile
+// The user agent is taken from the running Chrome (minus any "Headless"
+// marker) and saved whenever Chrome's version has moved on
+func (p *DeviceProfile) Apply(browser *rod.Browser) error {
+	version, err := proto.BrowserGetVersion{}.Call(browser)
+	if err != nil {
+		return fmt.Errorf("failed to read browser version: %w", err)
+	}
+
+	userAgent := strings.Replace(version.UserAgent, "HeadlessChrome", "Chrome", 1)
+	if userAgent != p.UserAgent {
+		if p.UserAgent != "" {
+			fmt.Printf("🔄 Chrome updated - device profile now reports %s\n", chromeVersion(userAgent))
+		}
+		p.UserAgent = userAgent
+		if err := p.save(); err != nil {
+			return err
+		}
+	}
+
+	browser.DefaultDevice(p.Device())
+	fmt.Printf("🖥️ Device profile: %dx%d @%gx, Chrome %s\n", p.Width, p.Height, p.DevicePixelRatio, chromeVersion(p.UserAgent))
+	return nil
+}
+
+// Device converts the profile for rod's page emulation
+func (p *DeviceProfile) Device() devices.Device {
+	return devices.Device{
+		Title:          "Account device",
+		Capabilities:   []string{},
+		UserAgent:      p.UserAgent,
+		AcceptLanguage: p.AcceptLanguage,
+		Screen: devices.Screen{
+			DevicePixelRatio: p.DevicePixelRatio,
+			Horizontal:       devices.ScreenSize{Width: p.Width, Height: p.Height},
+			Vertical:         devices.ScreenSize{Width: p.Height, Height: p.Width},
+		},
+	}.Landscape()
+}
+
+// save writes the profile back to DeviceProfileFile
+func (p *DeviceProfile) save() error {
+	profiles, err := loadDeviceProfiles()
+	if err != nil {
+		return err
+	}
+	profiles[p.Account] = p
+	return saveDeviceProfiles(profiles)
+}
+
+var chromeVersionPattern = regexp.MustCompile(`Chrome/([\d.]+)`)
+
+// chromeVersion pulls the Chrome version out of a user agent
+func chromeVersion(userAgent string) string {
+	if m := chromeVersionPattern.FindStringSubmatch(userAgent); m != nil {
+		return m[1]
+	}
+	return "unknown"
+}
+
+func deviceProfileKey(account string) string {
+	account = strings.ToLower(strings.TrimSpace(account))
+	if account == "" {
+		return "default"
+	}
+	return account
+}
+
+func loadDeviceProfiles() (map[string]*DeviceProfile, error) {
+	profiles := make(map[string]*DeviceProfile)
+
+	data, err := os.ReadFile(DeviceProfileFile)
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device profiles: %w", err)
+	}
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse device profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+func saveDeviceProfiles(profiles map[string]*DeviceProfile) error {
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(DeviceProfileFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to save device profiles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package stealth
+
+// Event categories written by the stealth package
+const (
+	EventDetection = "detection"
+	EventRateLimit = "rate_limit"
+	EventCooldown  = "cooldown"
+	EventAlert     = "alert"
+)
+
+// EventLogger records audit events (workflow milestones, detections, rate limits)
+type EventLogger interface {
+	LogEvent(category, message string, metadata map[string]interface{}) error
+}
+
+// Global event logger (nil = events are only printed)
+var eventLogger EventLogger
+
+// SetEventLogger sets where audit events are recorded
+func SetEventLogger(l EventLogger) {
+	eventLogger = l
+}
+
+// LogEvent records an audit event if a logger is set
+// Failures are ignored - the audit trail must never stop a run
+func LogEvent(category, message string, metadata map[string]interface{}) {
+	if eventLogger == nil {
+		return
+	}
+	eventLogger.LogEvent(category, message, metadata)
+}
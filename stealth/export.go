@@ -0,0 +1,129 @@
+package stealth
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+)
+
+// IntervalStats summarises the gaps between consecutive actions
+type IntervalStats struct {
+	Count  int // number of intervals (actions - 1)
+	Mean   time.Duration
+	StdDev time.Duration
+	Min    time.Duration
+	Max    time.Duration
+}
+
+// Regularity returns stddev/mean - values near zero mean the timing is suspiciously even
+func (s IntervalStats) Regularity() float64 {
+	if s.Mean <= 0 {
+		return 0
+	}
+	return float64(s.StdDev) / float64(s.Mean)
+}
+
+// ExportActions writes the in-memory action history to a CSV file (timestamp, type)
+func (rl *RateLimiter) ExportActions(path string) error {
+	rl.mu.RLock()
+	actions := make([]ActionRecord, len(rl.actions))
+	copy(actions, rl.actions)
+	rl.mu.RUnlock()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"timestamp", "type"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, a := range actions {
+		if err := w.Write([]string{a.Timestamp.Format(time.RFC3339), string(a.Type)}); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV: %w", err)
+	}
+	return nil
+}
+
+// IntervalStats computes inter-action interval statistics for one action type
+// Pass an empty action type to include every recorded action
+func (rl *RateLimiter) IntervalStats(action ActionType) IntervalStats {
+	rl.mu.RLock()
+	var times []time.Time
+	for _, a := range rl.actions {
+		if action == "" || a.Type == action {
+			times = append(times, a.Timestamp)
+		}
+	}
+	rl.mu.RUnlock()
+
+	return ComputeIntervalStats(times)
+}
+
+// ComputeIntervalStats computes mean, stddev, min and max of the gaps between timestamps
+func ComputeIntervalStats(times []time.Time) IntervalStats {
+	if len(times) < 2 {
+		return IntervalStats{}
+	}
+	sorted := make([]time.Time, len(times))
+	copy(sorted, times)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	gaps := make([]float64, 0, len(sorted)-1)
+	sum := 0.0
+	for i := 1; i < len(sorted); i++ {
+		gap := float64(sorted[i].Sub(sorted[i-1]))
+		gaps = append(gaps, gap)
+		sum += gap
+	}
+
+	mean := sum / float64(len(gaps))
+	minGap, maxGap := gaps[0], gaps[0]
+	variance := 0.0
+	for _, g := range gaps {
+		variance += (g - mean) * (g - mean)
+		minGap = math.Min(minGap, g)
+		maxGap = math.Max(maxGap, g)
+	}
+	variance /= float64(len(gaps))
+
+	return IntervalStats{
+		Count:  len(gaps),
+		Mean:   time.Duration(mean),
+		StdDev: time.Duration(math.Sqrt(variance)),
+		Min:    time.Duration(minGap),
+		Max:    time.Duration(maxGap),
+	}
+}
+
+// PrintIntervalStats prints interval statistics and flags timing that looks too regular
+func (rl *RateLimiter) PrintIntervalStats(action ActionType) {
+	stats := rl.IntervalStats(action)
+	label := string(action)
+	if label == "" {
+		label = "all actions"
+	}
+
+	fmt.Printf("\n⏱️ Action intervals for %s:\n", label)
+	if stats.Count == 0 {
+		fmt.Println("   Not enough actions recorded")
+		return
+	}
+	fmt.Printf("   Intervals: %d\n", stats.Count)
+	fmt.Printf("   Mean:      %v\n", stats.Mean.Round(time.Second))
+	fmt.Printf("   StdDev:    %v\n", stats.StdDev.Round(time.Second))
+	fmt.Printf("   Min/Max:   %v / %v\n", stats.Min.Round(time.Second), stats.Max.Round(time.Second))
+	if stats.Count >= 5 && stats.Regularity() < 0.15 {
+		fmt.Printf("   ⚠️ Timing looks very regular (stddev/mean %.2f) - consider widening delay ranges\n", stats.Regularity())
+	}
+}
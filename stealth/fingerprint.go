@@ -1,7 +1,12 @@
 package stealth
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
@@ -61,11 +66,74 @@ func CreateStealthBrowser(config *StealthConfig) (*rod.Browser, error) {
 // IMPORTANT: We only remove the webdriver flag. That's it.
 // Faking plugins, WebGL, etc. actually increases detection risk!
 func ApplyStealthScripts(page *rod.Page) {
-	page.MustEval(`() => {
-		// Only remove webdriver flag - nothing else!
+	page.MustEval(`() => {` + getStealthScript() + `}`)
+}
+
+// webdriverScript removes the webdriver flag - nothing else!
+const webdriverScript = `
 		Object.defineProperty(navigator, 'webdriver', {
 			get: () => undefined,
 			configurable: true
 		});
-	}`)
+`
+
+// The script every page gets: webdriverScript unless a file replaced it
+var (
+	stealthScriptMu     sync.RWMutex
+	stealthScript       = webdriverScript
+	stealthScriptSource = "built-in"
+)
+
+// LoadStealthScript replaces the built-in stealth script with the contents of
+// path, so the script can be updated without a new build. A missing file keeps
+// the built-in script; a file that is empty or not text is rejected.
+// A script the browser can't run is dropped for the built-in one by setupPage
+func LoadStealthScript(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read stealth script: %w", err)
+	}
+
+	script := strings.TrimSpace(string(data))
+	switch {
+	case script == "":
+		return fmt.Errorf("stealth script %s is empty", path)
+	case !utf8.ValidString(script) || bytes.IndexByte(data, 0) >= 0:
+		return fmt.Errorf("stealth script %s is not a text file", path)
+	case strings.HasPrefix(script, "<"):
+		return fmt.Errorf("stealth script %s looks like HTML, not JavaScript", path)
+	}
+
+	stealthScriptMu.Lock()
+	stealthScript = script
+	stealthScriptSource = path
+	stealthScriptMu.Unlock()
+
+	fmt.Printf("🥷 Using stealth script from %s (%d bytes)\n", path, len(script))
+	return nil
+}
+
+// getStealthScript returns the stealth script in use
+func getStealthScript() string {
+	stealthScriptMu.RLock()
+	defer stealthScriptMu.RUnlock()
+	return stealthScript
+}
+
+// stealthScriptOrigin returns where the stealth script in use came from
+func stealthScriptOrigin() string {
+	stealthScriptMu.RLock()
+	defer stealthScriptMu.RUnlock()
+	return stealthScriptSource
+}
+
+// resetStealthScript goes back to the built-in script
+func resetStealthScript() {
+	stealthScriptMu.Lock()
+	defer stealthScriptMu.Unlock()
+	stealthScript = webdriverScript
+	stealthScriptSource = "built-in"
 }
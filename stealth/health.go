@@ -0,0 +1,40 @@
+package stealth
+
+import "fmt"
+
+// AccountHealth is an account health score from 0 (at risk) to 100 (healthy)
+type AccountHealth int
+
+// HealthLowThreshold is the score below which risky safety levels are refused
+const HealthLowThreshold AccountHealth = 60
+
+// IsLow reports whether the account is unhealthy enough to slow down
+func (h AccountHealth) IsLow() bool {
+	return h < HealthLowThreshold
+}
+
+// SafeLevel returns the safety level to actually use for a requested level
+// Moderate and aggressive levels are downgraded to conservative on low health
+func (h AccountHealth) SafeLevel(requested SafetyLevel) SafetyLevel {
+	if !h.IsLow() {
+		return requested
+	}
+	if requested == SafetyModerate || requested == SafetyAggressive {
+		return SafetyConservative
+	}
+	return requested
+}
+
+// ApplyAccountHealth downgrades the active safety level if health is low
+// Returns true if the level was changed
+func ApplyAccountHealth(h AccountHealth) bool {
+	current := GetConfig().SafetyLevel
+	safe := h.SafeLevel(current)
+	if safe == current {
+		return false
+	}
+
+	fmt.Printf("🩺 Account health %d/100 is below %d - refusing %s mode\n", h, HealthLowThreshold, current)
+	SetSafetyLevel(safe)
+	return true
+}
@@ -0,0 +1,130 @@
+package stealth
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// IncidentThreshold cooldown-class detections within IncidentWindow
+	// trigger a one-step safety downgrade
+	IncidentThreshold = 3
+	IncidentWindow    = time.Hour
+
+	// incidentDebounce folds re-checks of the same page/error into one incident
+	incidentDebounce = time.Minute
+)
+
+// safetyOrder lists safety levels from most to least aggressive
+var safetyOrder = []SafetyLevel{
+	SafetyAggressive,
+	SafetyModerate,
+	SafetyConservative,
+	SafetyUltraConservative,
+}
+
+// MoreConservative returns the next safer level (ultra conservative stays put)
+func MoreConservative(level SafetyLevel) SafetyLevel {
+	for i, l := range safetyOrder {
+		if l == level && i+1 < len(safetyOrder) {
+			return safetyOrder[i+1]
+		}
+	}
+	return level
+}
+
+// IncidentTracker counts detection warnings and makes the bot more careful
+// when they pile up - detection feeds back into rate limiting
+type IncidentTracker struct {
+	mu        sync.Mutex
+	clock     Clock
+	incidents []time.Time
+	lastType  ErrorType
+	lastAt    time.Time
+}
+
+var (
+	incidentTracker     *IncidentTracker
+	incidentTrackerOnce sync.Once
+)
+
+// GetIncidentTracker returns the global incident tracker (singleton)
+func GetIncidentTracker() *IncidentTracker {
+	incidentTrackerOnce.Do(func() {
+		incidentTracker = &IncidentTracker{clock: SystemClock}
+	})
+	return incidentTracker
+}
+
+// SetClock replaces the tracker's time source (SystemClock by default)
+func (it *IncidentTracker) SetClock(clock Clock) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.clock = clock
+}
+
+// Record notes a detection result; only ActionCooldown errors count
+// Returns true when this incident caused a safety downgrade
+func (it *IncidentTracker) Record(err *LinkedInError) bool {
+	if err == nil || err.Action != ActionCooldown {
+		return false
+	}
+
+	it.mu.Lock()
+	now := it.clock.Now()
+	if err.Type == it.lastType && now.Sub(it.lastAt) < incidentDebounce {
+		it.mu.Unlock()
+		return false
+	}
+	it.lastType, it.lastAt = err.Type, now
+
+	it.incidents = append(it.incidents, now)
+	it.pruneUnlocked(now)
+	count := len(it.incidents)
+	if count < IncidentThreshold {
+		it.mu.Unlock()
+		return false
+	}
+	it.incidents = nil
+	it.mu.Unlock()
+
+	current := GetConfig().SafetyLevel
+	safer := MoreConservative(current)
+	if safer == current {
+		fmt.Printf("🚨 %d warnings within %v - already at %s\n", count, IncidentWindow, current)
+		return false
+	}
+
+	fmt.Printf("🚨 %d warnings within %v (latest: %s) - downgrading %s -> %s\n",
+		count, IncidentWindow, err.Type, current, safer)
+	SetSafetyLevel(safer)
+	GetRateLimiter().ReloadLimits()
+	LogEvent(EventCooldown, "safety level downgraded after repeated warnings", map[string]interface{}{
+		"from":     string(current),
+		"to":       string(safer),
+		"warnings": count,
+		"latest":   string(err.Type),
+	})
+	return true
+}
+
+// Count returns the number of incidents in the current window
+func (it *IncidentTracker) Count() int {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.pruneUnlocked(it.clock.Now())
+	return len(it.incidents)
+}
+
+// pruneUnlocked drops incidents older than IncidentWindow
+func (it *IncidentTracker) pruneUnlocked(now time.Time) {
+	cutoff := now.Add(-IncidentWindow)
+	kept := it.incidents[:0]
+	for _, t := range it.incidents {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	it.incidents = kept
+}
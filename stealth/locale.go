@@ -0,0 +1,115 @@
+package stealth
+
+import (
+	"strings"
+
+	"github.com/go-rod/rod"
+)
+
+// Locale holds the visible button/label texts for one LinkedIn UI language
+// All strings are lowercase; the page scripts compare against lowercased text
+type Locale struct {
+	Code     string   `json:"code"`
+	Connect  []string `json:"connect"`  // Connect button
+	Message  []string `json:"message"`  // Message button
+	Pending  []string `json:"pending"`  // Pending (request already sent)
+	AddNote  []string `json:"addNote"`  // "Add a note" in the invite modal
+	Send     []string `json:"send"`     // Send buttons in the invite modal and message box
+	Withdraw []string `json:"withdraw"` // Withdraw in the pending-invite confirmation
+	More     []string `json:"more"`     // "More" actions menu on the profile top card
+}
+
+// Locales maps a language code (the page's <html lang>) to its UI strings
+var Locales = map[string]*Locale{
+	"en": {
+		Code:     "en",
+		Connect:  []string{"connect"},
+		Message:  []string{"message"},
+		Pending:  []string{"pending"},
+		AddNote:  []string{"add a note"},
+		Send:     []string{"send", "send now", "send invitation", "send without a note"},
+		Withdraw: []string{"withdraw", "withdraw invitation"},
+		More:     []string{"more", "more actions"},
+	},
+	"de": {
+		Code:     "de",
+		Connect:  []string{"vernetzen"},
+		Message:  []string{"nachricht"},
+		Pending:  []string{"ausstehend"},
+		AddNote:  []string{"nachricht hinzufügen", "notiz hinzufügen"},
+		Send:     []string{"senden", "jetzt senden", "ohne nachricht senden", "einladung senden"},
+		Withdraw: []string{"zurückziehen", "einladung zurückziehen"},
+		More:     []string{"mehr", "weitere aktionen"},
+	},
+	"fr": {
+		Code:     "fr",
+		Connect:  []string{"se connecter", "relier"},
+		Message:  []string{"message"},
+		Pending:  []string{"en attente"},
+		AddNote:  []string{"ajouter une note"},
+		Send:     []string{"envoyer", "envoyer maintenant", "envoyer sans note", "envoyer l’invitation"},
+		Withdraw: []string{"retirer", "retirer l’invitation"},
+		More:     []string{"plus", "plus d’actions"},
+	},
+	"es": {
+		Code:     "es",
+		Connect:  []string{"conectar"},
+		Message:  []string{"mensaje"},
+		Pending:  []string{"pendiente"},
+		AddNote:  []string{"añadir una nota", "agregar una nota"},
+		Send:     []string{"enviar", "enviar ahora", "enviar sin nota", "enviar invitación"},
+		Withdraw: []string{"retirar", "retirar invitación"},
+		More:     []string{"más", "más acciones"},
+	},
+}
+
+// DefaultLocale is used when the page language is unknown
+const DefaultLocale = "en"
+
+// LocaleOverride forces a locale code instead of detecting it ("" = detect from page)
+var LocaleOverride = ""
+
+// GetLocale returns the locale for a language code such as "de" or "de-DE"
+// Falls back to English for unsupported languages
+func GetLocale(code string) *Locale {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i > 0 {
+		code = code[:i]
+	}
+	if loc, ok := Locales[code]; ok {
+		return loc
+	}
+	return Locales[DefaultLocale]
+}
+
+// DetectLocale reads the page's <html lang> attribute and returns the matching locale
+// English strings are always included as a fallback since LinkedIn mixes
+// untranslated labels into localized pages
+func DetectLocale(page *rod.Page) *Locale {
+	code := LocaleOverride
+	if code == "" {
+		if res, err := page.Eval(`() => document.documentElement.lang || ''`); err == nil {
+			code = res.Value.Str()
+		}
+	}
+
+	loc := GetLocale(code)
+	if loc.Code == DefaultLocale {
+		return loc
+	}
+	return loc.withFallback(Locales[DefaultLocale])
+}
+
+// withFallback merges another locale's strings after this locale's own
+func (l *Locale) withFallback(fb *Locale) *Locale {
+	return &Locale{
+		Code:     l.Code,
+		Connect:  append(append([]string{}, l.Connect...), fb.Connect...),
+		Message:  append(append([]string{}, l.Message...), fb.Message...),
+		Pending:  append(append([]string{}, l.Pending...), fb.Pending...),
+		AddNote:  append(append([]string{}, l.AddNote...), fb.AddNote...),
+		Send:     append(append([]string{}, l.Send...), fb.Send...),
+		Withdraw: append(append([]string{}, l.Withdraw...), fb.Withdraw...),
+		More:     append(append([]string{}, l.More...), fb.More...),
+	}
+}
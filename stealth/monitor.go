@@ -0,0 +1,47 @@
+package stealth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// MonitorInterval is how often MonitorPage polls the page for warnings
+var MonitorInterval = 10 * time.Second
+
+// MonitorWaits enables the background detection monitor during WatchedSleep
+var MonitorWaits = true
+
+// WatchedSleep sleeps for d while MonitorPage watches the page in the background.
+// Warnings can appear passively (e.g. a restriction banner pushed to the open tab),
+// so a critical one ends the wait early and is returned - the caller should pause
+// instead of waking up into a restricted account. Non-critical findings are logged.
+// Short waits (or MonitorWaits off) are a plain sleep.
+func WatchedSleep(page *rod.Page, d time.Duration) error {
+	if !MonitorWaits || page == nil || d < 2*MonitorInterval {
+		time.Sleep(d)
+		return nil
+	}
+
+	errorChan := make(chan *LinkedInError, 1)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	go MonitorPage(page, errorChan, stopChan)
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case err := <-errorChan:
+			if IsCritical(err) {
+				fmt.Printf("🛑 Warning appeared while waiting: %s\n", err.Message)
+				return err
+			}
+			fmt.Printf("⚠️ Noticed while waiting: %s (continuing)\n", err.Message)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package stealth
 
 import (
 	"math"
-	"math/rand"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -54,7 +53,12 @@ func MoveAndClick(page *rod.Page, el *rod.Element) error {
 }
 
 // MoveAndClickWithConfig moves and clicks with custom configuration
+// In read-only mode, clicks on send/connect/like controls are refused
 func MoveAndClickWithConfig(page *rod.Page, el *rod.Element, cfg *MouseConfig) error {
+	if IsReadOnly() && isWriteControl(el) {
+		return BlockWrite("click on a write control", "")
+	}
+
 	// Get element center position
 	box, err := el.Shape()
 	if err != nil {
@@ -74,8 +78,8 @@ func MoveAndClickWithConfig(page *rod.Page, el *rod.Element, cfg *MouseConfig) e
 	// Add small random offset within element (don't always click dead center)
 	width := math.Abs(quad[2] - quad[0])
 	height := math.Abs(quad[5] - quad[1])
-	targetX += (rand.Float64() - 0.5) * width * 0.3
-	targetY += (rand.Float64() - 0.5) * height * 0.3
+	targetX += (rng.Float64() - 0.5) * width * 0.3
+	targetY += (rng.Float64() - 0.5) * height * 0.3
 
 	// Get current mouse position
 	currentPos := page.Mouse.Position()
@@ -93,7 +97,7 @@ func MoveAndClickWithConfig(page *rod.Page, el *rod.Element, cfg *MouseConfig) e
 	}
 
 	// Small delay before click (human reaction time)
-	time.Sleep(time.Duration(30+rand.Intn(70)) * time.Millisecond)
+	time.Sleep(time.Duration(30+rng.Intn(70)) * time.Millisecond)
 
 	// Click
 	return page.Mouse.Click(proto.InputMouseButtonLeft, 1)
@@ -135,15 +139,15 @@ func moveMouseTo(page *rod.Page, from, to proto.Point, cfg *MouseConfig) error {
 
 		// Add micro-jitter (except on last step)
 		if cfg.JitterEnabled && i < steps {
-			pos.X += (rand.Float64() - 0.5) * cfg.JitterAmount
-			pos.Y += (rand.Float64() - 0.5) * cfg.JitterAmount
+			pos.X += (rng.Float64() - 0.5) * cfg.JitterAmount
+			pos.Y += (rng.Float64() - 0.5) * cfg.JitterAmount
 		}
 
 		// Move to this point
 		page.Mouse.MustMoveTo(pos.X, pos.Y)
 
 		// Variable delay between steps
-		jitteredDelay := stepDelay + time.Duration(rand.Intn(10)-5)*time.Millisecond
+		jitteredDelay := stepDelay + time.Duration(rng.Intn(10)-5)*time.Millisecond
 		if jitteredDelay < time.Millisecond {
 			jitteredDelay = time.Millisecond
 		}
@@ -151,7 +155,7 @@ func moveMouseTo(page *rod.Page, from, to proto.Point, cfg *MouseConfig) error {
 	}
 
 	// Overshoot and correct (occasional)
-	if rand.Float64() < cfg.OvershootChance {
+	if rng.Float64() < cfg.OvershootChance {
 		overshootAndCorrect(page, to, distance, cfg)
 	}
 
@@ -169,14 +173,14 @@ func generateControlPoints(from, to proto.Point, variance float64) (proto.Point,
 	perpY := dx / distance
 
 	// Control point 1: ~1/3 along the path with perpendicular offset
-	offset1 := (rand.Float64() - 0.5) * 2 * variance * distance
+	offset1 := (rng.Float64() - 0.5) * 2 * variance * distance
 	ctrl1 := proto.Point{
 		X: from.X + dx*0.3 + perpX*offset1,
 		Y: from.Y + dy*0.3 + perpY*offset1,
 	}
 
 	// Control point 2: ~2/3 along the path with perpendicular offset
-	offset2 := (rand.Float64() - 0.5) * 2 * variance * distance
+	offset2 := (rng.Float64() - 0.5) * 2 * variance * distance
 	ctrl2 := proto.Point{
 		X: from.X + dx*0.7 + perpX*offset2,
 		Y: from.Y + dy*0.7 + perpY*offset2,
@@ -211,10 +215,10 @@ func easeInOutQuad(t float64) float64 {
 // overshootAndCorrect simulates overshooting the target and correcting
 func overshootAndCorrect(page *rod.Page, target proto.Point, distance float64, cfg *MouseConfig) {
 	// Calculate overshoot amount
-	overshootDist := distance * cfg.OvershootDistance * (0.5 + rand.Float64()*0.5)
+	overshootDist := distance * cfg.OvershootDistance * (0.5 + rng.Float64()*0.5)
 
 	// Random direction for overshoot
-	angle := rand.Float64() * 2 * math.Pi
+	angle := rng.Float64() * 2 * math.Pi
 	overshootPos := proto.Point{
 		X: target.X + math.Cos(angle)*overshootDist,
 		Y: target.Y + math.Sin(angle)*overshootDist,
@@ -222,16 +226,16 @@ func overshootAndCorrect(page *rod.Page, target proto.Point, distance float64, c
 
 	// Move to overshoot position (quick)
 	page.Mouse.MustMoveTo(overshootPos.X, overshootPos.Y)
-	time.Sleep(time.Duration(15+rand.Intn(25)) * time.Millisecond)
+	time.Sleep(time.Duration(15+rng.Intn(25)) * time.Millisecond)
 
 	// Correct back to target (2-3 quick steps)
-	correctionSteps := 2 + rand.Intn(2)
+	correctionSteps := 2 + rng.Intn(2)
 	for i := 1; i <= correctionSteps; i++ {
 		t := float64(i) / float64(correctionSteps)
 		x := overshootPos.X + (target.X-overshootPos.X)*t
 		y := overshootPos.Y + (target.Y-overshootPos.Y)*t
 		page.Mouse.MustMoveTo(x, y)
-		time.Sleep(time.Duration(10+rand.Intn(15)) * time.Millisecond)
+		time.Sleep(time.Duration(10+rng.Intn(15)) * time.Millisecond)
 	}
 }
 
@@ -246,8 +250,8 @@ func getRandomViewportPos(page *rod.Page) proto.Point {
 	height := result.Get("height").Num()
 
 	return proto.Point{
-		X: width * (0.3 + rand.Float64()*0.4),  // 30-70% of width
-		Y: height * (0.3 + rand.Float64()*0.4), // 30-70% of height
+		X: width * (0.3 + rng.Float64()*0.4),  // 30-70% of width
+		Y: height * (0.3 + rng.Float64()*0.4), // 30-70% of height
 	}
 }
 
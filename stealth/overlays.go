@@ -0,0 +1,86 @@
+package stealth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-rod/rod"
+)
+
+// DismissOverlays closes overlays that sit on top of the page without being
+// part of any action - cookie banners, "Got it" tips, Premium upsells and
+// open messaging bubbles - so they can't swallow the next click.
+// Dialogs about the account itself (restrictions, verification, warnings) are
+// left alone for CheckPage to report. Returns true if anything was closed
+func DismissOverlays(page *rod.Page) (dismissed bool) {
+	page = page.Timeout(GetEvalTimeout())
+	defer page.CancelTimeout()
+
+	res, err := page.Eval(`() => {
+		const closed = [];
+		const visible = (el) => !!(el.offsetWidth || el.offsetHeight || el.getClientRects().length);
+		const clickLabel = (container, labels) => {
+			for (const btn of container.querySelectorAll('button, a[role="button"]')) {
+				if (!visible(btn)) continue;
+				const text = (btn.innerText || '').trim().toLowerCase();
+				const label = (btn.getAttribute('aria-label') || '').trim().toLowerCase();
+				if (labels.some(l => text === l || label.startsWith(l))) {
+					btn.click();
+					return true;
+				}
+			}
+			return false;
+		};
+		const accountText = /restrict|verif|security check|automated|unusual activity|temporarily limited/i;
+
+		// Cookie consent banner - decline where offered
+		for (const banner of document.querySelectorAll('[class*="global-alert"], [data-test-global-alert], [class*="cookie"]')) {
+			if (!visible(banner) || !/cookie/i.test(banner.innerText || '')) continue;
+			if (clickLabel(banner, ['reject', 'decline', 'accept', 'dismiss'])) closed.push('cookie banner');
+		}
+
+		// Tips, upsells and toasts
+		const containers = document.querySelectorAll(
+			'div[role="dialog"], div[role="alertdialog"], .artdeco-modal, .artdeco-toast-item, .artdeco-hoverable-content, [class*="premium-upsell"]'
+		);
+		for (const c of containers) {
+			if (!visible(c)) continue;
+			const text = c.innerText || '';
+			if (accountText.test(text)) continue;
+
+			if (/premium|free trial|try for free|upgrade/i.test(text)) {
+				if (clickLabel(c, ['dismiss', 'no thanks', 'not now', 'close', 'maybe later'])) closed.push('premium upsell');
+			} else if (clickLabel(c, ['got it', 'dismiss tip'])) {
+				closed.push('tip');
+			}
+		}
+
+		// Open messaging bubbles can cover profile buttons and take typed text
+		for (const bubble of document.querySelectorAll('.msg-overlay-conversation-bubble')) {
+			const btn = bubble.querySelector(
+				'button[aria-label^="Close your"], button[data-control-name="overlay.close_conversation_window"]'
+			);
+			if (btn && visible(btn)) {
+				btn.click();
+				closed.push('messaging overlay');
+			}
+		}
+
+		return closed;
+	}`)
+	if err != nil {
+		return false
+	}
+
+	var closed []string
+	for _, kind := range res.Value.Arr() {
+		closed = append(closed, kind.Str())
+	}
+	if len(closed) == 0 {
+		return false
+	}
+
+	fmt.Printf("🧹 Dismissed %s\n", strings.Join(closed, ", "))
+	SleepMillis(400, 800)
+	return true
+}
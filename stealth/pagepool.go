@@ -0,0 +1,143 @@
+package stealth
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// PagePool hands out reusable pages with stealth already applied
+//
+// WHY POOL PAGES:
+// - Opening and closing a tab per workflow is slow and not how people browse
+// - Every pooled page gets the stealth script, so no workflow can forget it
+// - The script is registered for new documents, so it survives navigation
+type PagePool struct {
+	browser  *rod.Browser
+	maxPages int
+
+	mu      sync.Mutex
+	idle    []*rod.Page
+	owned   []*rod.Page // Pages created by the pool (closed on Close)
+	adopted []*rod.Page // Pages handed in with Adopt (left open on Close)
+	total   int
+}
+
+// NewPagePool creates a pool that opens at most maxPages tabs (0 = unlimited)
+func NewPagePool(browser *rod.Browser, maxPages int) *PagePool {
+	return &PagePool{
+		browser:  browser,
+		maxPages: maxPages,
+	}
+}
+
+// Adopt adds an existing page (e.g. the feed tab after login) to the pool
+// Adopted pages are left open when the pool closes
+func (pp *PagePool) Adopt(page *rod.Page) error {
+	if err := setupPage(page); err != nil {
+		return err
+	}
+
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	pp.idle = append(pp.idle, page)
+	pp.adopted = append(pp.adopted, page)
+	pp.total++
+	return nil
+}
+
+// Get borrows a page, reusing an idle one when available
+func (pp *PagePool) Get() (*rod.Page, error) {
+	pp.mu.Lock()
+	if n := len(pp.idle); n > 0 {
+		page := pp.idle[n-1]
+		pp.idle = pp.idle[:n-1]
+		pp.mu.Unlock()
+		return page, nil
+	}
+	if pp.maxPages > 0 && pp.total >= pp.maxPages {
+		pp.mu.Unlock()
+		return nil, fmt.Errorf("page pool exhausted (%d pages in use)", pp.total)
+	}
+	pp.total++
+	pp.mu.Unlock()
+
+	page, err := pp.browser.Page(proto.TargetCreateTarget{URL: "about:blank"})
+	if err == nil {
+		err = setupPage(page)
+	}
+	if err != nil {
+		pp.mu.Lock()
+		pp.total--
+		pp.mu.Unlock()
+		return nil, fmt.Errorf("failed to create pooled page: %w", err)
+	}
+
+	pp.mu.Lock()
+	pp.owned = append(pp.owned, page)
+	pp.mu.Unlock()
+	return page, nil
+}
+
+// Put returns a borrowed page to the pool
+func (pp *PagePool) Put(page *rod.Page) {
+	if page == nil {
+		return
+	}
+
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	pp.idle = append(pp.idle, page)
+}
+
+// Close closes every page the pool opened itself
+func (pp *PagePool) Close() {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	for _, page := range pp.owned {
+		UnwatchNetwork(page)
+		page.Close()
+	}
+	for _, page := range pp.adopted {
+		UnwatchNetwork(page)
+	}
+	pp.owned = nil
+	pp.adopted = nil
+	pp.idle = nil
+	pp.total = 0
+}
+
+// setupPage applies stealth to the current document and all future navigations,
+// and starts watching the page's responses for throttling
+func setupPage(page *rod.Page) error {
+	script := getStealthScript()
+	remove, err := page.EvalOnNewDocument(script)
+	if err != nil {
+		return fmt.Errorf("failed to register stealth script: %w", err)
+	}
+
+	if _, err := page.Eval(`() => {` + script + `}`); err != nil {
+		origin := stealthScriptOrigin()
+		if origin == "built-in" {
+			return fmt.Errorf("failed to apply stealth script: %w", err)
+		}
+
+		// A loaded script that doesn't run (syntax error, throws) is replaced by the built-in one
+		fmt.Printf("⚠️ Stealth script from %s failed to run: %v - using the built-in script\n", origin, err)
+		resetStealthScript()
+		if err := remove(); err != nil {
+			return fmt.Errorf("failed to unregister stealth script: %w", err)
+		}
+		if _, err := page.EvalOnNewDocument(webdriverScript); err != nil {
+			return fmt.Errorf("failed to register stealth script: %w", err)
+		}
+		if _, err := page.Eval(`() => {` + webdriverScript + `}`); err != nil {
+			return fmt.Errorf("failed to apply stealth script: %w", err)
+		}
+	}
+	WatchNetwork(page)
+	return nil
+}
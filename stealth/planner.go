@@ -0,0 +1,209 @@
+package stealth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SessionStep is a single planned unit of work within a session
+type SessionStep string
+
+const (
+	StepConnect SessionStep = "connect" // Send one connection request
+	StepMessage SessionStep = "message" // Send one follow-up message
+	StepBrowse  SessionStep = "browse"  // Organic browsing (feed/profile), no writes
+)
+
+// PlannerConfig controls how actions are interleaved within a session
+type PlannerConfig struct {
+	// Relative weights used when picking the next step
+	ConnectWeight float64
+	MessageWeight float64
+	BrowseWeight  float64
+
+	// Never plan more than this many consecutive steps of the same write type
+	MaxConsecutive int
+
+	// Hard cap on the number of steps in a single plan (0 = no cap)
+	MaxSteps int
+}
+
+// DefaultPlannerConfig returns weights that favour connects, sprinkle in
+// messages and keep plenty of browsing between write actions
+func DefaultPlannerConfig() *PlannerConfig {
+	return &PlannerConfig{
+		ConnectWeight:  0.45,
+		MessageWeight:  0.2,
+		BrowseWeight:   0.35,
+		MaxConsecutive: 2,
+		MaxSteps:       40,
+	}
+}
+
+// Global planner config
+var PlannerCfg = DefaultPlannerConfig()
+
+// SessionPlanner builds an interleaved action sequence for a session
+//
+// WHY INTERLEAVE:
+// - Running all searches, then all connects, then all messages is a fixed daily signature
+// - Humans mix tasks: a few connects, some reading, a message, more reading
+// - Budgets come from the rate limiter so the plan never exceeds what can be sent
+type SessionPlanner struct {
+	config    *PlannerConfig
+	limiter   *RateLimiter
+	scheduler *Scheduler
+}
+
+// NewSessionPlanner creates a planner backed by a rate limiter and an optional scheduler
+func NewSessionPlanner(limiter *RateLimiter, scheduler *Scheduler) *SessionPlanner {
+	return NewSessionPlannerWithConfig(limiter, scheduler, PlannerCfg)
+}
+
+// NewSessionPlannerWithConfig creates a planner with custom weights
+func NewSessionPlannerWithConfig(limiter *RateLimiter, scheduler *Scheduler, cfg *PlannerConfig) *SessionPlanner {
+	if limiter == nil {
+		limiter = GetRateLimiter()
+	}
+	return &SessionPlanner{
+		config:    cfg,
+		limiter:   limiter,
+		scheduler: scheduler,
+	}
+}
+
+// CanRun reports whether the scheduler window currently allows activity
+// Without a scheduler the planner is always allowed to run
+func (sp *SessionPlanner) CanRun() bool {
+	if sp.scheduler == nil {
+		return true
+	}
+	return sp.scheduler.CanOperate("")
+}
+
+// CanRunStep reports whether the step's action window is open right now
+// Browsing has no window; without a scheduler every step may run
+func (sp *SessionPlanner) CanRunStep(step SessionStep) bool {
+	if sp.scheduler == nil {
+		return true
+	}
+	switch step {
+	case StepConnect:
+		return sp.scheduler.CanOperate(ActionConnection)
+	case StepMessage:
+		return sp.scheduler.CanOperate(ActionMessage)
+	}
+	return sp.scheduler.CanOperate("")
+}
+
+// NoCap passed to Budget/Plan leaves that action capped by the rate limiter only
+const NoCap = -1
+
+// Budget returns how many connects and messages remain for today
+// maxConnects/maxMessages additionally cap the budget (NoCap = limiter only,
+// 0 = none, e.g. when there are no targets)
+func (sp *SessionPlanner) Budget(maxConnects, maxMessages int) (int, int) {
+	connects := sp.limiter.GetStats(ActionConnection).DailyRemaining
+	messages := sp.limiter.GetStats(ActionMessage).DailyRemaining
+
+	if maxConnects != NoCap && connects > maxConnects {
+		connects = maxConnects
+	}
+	if maxMessages != NoCap && messages > maxMessages {
+		messages = maxMessages
+	}
+	if connects < 0 {
+		connects = 0
+	}
+	if messages < 0 {
+		messages = 0
+	}
+	return connects, messages
+}
+
+// Plan builds a weighted, interleaved sequence of steps for this session
+// The plan always ends once the connect and message budgets are spent
+func (sp *SessionPlanner) Plan(maxConnects, maxMessages int) []SessionStep {
+	connects, messages := sp.Budget(maxConnects, maxMessages)
+
+	var plan []SessionStep
+	var last SessionStep
+	streak := 0
+
+	for connects > 0 || messages > 0 {
+		if sp.config.MaxSteps > 0 && len(plan) >= sp.config.MaxSteps {
+			break
+		}
+
+		weights := map[SessionStep]float64{}
+		if connects > 0 && !(last == StepConnect && streak >= sp.config.MaxConsecutive) {
+			weights[StepConnect] = sp.config.ConnectWeight
+		}
+		if messages > 0 && !(last == StepMessage && streak >= sp.config.MaxConsecutive) {
+			weights[StepMessage] = sp.config.MessageWeight
+		}
+		// Never browse twice in a row - it only pads the session
+		if last != StepBrowse || len(weights) == 0 {
+			weights[StepBrowse] = sp.config.BrowseWeight
+		}
+
+		step := pickWeighted(weights)
+
+		switch step {
+		case StepConnect:
+			connects--
+		case StepMessage:
+			messages--
+		}
+
+		if step == last {
+			streak++
+		} else {
+			streak = 1
+		}
+		last = step
+		plan = append(plan, step)
+	}
+
+	return plan
+}
+
+// pickWeighted selects a step at random proportionally to its weight
+func pickWeighted(weights map[SessionStep]float64) SessionStep {
+	// Iterate in a fixed order so the choice depends only on the random draw
+	order := []SessionStep{StepConnect, StepMessage, StepBrowse}
+
+	total := 0.0
+	for _, step := range order {
+		total += weights[step]
+	}
+	if total <= 0 {
+		return StepBrowse
+	}
+
+	r := rng.Float64() * total
+	for _, step := range order {
+		w, ok := weights[step]
+		if !ok {
+			continue
+		}
+		if r < w {
+			return step
+		}
+		r -= w
+	}
+	return StepBrowse
+}
+
+// DescribePlan returns a compact human-readable summary of a plan
+func DescribePlan(plan []SessionStep) string {
+	counts := map[SessionStep]int{}
+	parts := make([]string, 0, len(plan))
+	for _, step := range plan {
+		counts[step]++
+		parts = append(parts, string(step))
+	}
+	return fmt.Sprintf("%d steps (%d connect, %d message, %d browse): %s",
+		len(plan), counts[StepConnect], counts[StepMessage], counts[StepBrowse],
+		strings.Join(parts, " → "))
+}
@@ -0,0 +1,48 @@
+package stealth
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestPlanBudget(t *testing.T) {
+	limits := map[ActionType]*RateLimitConfig{
+		ActionConnection: {DailyLimit: 5},
+		ActionMessage:    {DailyLimit: 3},
+	}
+	rl := NewRateLimiterWithConfig(limits, filepath.Join(t.TempDir(), "rate_limiter_state.json"))
+	sp := NewSessionPlannerWithConfig(rl, nil, &PlannerConfig{
+		ConnectWeight:  1,
+		MessageWeight:  1,
+		BrowseWeight:   1,
+		MaxConsecutive: 2,
+	})
+
+	tests := []struct {
+		maxConnects, maxMessages int
+		wantConnects, wantMsgs   int
+	}{
+		{0, 0, 0, 0},
+		{NoCap, 0, 5, 0},
+		{0, NoCap, 0, 3},
+		{NoCap, NoCap, 5, 3},
+		{2, 10, 2, 3},
+	}
+
+	for _, tt := range tests {
+		connects, messages := sp.Budget(tt.maxConnects, tt.maxMessages)
+		if connects != tt.wantConnects || messages != tt.wantMsgs {
+			t.Errorf("Budget(%d, %d) = %d, %d; want %d, %d",
+				tt.maxConnects, tt.maxMessages, connects, messages, tt.wantConnects, tt.wantMsgs)
+		}
+
+		counts := map[SessionStep]int{}
+		for _, step := range sp.Plan(tt.maxConnects, tt.maxMessages) {
+			counts[step]++
+		}
+		if counts[StepConnect] != tt.wantConnects || counts[StepMessage] != tt.wantMsgs {
+			t.Errorf("Plan(%d, %d) has %d connects, %d messages; want %d, %d",
+				tt.maxConnects, tt.maxMessages, counts[StepConnect], counts[StepMessage], tt.wantConnects, tt.wantMsgs)
+		}
+	}
+}
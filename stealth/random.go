@@ -0,0 +1,44 @@
+package stealth
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// lockedSource is a rand.Source that is safe for concurrent use
+// (rand.New sources are not, unlike the math/rand global)
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// Shared random source for all delay/scroll/mouse/typing jitter
+// Time-seeded by default; SetSeed makes a run reproducible
+var (
+	rngSource = &lockedSource{src: rand.NewSource(time.Now().UnixNano())}
+	rng       = rand.New(rngSource)
+)
+
+// SetSeed reseeds the shared random source so randomized behavior
+// (delays, scrolling, mouse paths, typing, planning) can be replayed
+func SetSeed(seed int64) {
+	rngSource.Seed(seed)
+}
+
+// Rand returns the shared random source for packages built on stealth
+func Rand() *rand.Rand {
+	return rng
+}
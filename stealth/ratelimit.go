@@ -1,13 +1,16 @@
 package stealth
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"math/rand"
 	"os"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/go-rod/rod"
 )
 
 // =============================================================================
@@ -56,6 +59,26 @@ type GlobalConfig struct {
 	BreakAfterActions  int `json:"break_after_actions"`      // Take break after N actions
 	BreakDurationMin   int `json:"break_duration_min_sec"`   // Min break length (seconds)
 	BreakDurationMax   int `json:"break_duration_max_sec"`   // Max break length (seconds)
+
+	// Page operation timeouts (not tied to the safety level)
+	Timeouts Timeouts `json:"timeouts"`
+}
+
+// Timeouts holds how long page operations may take before giving up (seconds)
+// Raise them on slow connections; lower them to fail faster on quick ones
+type Timeouts struct {
+	Navigation int `json:"navigation_sec"` // Opening a page and waiting for it to render
+	Stability  int `json:"stability_sec"`  // Waiting for background requests to settle
+	Eval       int `json:"eval_sec"`       // Multi-step UI flows (invite modal, message box, pagination)
+	Detection  int `json:"detection_sec"`  // Scanning a page for warnings
+}
+
+// DefaultTimeouts is used for any timeout missing from the config file
+var DefaultTimeouts = Timeouts{
+	Navigation: 20,
+	Stability:  10,
+	Eval:       15,
+	Detection:  5,
 }
 
 // Pre-defined safety configurations
@@ -163,6 +186,7 @@ func GetConfig() *GlobalConfig {
 			// Default to conservative
 			globalConfig = safetyConfigs[SafetyConservative].clone()
 		}
+		setBrowsingConfig(BrowsingConfigFor(globalConfig.SafetyLevel))
 		fmt.Printf("⚙️ Rate limiter initialized: %s mode\n", globalConfig.SafetyLevel)
 	})
 	return globalConfig
@@ -174,7 +198,11 @@ func SetSafetyLevel(level SafetyLevel) {
 	defer globalConfigMu.Unlock()
 
 	if cfg, exists := safetyConfigs[level]; exists {
+		// Timeouts are about the network, not risk - keep the user's values
+		timeouts := GetConfig().Timeouts
 		globalConfig = cfg.clone()
+		globalConfig.Timeouts = timeouts
+		setBrowsingConfig(BrowsingConfigFor(level))
 		saveConfigToFile(globalConfig)
 		fmt.Printf("⚙️ Safety level changed to: %s\n", level)
 	}
@@ -202,6 +230,7 @@ func (c *GlobalConfig) clone() *GlobalConfig {
 		BreakAfterActions:     c.BreakAfterActions,
 		BreakDurationMin:      c.BreakDurationMin,
 		BreakDurationMax:      c.BreakDurationMax,
+		Timeouts:              c.Timeouts.withDefaults(),
 	}
 }
 
@@ -234,6 +263,45 @@ func GetBreakAfterActions() int { return GetConfig().BreakAfterActions }
 func GetBreakDurationMin() int  { return GetConfig().BreakDurationMin }
 func GetBreakDurationMax() int  { return GetConfig().BreakDurationMax }
 
+// Timeout getters
+func GetNavigationTimeout() time.Duration {
+	return timeoutSeconds(GetConfig().Timeouts.Navigation, DefaultTimeouts.Navigation)
+}
+func GetStabilityTimeout() time.Duration {
+	return timeoutSeconds(GetConfig().Timeouts.Stability, DefaultTimeouts.Stability)
+}
+func GetEvalTimeout() time.Duration {
+	return timeoutSeconds(GetConfig().Timeouts.Eval, DefaultTimeouts.Eval)
+}
+func GetDetectionTimeout() time.Duration {
+	return timeoutSeconds(GetConfig().Timeouts.Detection, DefaultTimeouts.Detection)
+}
+
+// timeoutSeconds converts a configured timeout, falling back to def when unset
+func timeoutSeconds(sec, def int) time.Duration {
+	if sec <= 0 {
+		sec = def
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// withDefaults fills unset (zero) timeouts from DefaultTimeouts
+func (t Timeouts) withDefaults() Timeouts {
+	if t.Navigation <= 0 {
+		t.Navigation = DefaultTimeouts.Navigation
+	}
+	if t.Stability <= 0 {
+		t.Stability = DefaultTimeouts.Stability
+	}
+	if t.Eval <= 0 {
+		t.Eval = DefaultTimeouts.Eval
+	}
+	if t.Detection <= 0 {
+		t.Detection = DefaultTimeouts.Detection
+	}
+	return t
+}
+
 // GetRandomDelay returns a random delay for the given action type
 func GetRandomDelay(action ActionType) time.Duration {
 	cfg := GetConfig()
@@ -253,7 +321,7 @@ func GetRandomDelay(action ActionType) time.Duration {
 	if min >= max {
 		return time.Duration(min) * time.Second
 	}
-	delay := min + rand.Intn(max-min+1)
+	delay := min + rng.Intn(max-min+1)
 	return time.Duration(delay) * time.Second
 }
 
@@ -264,7 +332,7 @@ func GetRandomBreakDuration() time.Duration {
 	if min >= max {
 		return time.Duration(min) * time.Second
 	}
-	duration := min + rand.Intn(max-min+1)
+	duration := min + rng.Intn(max-min+1)
 	return time.Duration(duration) * time.Second
 }
 
@@ -287,19 +355,111 @@ func PrintConfig() {
 		cfg.BurstLimit, cfg.BurstCooldown)
 	fmt.Printf("Breaks: every %d actions (%d-%ds)\n",
 		cfg.BreakAfterActions, cfg.BreakDurationMin, cfg.BreakDurationMax)
+	browse := GetBrowsingConfig()
+	fmt.Printf("Browsing: %d-%ds per profile, %d feed scrolls\n",
+		browse.ProfileViewMin, browse.ProfileViewMax, browse.FeedScrolls)
+	fmt.Printf("Timeouts: navigation %v, stability %v, eval %v, detection %v\n",
+		GetNavigationTimeout(), GetStabilityTimeout(), GetEvalTimeout(), GetDetectionTimeout())
 	fmt.Println(strings.Repeat("=", 50))
 }
 
+// Validate checks the config for values that can't be what the user meant
+// All problems are reported together so one edit can fix them
+func (c *GlobalConfig) Validate() error {
+	var problems []error
+
+	if _, ok := safetyConfigs[c.SafetyLevel]; !ok {
+		problems = append(problems, fmt.Errorf("unknown safety_level %q", c.SafetyLevel))
+	}
+
+	nonNegative := []struct {
+		name  string
+		value int
+	}{
+		{"connection_daily_limit", c.ConnectionDailyLimit},
+		{"connection_hourly_limit", c.ConnectionHourlyLimit},
+		{"connection_delay_min_sec", c.ConnectionDelayMin},
+		{"message_daily_limit", c.MessageDailyLimit},
+		{"message_hourly_limit", c.MessageHourlyLimit},
+		{"message_delay_min_sec", c.MessageDelayMin},
+		{"search_daily_limit", c.SearchDailyLimit},
+		{"search_hourly_limit", c.SearchHourlyLimit},
+		{"search_delay_min_sec", c.SearchDelayMin},
+		{"burst_limit", c.BurstLimit},
+		{"burst_cooldown_sec", c.BurstCooldown},
+		{"max_session_duration_min", c.MaxSessionDuration},
+		{"break_after_actions", c.BreakAfterActions},
+		{"break_duration_min_sec", c.BreakDurationMin},
+		{"timeouts.navigation_sec", c.Timeouts.Navigation},
+		{"timeouts.stability_sec", c.Timeouts.Stability},
+		{"timeouts.eval_sec", c.Timeouts.Eval},
+		{"timeouts.detection_sec", c.Timeouts.Detection},
+	}
+	for _, f := range nonNegative {
+		if f.value < 0 {
+			problems = append(problems, fmt.Errorf("%s must not be negative (got %d)", f.name, f.value))
+		}
+	}
+
+	ranges := []struct {
+		name     string
+		min, max int
+	}{
+		{"connection_delay", c.ConnectionDelayMin, c.ConnectionDelayMax},
+		{"message_delay", c.MessageDelayMin, c.MessageDelayMax},
+		{"search_delay", c.SearchDelayMin, c.SearchDelayMax},
+		{"break_duration", c.BreakDurationMin, c.BreakDurationMax},
+	}
+	for _, r := range ranges {
+		if r.min > r.max {
+			problems = append(problems, fmt.Errorf("%s min (%d) is greater than max (%d)", r.name, r.min, r.max))
+		}
+	}
+
+	hourly := []struct {
+		name          string
+		hourly, daily int
+	}{
+		{"connection", c.ConnectionHourlyLimit, c.ConnectionDailyLimit},
+		{"message", c.MessageHourlyLimit, c.MessageDailyLimit},
+		{"search", c.SearchHourlyLimit, c.SearchDailyLimit},
+	}
+	for _, h := range hourly {
+		if h.hourly > h.daily {
+			problems = append(problems, fmt.Errorf("%s_hourly_limit (%d) is greater than %s_daily_limit (%d)", h.name, h.hourly, h.name, h.daily))
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
 // Config persistence
+// A missing file is normal (first run); a broken one is reported instead of silently ignored
 func loadConfigFromFile() *GlobalConfig {
 	data, err := os.ReadFile(configFile)
 	if err != nil {
 		return nil
 	}
+
 	var cfg GlobalConfig
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		fmt.Printf("❌ %s could not be read and was ignored: %v\n", configFile, err)
+		fmt.Println("   Falling back to conservative defaults - fix the file to apply your settings")
 		return nil
 	}
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Printf("❌ %s has invalid settings and was ignored:\n", configFile)
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Printf("   - %s\n", line)
+		}
+		fmt.Println("   Falling back to conservative defaults - fix the file to apply your settings")
+		return nil
+	}
+
+	cfg.Timeouts = cfg.Timeouts.withDefaults()
 	return &cfg
 }
 
@@ -322,6 +482,7 @@ const (
 	ActionConnection ActionType = "connection"
 	ActionMessage    ActionType = "message"
 	ActionSearch     ActionType = "search"
+	ActionWithdraw   ActionType = "withdraw"
 )
 
 // RateLimitConfig defines limits for a specific action type
@@ -367,6 +528,18 @@ func DefaultLimits() map[ActionType]*RateLimitConfig {
 			BurstLimit:         cfg.BurstLimit,
 			BurstCooldown:      cfg.BurstCooldown,
 		},
+		// Withdrawals are invitation-manager clicks: capped like connections,
+		// spaced like searches
+		ActionWithdraw: {
+			DailyLimit:         cfg.ConnectionDailyLimit,
+			HourlyLimit:        cfg.ConnectionHourlyLimit,
+			MinIntervalSeconds: cfg.SearchDelayMin,
+			MaxIntervalSeconds: cfg.SearchDelayMax,
+			CooldownThreshold:  cfg.ConnectionDailyLimit,
+			CooldownDuration:   cfg.BurstCooldown / 60,
+			BurstLimit:         cfg.BurstLimit,
+			BurstCooldown:      cfg.BurstCooldown,
+		},
 		ActionSearch: {
 			DailyLimit:         cfg.SearchDailyLimit,
 			HourlyLimit:        cfg.SearchHourlyLimit,
@@ -401,8 +574,16 @@ type RateLimiter struct {
 	inCooldown  map[ActionType]bool      // Currently in cooldown
 	cooldownEnd map[ActionType]time.Time // When cooldown ends
 
+	// Token bucket mode (see SetMode)
+	mode          LimiterMode
+	bucketSize    int
+	tokens        map[ActionType]float64   // Bucket level at tokensUpdated
+	tokensUpdated map[ActionType]time.Time // When tokens was last settled
+
 	// Persistence
 	stateFile string
+
+	clock Clock
 }
 
 // RateLimiterState for JSON persistence
@@ -412,6 +593,8 @@ type RateLimiterState struct {
 	BurstCount  map[string]int       `json:"burst_count"`
 	BurstStart  map[string]time.Time `json:"burst_start"`
 	CooldownEnd map[string]time.Time `json:"cooldown_end"`
+	Tokens      map[string]float64   `json:"tokens,omitempty"`
+	TokensAt    map[string]time.Time `json:"tokens_at,omitempty"`
 	SavedAt     time.Time            `json:"saved_at"`
 }
 
@@ -423,14 +606,19 @@ func NewRateLimiter() *RateLimiter {
 // NewRateLimiterWithConfig creates a rate limiter with custom configuration
 func NewRateLimiterWithConfig(limits map[ActionType]*RateLimitConfig, stateFile string) *RateLimiter {
 	rl := &RateLimiter{
-		limits:      limits,
-		actions:     make([]ActionRecord, 0),
-		lastAction:  make(map[ActionType]time.Time),
-		burstCount:  make(map[ActionType]int),
-		burstStart:  make(map[ActionType]time.Time),
-		inCooldown:  make(map[ActionType]bool),
-		cooldownEnd: make(map[ActionType]time.Time),
-		stateFile:   stateFile,
+		limits:        limits,
+		actions:       make([]ActionRecord, 0),
+		lastAction:    make(map[ActionType]time.Time),
+		burstCount:    make(map[ActionType]int),
+		burstStart:    make(map[ActionType]time.Time),
+		inCooldown:    make(map[ActionType]bool),
+		cooldownEnd:   make(map[ActionType]time.Time),
+		mode:          LimiterWindow,
+		bucketSize:    DefaultTokenBucketBurst,
+		tokens:        make(map[ActionType]float64),
+		tokensUpdated: make(map[ActionType]time.Time),
+		stateFile:     stateFile,
+		clock:         SystemClock,
 	}
 
 	// Load persisted state
@@ -439,6 +627,22 @@ func NewRateLimiterWithConfig(limits map[ActionType]*RateLimitConfig, stateFile
 	return rl
 }
 
+// SetClock replaces the limiter's time source (SystemClock by default)
+func (rl *RateLimiter) SetClock(clock Clock) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.clock = clock
+}
+
+// ReloadLimits re-reads the limits from the global config
+// Call after SetSafetyLevel so a running session picks up the new level
+func (rl *RateLimiter) ReloadLimits() {
+	limits := DefaultLimits()
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.limits = limits
+}
+
 // CanPerform checks if an action can be performed now
 func (rl *RateLimiter) CanPerform(action ActionType) (bool, string) {
 	rl.mu.RLock()
@@ -449,12 +653,12 @@ func (rl *RateLimiter) CanPerform(action ActionType) (bool, string) {
 		return true, "" // No limits configured
 	}
 
-	now := time.Now()
+	now := rl.clock.Now()
 
 	// Check cooldown
 	if rl.inCooldown[action] && now.Before(rl.cooldownEnd[action]) {
 		remaining := rl.cooldownEnd[action].Sub(now)
-		return false, fmt.Sprintf("in cooldown (%v remaining)", remaining.Round(time.Second))
+		return false, rateLimitBlocked(action, fmt.Sprintf("in cooldown (%v remaining)", remaining.Round(time.Second)))
 	}
 
 	// Clear expired cooldown
@@ -467,16 +671,20 @@ func (rl *RateLimiter) CanPerform(action ActionType) (bool, string) {
 		rl.mu.RLock()
 	}
 
+	if rl.mode == LimiterTokenBucket {
+		return rl.canPerformTokenBucket(action, cfg, now)
+	}
+
 	// Check daily limit
 	dailyCount := rl.countActionsSince(action, now.Add(-24*time.Hour))
 	if dailyCount >= cfg.DailyLimit {
-		return false, fmt.Sprintf("daily limit reached (%d/%d)", dailyCount, cfg.DailyLimit)
+		return false, rateLimitBlocked(action, fmt.Sprintf("daily limit reached (%d/%d)", dailyCount, cfg.DailyLimit))
 	}
 
 	// Check hourly limit
 	hourlyCount := rl.countActionsSince(action, now.Add(-1*time.Hour))
 	if hourlyCount >= cfg.HourlyLimit {
-		return false, fmt.Sprintf("hourly limit reached (%d/%d)", hourlyCount, cfg.HourlyLimit)
+		return false, rateLimitBlocked(action, fmt.Sprintf("hourly limit reached (%d/%d)", hourlyCount, cfg.HourlyLimit))
 	}
 
 	// Check minimum interval
@@ -485,7 +693,7 @@ func (rl *RateLimiter) CanPerform(action ActionType) (bool, string) {
 		minInterval := time.Duration(cfg.MinIntervalSeconds) * time.Second
 		if elapsed < minInterval {
 			wait := minInterval - elapsed
-			return false, fmt.Sprintf("too soon (wait %v)", wait.Round(time.Second))
+			return false, rateLimitBlocked(action, fmt.Sprintf("too soon (wait %v)", wait.Round(time.Second)))
 		}
 	}
 
@@ -497,7 +705,7 @@ func (rl *RateLimiter) RecordAction(action ActionType) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
+	now := rl.clock.Now()
 
 	// Record the action
 	rl.actions = append(rl.actions, ActionRecord{
@@ -512,6 +720,14 @@ func (rl *RateLimiter) RecordAction(action ActionType) {
 		return
 	}
 
+	// The bucket does the pacing - no burst cooldowns on top of it
+	if rl.mode == LimiterTokenBucket {
+		rl.takeToken(action, cfg, now)
+		rl.pruneOldActions()
+		rl.saveStateUnlocked()
+		return
+	}
+
 	// Check if burst window expired (reset burst count)
 	if burstStart, exists := rl.burstStart[action]; exists {
 		burstWindow := time.Duration(cfg.BurstCooldown) * time.Second
@@ -532,6 +748,10 @@ func (rl *RateLimiter) RecordAction(action ActionType) {
 		rl.cooldownEnd[action] = now.Add(time.Duration(cfg.BurstCooldown) * time.Second)
 		fmt.Printf("⏸️ Burst limit reached for %s - cooldown until %s\n",
 			action, rl.cooldownEnd[action].Format("15:04:05"))
+		LogEvent(EventCooldown, "burst limit reached", map[string]interface{}{
+			"action": string(action),
+			"until":  rl.cooldownEnd[action],
+		})
 	}
 
 	// Check if cooldown threshold reached
@@ -541,6 +761,10 @@ func (rl *RateLimiter) RecordAction(action ActionType) {
 		rl.cooldownEnd[action] = now.Add(time.Duration(cfg.CooldownDuration) * time.Minute)
 		fmt.Printf("⏸️ Cooldown threshold reached for %s - resting until %s\n",
 			action, rl.cooldownEnd[action].Format("15:04:05"))
+		LogEvent(EventCooldown, "cooldown threshold reached", map[string]interface{}{
+			"action": string(action),
+			"until":  rl.cooldownEnd[action],
+		})
 	}
 
 	// Prune old actions (keep 24h)
@@ -552,21 +776,40 @@ func (rl *RateLimiter) RecordAction(action ActionType) {
 
 // WaitForAction waits until action can be performed, returns false if should stop
 func (rl *RateLimiter) WaitForAction(action ActionType) bool {
+	ok, _ := rl.waitForAction(action, func(d time.Duration) error {
+		time.Sleep(d)
+		return nil
+	})
+	return ok
+}
+
+// WaitForActionOn is WaitForAction with the detection monitor watching page during the wait
+// A critical warning during the wait is returned (with false) so the caller can pause
+func (rl *RateLimiter) WaitForActionOn(page *rod.Page, action ActionType) (bool, error) {
+	return rl.waitForAction(action, func(d time.Duration) error {
+		return WatchedSleep(page, d)
+	})
+}
+
+// waitForAction loops until action is allowed, sleeping with sleep between checks
+func (rl *RateLimiter) waitForAction(action ActionType, sleep func(time.Duration) error) (bool, error) {
 	for {
 		can, reason := rl.CanPerform(action)
 		if can {
-			return true
+			return true, nil
 		}
 
 		// Calculate wait time
 		waitTime := rl.getWaitTime(action)
 		if waitTime > 30*time.Minute {
 			fmt.Printf("⏰ Long wait required for %s (%s): %v\n", action, reason, waitTime.Round(time.Minute))
-			return false // Too long, let caller decide
+			return false, nil // Too long, let caller decide
 		}
 
 		fmt.Printf("⏳ Waiting for %s (%s): %v\n", action, reason, waitTime.Round(time.Second))
-		time.Sleep(waitTime)
+		if err := sleep(waitTime); err != nil {
+			return false, err
+		}
 	}
 }
 
@@ -577,13 +820,13 @@ func (rl *RateLimiter) GetRecommendedDelay(action ActionType) time.Duration {
 
 	cfg, exists := rl.limits[action]
 	if !exists {
-		return time.Duration(5+rand.Intn(10)) * time.Second
+		return time.Duration(5+rng.Intn(10)) * time.Second
 	}
 
 	// Random delay between min and max interval
 	minSec := cfg.MinIntervalSeconds
 	maxSec := cfg.MaxIntervalSeconds
-	delaySec := minSec + rand.Intn(maxSec-minSec+1)
+	delaySec := minSec + rng.Intn(maxSec-minSec+1)
 
 	return time.Duration(delaySec) * time.Second
 }
@@ -593,7 +836,7 @@ func (rl *RateLimiter) GetStats(action ActionType) ActionStats {
 	rl.mu.RLock()
 	defer rl.mu.RUnlock()
 
-	now := time.Now()
+	now := rl.clock.Now()
 	cfg := rl.limits[action]
 
 	stats := ActionStats{
@@ -610,6 +853,11 @@ func (rl *RateLimiter) GetStats(action ActionType) ActionStats {
 		stats.DailyRemaining = cfg.DailyLimit - stats.DailyCount
 		stats.HourlyRemaining = cfg.HourlyLimit - stats.HourlyCount
 		stats.BurstLimit = cfg.BurstLimit
+		if rl.mode == LimiterTokenBucket {
+			stats.TokenBucket = true
+			stats.Tokens = rl.tokensAt(action, cfg, now)
+			stats.BucketSize = rl.bucketSize
+		}
 	}
 
 	if rl.inCooldown[action] {
@@ -639,6 +887,9 @@ type ActionStats struct {
 	BurstLimit          int
 	LastAction          time.Time
 	TimeSinceLastAction time.Duration
+	TokenBucket         bool    // Token bucket mode is on
+	Tokens              float64 // Actions available right now (token bucket mode)
+	BucketSize          int
 }
 
 // PrintStats prints formatted statistics
@@ -648,7 +899,11 @@ func (rl *RateLimiter) PrintStats(action ActionType) {
 	fmt.Printf("\n📊 Rate Limit Stats for %s:\n", action)
 	fmt.Printf("   Daily:  %d/%d (remaining: %d)\n", stats.DailyCount, stats.DailyLimit, stats.DailyRemaining)
 	fmt.Printf("   Hourly: %d/%d (remaining: %d)\n", stats.HourlyCount, stats.HourlyLimit, stats.HourlyRemaining)
-	fmt.Printf("   Burst:  %d/%d\n", stats.BurstCount, stats.BurstLimit)
+	if stats.TokenBucket {
+		fmt.Printf("   Tokens: %.2f/%d\n", stats.Tokens, stats.BucketSize)
+	} else {
+		fmt.Printf("   Burst:  %d/%d\n", stats.BurstCount, stats.BurstLimit)
+	}
 
 	if stats.InCooldown {
 		fmt.Printf("   ⏸️ IN COOLDOWN: %v remaining\n", stats.CooldownRemaining.Round(time.Second))
@@ -672,6 +927,12 @@ func (rl *RateLimiter) PrintAllStats() {
 
 // === Internal helpers ===
 
+// rateLimitBlocked records a rate-limit block in the audit trail and returns the reason
+func rateLimitBlocked(action ActionType, reason string) string {
+	LogEvent(EventRateLimit, reason, map[string]interface{}{"action": string(action)})
+	return reason
+}
+
 func (rl *RateLimiter) countActionsSince(action ActionType, since time.Time) int {
 	count := 0
 	for _, record := range rl.actions {
@@ -695,13 +956,20 @@ func (rl *RateLimiter) getWaitTime(action ActionType) time.Duration {
 		return 5 * time.Second
 	}
 
-	now := time.Now()
+	now := rl.clock.Now()
 
 	// If in cooldown, wait for cooldown to end
 	if rl.inCooldown[action] && now.Before(rl.cooldownEnd[action]) {
 		return rl.cooldownEnd[action].Sub(now) + time.Second
 	}
 
+	// In token bucket mode, wait for the next token
+	if rl.mode == LimiterTokenBucket {
+		if wait := rl.tokenWait(action, cfg, now); wait > 0 {
+			return wait + time.Second
+		}
+	}
+
 	// If minimum interval not met, wait for it
 	if lastTime, exists := rl.lastAction[action]; exists {
 		minInterval := time.Duration(cfg.MinIntervalSeconds) * time.Second
@@ -716,7 +984,7 @@ func (rl *RateLimiter) getWaitTime(action ActionType) time.Duration {
 }
 
 func (rl *RateLimiter) pruneOldActions() {
-	cutoff := time.Now().Add(-24 * time.Hour)
+	cutoff := rl.clock.Now().Add(-24 * time.Hour)
 	filtered := make([]ActionRecord, 0, len(rl.actions))
 
 	for _, record := range rl.actions {
@@ -742,7 +1010,7 @@ func (rl *RateLimiter) loadState() {
 	}
 
 	// Only load if saved recently (within 24h)
-	if time.Since(state.SavedAt) > 24*time.Hour {
+	if rl.clock.Now().Sub(state.SavedAt) > 24*time.Hour {
 		return
 	}
 
@@ -759,11 +1027,17 @@ func (rl *RateLimiter) loadState() {
 		rl.burstStart[ActionType(k)] = v
 	}
 	for k, v := range state.CooldownEnd {
-		if time.Now().Before(v) {
+		if rl.clock.Now().Before(v) {
 			rl.inCooldown[ActionType(k)] = true
 			rl.cooldownEnd[ActionType(k)] = v
 		}
 	}
+	for k, v := range state.Tokens {
+		if at, ok := state.TokensAt[k]; ok {
+			rl.tokens[ActionType(k)] = v
+			rl.tokensUpdated[ActionType(k)] = at
+		}
+	}
 
 	// Prune old actions
 	rl.pruneOldActions()
@@ -778,7 +1052,9 @@ func (rl *RateLimiter) saveStateUnlocked() {
 		BurstCount:  make(map[string]int),
 		BurstStart:  make(map[string]time.Time),
 		CooldownEnd: make(map[string]time.Time),
-		SavedAt:     time.Now(),
+		Tokens:      make(map[string]float64),
+		TokensAt:    make(map[string]time.Time),
+		SavedAt:     rl.clock.Now(),
 	}
 
 	for k, v := range rl.lastAction {
@@ -793,6 +1069,10 @@ func (rl *RateLimiter) saveStateUnlocked() {
 	for k, v := range rl.cooldownEnd {
 		state.CooldownEnd[string(k)] = v
 	}
+	for k, v := range rl.tokens {
+		state.Tokens[string(k)] = v
+		state.TokensAt[string(k)] = rl.tokensUpdated[k]
+	}
 
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
@@ -819,6 +1099,8 @@ func (rl *RateLimiter) Reset(action ActionType) {
 	delete(rl.burstStart, action)
 	delete(rl.inCooldown, action)
 	delete(rl.cooldownEnd, action)
+	delete(rl.tokens, action)
+	delete(rl.tokensUpdated, action)
 
 	// Remove actions of this type
 	filtered := make([]ActionRecord, 0)
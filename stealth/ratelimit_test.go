@@ -0,0 +1,76 @@
+package stealth
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(t *testing.T, cfg RateLimitConfig) (*RateLimiter, *ManualClock) {
+	t.Helper()
+	clock := NewManualClock(time.Date(2024, time.June, 3, 9, 0, 0, 0, time.Local))
+	rl := NewRateLimiterWithConfig(map[ActionType]*RateLimitConfig{ActionConnection: &cfg},
+		filepath.Join(t.TempDir(), "rate_limiter_state.json"))
+	rl.SetClock(clock)
+	return rl, clock
+}
+
+func TestRateLimiterDailyWindowRollsOver(t *testing.T) {
+	rl, clock := newTestLimiter(t, RateLimitConfig{
+		DailyLimit:         3,
+		HourlyLimit:        100,
+		MinIntervalSeconds: 60,
+		CooldownThreshold:  100,
+		BurstLimit:         100,
+		BurstCooldown:      60,
+	})
+
+	for i := 0; i < 3; i++ {
+		if ok, reason := rl.CanPerform(ActionConnection); !ok {
+			t.Fatalf("action %d blocked: %s", i+1, reason)
+		}
+		rl.RecordAction(ActionConnection)
+		clock.Advance(10 * time.Minute)
+	}
+
+	if ok, _ := rl.CanPerform(ActionConnection); ok {
+		t.Fatal("allowed a 4th action with a daily limit of 3")
+	}
+
+	// 23h after the first action all three are still in the window
+	clock.Set(time.Date(2024, time.June, 4, 8, 0, 0, 0, time.Local))
+	if ok, _ := rl.CanPerform(ActionConnection); ok {
+		t.Fatal("daily window rolled over before 24h passed")
+	}
+
+	// Once the first action is more than 24h old, one slot frees up
+	clock.Set(time.Date(2024, time.June, 4, 9, 1, 0, 0, time.Local))
+	if ok, reason := rl.CanPerform(ActionConnection); !ok {
+		t.Fatalf("still blocked after the first action left the window: %s", reason)
+	}
+	rl.RecordAction(ActionConnection)
+	if ok, _ := rl.CanPerform(ActionConnection); ok {
+		t.Fatal("allowed more than 3 actions in the rolling day")
+	}
+}
+
+func TestRateLimiterMinInterval(t *testing.T) {
+	rl, clock := newTestLimiter(t, RateLimitConfig{
+		DailyLimit:         100,
+		HourlyLimit:        100,
+		MinIntervalSeconds: 60,
+		CooldownThreshold:  100,
+		BurstLimit:         100,
+		BurstCooldown:      60,
+	})
+
+	rl.RecordAction(ActionConnection)
+	clock.Advance(59 * time.Second)
+	if ok, _ := rl.CanPerform(ActionConnection); ok {
+		t.Fatal("allowed an action before the minimum interval passed")
+	}
+	clock.Advance(time.Second)
+	if ok, reason := rl.CanPerform(ActionConnection); !ok {
+		t.Fatalf("blocked after the minimum interval: %s", reason)
+	}
+}
@@ -0,0 +1,76 @@
+package stealth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-rod/rod"
+)
+
+// ErrReadOnly is returned when a write action is blocked by read-only mode
+var ErrReadOnly = errors.New("read-only mode: write action blocked")
+
+// readOnly is the master switch checked by every send/connect click helper
+//
+// WHY A SECOND SWITCH:
+// - Dry run is a flag on each tracker/service, so every caller has to remember it
+// - Read-only is enforced where the clicks happen, so a forgotten flag can't send anything
+var readOnly atomic.Bool
+
+// SetReadOnly enables or disables read-only mode for the whole process
+func SetReadOnly(enabled bool) {
+	readOnly.Store(enabled)
+	if enabled {
+		fmt.Println("🔒 READ-ONLY MODE - connect/message/like clicks are blocked")
+	}
+}
+
+// IsReadOnly reports whether write actions are blocked
+func IsReadOnly() bool {
+	return readOnly.Load()
+}
+
+// BlockWrite returns ErrReadOnly (and logs the blocked action) in read-only mode, nil otherwise
+// Call it right before the click that would send something
+func BlockWrite(what, target string) error {
+	if !IsReadOnly() {
+		return nil
+	}
+	if target != "" {
+		fmt.Printf("🔒 [READ-ONLY] Blocked %s: %s\n", what, target)
+	} else {
+		fmt.Printf("🔒 [READ-ONLY] Blocked %s\n", what)
+	}
+	return ErrReadOnly
+}
+
+// writeLabels are button texts/labels of controls that change something on LinkedIn
+var writeLabels = []string{"like", "follow", "invite", "withdraw"}
+
+// isWriteControl reports whether el is a button that sends, connects, likes or follows
+func isWriteControl(el *rod.Element) bool {
+	text, _ := el.Text()
+	label, _ := el.Attribute("aria-label")
+	s := strings.ToLower(strings.TrimSpace(text))
+	if label != nil {
+		s += " " + strings.ToLower(*label)
+	}
+
+	for _, loc := range Locales {
+		for _, words := range [][]string{loc.Connect, loc.Send, loc.Withdraw} {
+			for _, w := range words {
+				if strings.Contains(s, w) {
+					return true
+				}
+			}
+		}
+	}
+	for _, w := range writeLabels {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
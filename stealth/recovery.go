@@ -0,0 +1,114 @@
+package stealth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// RecoveryRule controls how CheckAndHandle reacts to one RecoveryAction
+type RecoveryRule struct {
+	WaitSec    int `json:"wait_sec"`    // Pause before continuing (or between retries)
+	MaxRetries int `json:"max_retries"` // Re-check the page this many times before giving up
+}
+
+// Wait returns the rule's wait as a duration
+func (r RecoveryRule) Wait() time.Duration {
+	return time.Duration(r.WaitSec) * time.Second
+}
+
+// RecoveryPolicy maps each RecoveryAction to a RecoveryRule
+// Actions missing from a loaded policy keep their default rule
+type RecoveryPolicy struct {
+	Rules map[RecoveryAction]RecoveryRule `json:"rules"`
+}
+
+// DefaultRecoveryPolicy returns the built-in recovery behaviour
+func DefaultRecoveryPolicy() *RecoveryPolicy {
+	return &RecoveryPolicy{
+		Rules: map[RecoveryAction]RecoveryRule{
+			ActionStop:     {WaitSec: 0, MaxRetries: 0},
+			ActionManual:   {WaitSec: 0, MaxRetries: 0},
+			ActionReauth:   {WaitSec: 0, MaxRetries: 0},
+			ActionCooldown: {WaitSec: 30 * 60, MaxRetries: 0}, // 30 min break
+			ActionWait:     {WaitSec: 5, MaxRetries: 0},
+			ActionSkip:     {WaitSec: 0, MaxRetries: 0},
+			ActionContinue: {WaitSec: 0, MaxRetries: 0},
+		},
+	}
+}
+
+// Rule returns the rule for an action (zero rule if unknown)
+func (p *RecoveryPolicy) Rule(action RecoveryAction) RecoveryRule {
+	return p.Rules[action]
+}
+
+// Global recovery policy instance - SINGLETON
+var (
+	recoveryPolicy     *RecoveryPolicy
+	recoveryPolicyOnce sync.Once
+	recoveryPolicyMu   sync.RWMutex
+	recoveryPolicyFile = "recovery_policy.json"
+)
+
+// GetRecoveryPolicy returns the active recovery policy (singleton)
+func GetRecoveryPolicy() *RecoveryPolicy {
+	recoveryPolicyOnce.Do(func() {
+		policy, err := LoadRecoveryPolicy(recoveryPolicyFile)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				fmt.Printf("⚠️ Failed to load %s: %v (using defaults)\n", recoveryPolicyFile, err)
+			}
+			policy = DefaultRecoveryPolicy()
+		}
+		recoveryPolicyMu.Lock()
+		recoveryPolicy = policy
+		recoveryPolicyMu.Unlock()
+	})
+
+	recoveryPolicyMu.RLock()
+	defer recoveryPolicyMu.RUnlock()
+	return recoveryPolicy
+}
+
+// SetRecoveryPolicy replaces the active recovery policy
+func SetRecoveryPolicy(policy *RecoveryPolicy) {
+	GetRecoveryPolicy() // make sure the file load doesn't overwrite us later
+
+	recoveryPolicyMu.Lock()
+	defer recoveryPolicyMu.Unlock()
+	recoveryPolicy = policy
+}
+
+// LoadRecoveryPolicy reads a policy from a JSON file, filling gaps with defaults
+func LoadRecoveryPolicy(path string) (*RecoveryPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded RecoveryPolicy
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return nil, fmt.Errorf("invalid recovery policy: %w", err)
+	}
+
+	policy := DefaultRecoveryPolicy()
+	for action, rule := range loaded.Rules {
+		if rule.WaitSec < 0 || rule.MaxRetries < 0 {
+			return nil, fmt.Errorf("invalid recovery rule for %s: negative values", action)
+		}
+		policy.Rules[action] = rule
+	}
+	return policy, nil
+}
+
+// SaveRecoveryPolicy writes a policy to a JSON file
+func SaveRecoveryPolicy(path string, policy *RecoveryPolicy) error {
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -2,7 +2,7 @@ package stealth
 
 import (
 	"fmt"
-	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -34,6 +34,26 @@ type ScheduleConfig struct {
 	BurstDurationMax int
 	BurstGapMin      int // minutes between bursts
 	BurstGapMax      int
+
+	// Per-action activity windows inside work hours, e.g. connects 9-12 and
+	// messages 14-17. Actions without windows may run all work day.
+	ActionWindows map[ActionType][]ActivityWindow
+}
+
+// ActivityWindow is a daily block of hours (24-hour clock, end exclusive)
+type ActivityWindow struct {
+	StartHour int
+	EndHour   int
+}
+
+// Contains reports whether t falls inside the window
+func (w ActivityWindow) Contains(t time.Time) bool {
+	return t.Hour() >= w.StartHour && t.Hour() < w.EndHour
+}
+
+// String formats the window as "9:00-12:00"
+func (w ActivityWindow) String() string {
+	return fmt.Sprintf("%d:00-%d:00", w.StartHour, w.EndHour)
 }
 
 // DefaultScheduleConfig returns a realistic work schedule
@@ -64,6 +84,8 @@ func DefaultScheduleConfig() *ScheduleConfig {
 		BurstDurationMax: 45,
 		BurstGapMin:      5, // Then rest 5-20 min
 		BurstGapMax:      20,
+
+		ActionWindows: map[ActionType][]ActivityWindow{},
 	}
 }
 
@@ -73,6 +95,7 @@ var ScheduleCfg = DefaultScheduleConfig()
 // Scheduler manages activity timing
 type Scheduler struct {
 	config *ScheduleConfig
+	clock  Clock
 
 	// Daily state (recalculated each day)
 	todayStart    time.Time
@@ -97,34 +120,39 @@ func NewScheduler() *Scheduler {
 
 // NewSchedulerWithConfig creates a scheduler with custom config
 func NewSchedulerWithConfig(cfg *ScheduleConfig) *Scheduler {
-	s := &Scheduler{config: cfg}
+	return NewSchedulerWithClock(cfg, SystemClock)
+}
+
+// NewSchedulerWithClock creates a scheduler that reads the time from clock
+func NewSchedulerWithClock(cfg *ScheduleConfig, clock Clock) *Scheduler {
+	s := &Scheduler{config: cfg, clock: clock}
 	s.initDay()
 	return s
 }
 
 // initDay sets up today's schedule with variation
 func (s *Scheduler) initDay() {
-	now := time.Now()
+	now := s.clock.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
 	// Calculate today's start time with variation
-	startVariation := rand.Intn(s.config.StartVariation*2+1) - s.config.StartVariation
+	startVariation := rng.Intn(s.config.StartVariation*2+1) - s.config.StartVariation
 	s.todayStart = today.Add(time.Duration(s.config.WorkStartHour)*time.Hour +
 		time.Duration(startVariation)*time.Minute)
 
 	// Calculate today's end time with variation
-	endVariation := rand.Intn(s.config.EndVariation*2+1) - s.config.EndVariation
+	endVariation := rng.Intn(s.config.EndVariation*2+1) - s.config.EndVariation
 	s.todayEnd = today.Add(time.Duration(s.config.WorkEndHour)*time.Hour +
 		time.Duration(endVariation)*time.Minute)
 
 	// Calculate lunch time with slight variation
-	lunchVariation := rand.Intn(31) - 15 // ±15 minutes
+	lunchVariation := rng.Intn(31) - 15 // ±15 minutes
 	s.todayLunch = today.Add(time.Duration(s.config.LunchStartHour)*time.Hour +
 		time.Duration(lunchVariation)*time.Minute)
 
 	// Random lunch duration
 	lunchMins := s.config.LunchDurationMin +
-		rand.Intn(s.config.LunchDurationMax-s.config.LunchDurationMin+1)
+		rng.Intn(s.config.LunchDurationMax-s.config.LunchDurationMin+1)
 	s.lunchDuration = time.Duration(lunchMins) * time.Minute
 
 	s.currentDay = now.YearDay()
@@ -140,7 +168,7 @@ func (s *Scheduler) initDay() {
 
 // refreshIfNewDay checks if we need to recalculate today's schedule
 func (s *Scheduler) refreshIfNewDay() {
-	now := time.Now()
+	now := s.clock.Now()
 	if now.YearDay() != s.currentDay || !s.initialized {
 		s.initDay()
 	}
@@ -148,7 +176,7 @@ func (s *Scheduler) refreshIfNewDay() {
 
 // IsWorkDay returns true if today is a work day
 func (s *Scheduler) IsWorkDay() bool {
-	today := time.Now().Weekday()
+	today := s.clock.Now().Weekday()
 	for _, wd := range s.config.WorkDays {
 		if wd == today {
 			return true
@@ -165,7 +193,7 @@ func (s *Scheduler) IsWorkHours() bool {
 		return false
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 	return now.After(s.todayStart) && now.Before(s.todayEnd)
 }
 
@@ -173,14 +201,34 @@ func (s *Scheduler) IsWorkHours() bool {
 func (s *Scheduler) IsLunchTime() bool {
 	s.refreshIfNewDay()
 
-	now := time.Now()
+	now := s.clock.Now()
 	lunchEnd := s.todayLunch.Add(s.lunchDuration)
 	return now.After(s.todayLunch) && now.Before(lunchEnd)
 }
 
-// CanOperate returns true if it's appropriate to perform actions
-func (s *Scheduler) CanOperate() bool {
-	return s.IsWorkHours() && !s.IsLunchTime()
+// InActionWindow returns true if the action's activity windows allow it right now
+// Actions without configured windows are allowed all work day
+func (s *Scheduler) InActionWindow(action ActionType) bool {
+	windows := s.config.ActionWindows[action]
+	if len(windows) == 0 {
+		return true
+	}
+	now := s.clock.Now()
+	for _, w := range windows {
+		if w.Contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanOperate returns true if it's appropriate to perform the action
+// An empty action asks about work in general (work hours and lunch only)
+func (s *Scheduler) CanOperate(action ActionType) bool {
+	if !s.IsWorkHours() || s.IsLunchTime() {
+		return false
+	}
+	return action == "" || s.InActionWindow(action)
 }
 
 // WaitUntilCanOperate blocks until it's appropriate to operate
@@ -189,16 +237,16 @@ func (s *Scheduler) WaitUntilCanOperate() bool {
 	for {
 		s.refreshIfNewDay()
 
-		if s.CanOperate() {
+		if s.CanOperate("") {
 			return true
 		}
 
-		now := time.Now()
+		now := s.clock.Now()
 
 		// If it's lunch, wait for lunch to end
 		if s.IsLunchTime() {
 			lunchEnd := s.todayLunch.Add(s.lunchDuration)
-			waitTime := lunchEnd.Sub(now) + time.Duration(rand.Intn(300))*time.Second
+			waitTime := lunchEnd.Sub(now) + time.Duration(rng.Intn(300))*time.Second
 			fmt.Printf("🍽️ Lunch break - waiting %v\n", waitTime.Round(time.Minute))
 			time.Sleep(waitTime)
 			continue
@@ -232,9 +280,9 @@ func (s *Scheduler) WaitUntilCanOperate() bool {
 // StartBurst begins a new activity burst
 func (s *Scheduler) StartBurst() {
 	burstMins := s.config.BurstDurationMin +
-		rand.Intn(s.config.BurstDurationMax-s.config.BurstDurationMin+1)
+		rng.Intn(s.config.BurstDurationMax-s.config.BurstDurationMin+1)
 	s.burstDuration = time.Duration(burstMins) * time.Minute
-	s.burstStart = time.Now()
+	s.burstStart = s.clock.Now()
 	s.inBurst = true
 
 	fmt.Printf("🚀 Starting activity burst (%d min)\n", burstMins)
@@ -247,12 +295,12 @@ func (s *Scheduler) ShouldTakeBreak() bool {
 	}
 
 	// Check if burst duration exceeded
-	if time.Since(s.burstStart) > s.burstDuration {
+	if s.clock.Now().Sub(s.burstStart) > s.burstDuration {
 		return true
 	}
 
 	// Random short break chance
-	return rand.Float64() < s.config.ShortBreakChance/10 // Per-check probability
+	return rng.Float64() < s.config.ShortBreakChance/10 // Per-check probability
 }
 
 // TakeBreak pauses for an appropriate break duration
@@ -260,15 +308,15 @@ func (s *Scheduler) TakeBreak() {
 	s.inBurst = false
 
 	// Determine break type
-	if rand.Float64() < 0.3 { // 30% chance of short break
+	if rng.Float64() < 0.3 { // 30% chance of short break
 		breakMins := s.config.ShortBreakDurationMin +
-			rand.Intn(s.config.ShortBreakDurationMax-s.config.ShortBreakDurationMin+1)
+			rng.Intn(s.config.ShortBreakDurationMax-s.config.ShortBreakDurationMin+1)
 		fmt.Printf("☕ Short break (%d min)\n", breakMins)
 		time.Sleep(time.Duration(breakMins) * time.Minute)
 	} else {
 		// Normal gap between bursts
 		gapMins := s.config.BurstGapMin +
-			rand.Intn(s.config.BurstGapMax-s.config.BurstGapMin+1)
+			rng.Intn(s.config.BurstGapMax-s.config.BurstGapMin+1)
 		fmt.Printf("💤 Resting between activities (%d min)\n", gapMins)
 		time.Sleep(time.Duration(gapMins) * time.Minute)
 	}
@@ -276,7 +324,7 @@ func (s *Scheduler) TakeBreak() {
 
 // RecordActivity logs that an activity was performed
 func (s *Scheduler) RecordActivity() {
-	s.lastActivity = time.Now()
+	s.lastActivity = s.clock.Now()
 }
 
 // TimeSinceLastActivity returns duration since last recorded activity
@@ -284,7 +332,7 @@ func (s *Scheduler) TimeSinceLastActivity() time.Duration {
 	if s.lastActivity.IsZero() {
 		return 0
 	}
-	return time.Since(s.lastActivity)
+	return s.clock.Now().Sub(s.lastActivity)
 }
 
 // GetStatus returns a human-readable status string
@@ -295,7 +343,7 @@ func (s *Scheduler) GetStatus() string {
 		return "🏠 Weekend/Holiday"
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 
 	if now.Before(s.todayStart) {
 		return fmt.Sprintf("⏰ Before work (starts %s)", s.todayStart.Format("3:04 PM"))
@@ -310,20 +358,77 @@ func (s *Scheduler) GetStatus() string {
 		return fmt.Sprintf("🍽️ Lunch break (until %s)", lunchEnd.Format("3:04 PM"))
 	}
 
+	status := "✅ Ready to work"
 	if s.inBurst {
-		remaining := s.burstDuration - time.Since(s.burstStart)
-		return fmt.Sprintf("🚀 Active burst (%v remaining)", remaining.Round(time.Minute))
+		remaining := s.burstDuration - s.clock.Now().Sub(s.burstStart)
+		status = fmt.Sprintf("🚀 Active burst (%v remaining)", remaining.Round(time.Minute))
 	}
 
-	return "✅ Ready to work"
+	for _, action := range []ActionType{ActionConnection, ActionMessage, ActionSearch} {
+		if windows := s.config.ActionWindows[action]; len(windows) > 0 && !s.InActionWindow(action) {
+			status += fmt.Sprintf(" (outside %s window %v)", action, windows)
+		}
+	}
+	return status
+}
+
+// Delay multipliers used by DelayMultiplier
+const (
+	offHoursDelayFactor = 2.5  // Outside work hours / non-work days
+	lunchDelayFactor    = 1.8  // Lunch break - distracted, slow
+	edgeDelayFactor     = 1.25 // First/last 30 min of the day - warming up or winding down
+	peakDelayFactor     = 0.8  // Mid-morning and mid-afternoon focus time
+)
+
+// DelayMultiplier returns how much to stretch (>1) or compress (<1) delays right now
+// Based on today's work hours so delays follow the same rhythm as the schedule
+func (s *Scheduler) DelayMultiplier() float64 {
+	s.refreshIfNewDay()
+
+	if !s.IsWorkHours() {
+		return offHoursDelayFactor
+	}
+	if s.IsLunchTime() {
+		return lunchDelayFactor
+	}
+
+	now := s.clock.Now()
+	if now.Sub(s.todayStart) < 30*time.Minute || s.todayEnd.Sub(now) < 30*time.Minute {
+		return edgeDelayFactor
+	}
+
+	hour := now.Hour()
+	if (hour >= 10 && hour < 12) || (hour >= 14 && hour < 16) {
+		return peakDelayFactor
+	}
+
+	return 1.0
+}
+
+// AdaptiveDelay returns GetRandomDelay scaled to the current time of day
+func (s *Scheduler) AdaptiveDelay(action ActionType) time.Duration {
+	return time.Duration(float64(GetRandomDelay(action)) * s.DelayMultiplier())
 }
 
 // === Convenience functions for simple usage ===
 
+var adaptiveScheduler *Scheduler
+var adaptiveSchedulerOnce sync.Once
+
+// GetAdaptiveDelay returns a time-of-day aware delay for an action
+// Blends the rate limiter's delay range with the scheduler's work hours:
+// stretched off-hours and at lunch, compressed during peak hours
+func GetAdaptiveDelay(action ActionType) time.Duration {
+	adaptiveSchedulerOnce.Do(func() {
+		adaptiveScheduler = NewScheduler()
+	})
+	return adaptiveScheduler.AdaptiveDelay(action)
+}
+
 // ShouldRunNow returns true if automation should run right now
 func ShouldRunNow() bool {
 	s := NewScheduler()
-	return s.CanOperate()
+	return s.CanOperate("")
 }
 
 // WaitForWorkHours blocks until work hours, returns false if should stop
@@ -2,7 +2,6 @@ package stealth
 
 import (
 	"math"
-	"math/rand"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -64,7 +63,7 @@ func ScrollDown(page *rod.Page) error {
 // ScrollDownWithConfig performs scroll with custom configuration
 func ScrollDownWithConfig(page *rod.Page, cfg *ScrollConfig) error {
 	// Random scroll distance
-	distance := rand.Intn(cfg.BaseScrollMax-cfg.BaseScrollMin+1) + cfg.BaseScrollMin
+	distance := rng.Intn(cfg.BaseScrollMax-cfg.BaseScrollMin+1) + cfg.BaseScrollMin
 
 	// Perform the scroll with acceleration
 	if cfg.UseAcceleration {
@@ -74,13 +73,13 @@ func ScrollDownWithConfig(page *rod.Page, cfg *ScrollConfig) error {
 	}
 
 	// Occasional scroll-back
-	if rand.Float64() < cfg.ScrollBackChance {
+	if rng.Float64() < cfg.ScrollBackChance {
 		scrollBack(page, distance, cfg)
 	}
 
 	// Occasional pause (simulating reading)
-	if rand.Float64() < cfg.PauseChance {
-		pauseDelay := rand.Intn(cfg.PauseMax-cfg.PauseMin+1) + cfg.PauseMin
+	if rng.Float64() < cfg.PauseChance {
+		pauseDelay := rng.Intn(cfg.PauseMax-cfg.PauseMin+1) + cfg.PauseMin
 		time.Sleep(time.Duration(pauseDelay) * time.Millisecond)
 	}
 
@@ -120,7 +119,7 @@ func smoothScroll(page *rod.Page, totalDistance int, cfg *ScrollConfig) {
 		page.Mouse.MustScroll(0, float64(stepDistance))
 
 		// Variable delay between steps (faster in middle)
-		delay := cfg.ScrollSpeedMin + rand.Intn(cfg.ScrollSpeedMax-cfg.ScrollSpeedMin+1)
+		delay := cfg.ScrollSpeedMin + rng.Intn(cfg.ScrollSpeedMax-cfg.ScrollSpeedMin+1)
 		if i > cfg.AccelSteps/2 && i < steps-cfg.AccelSteps/2 {
 			delay = delay / 2 // Faster in the middle
 		}
@@ -131,12 +130,12 @@ func smoothScroll(page *rod.Page, totalDistance int, cfg *ScrollConfig) {
 // simpleScroll performs basic scrolling without acceleration
 func simpleScroll(page *rod.Page, distance int, cfg *ScrollConfig) {
 	// Break into small steps
-	steps := 3 + rand.Intn(4) // 3-6 steps
+	steps := 3 + rng.Intn(4) // 3-6 steps
 	stepSize := distance / steps
 
 	for i := 0; i < steps; i++ {
 		// Add slight variation to each step
-		variation := rand.Intn(21) - 10 // -10 to +10
+		variation := rng.Intn(21) - 10 // -10 to +10
 		actualStep := stepSize + variation
 		if actualStep < 10 {
 			actualStep = 10
@@ -144,7 +143,7 @@ func simpleScroll(page *rod.Page, distance int, cfg *ScrollConfig) {
 
 		page.Mouse.MustScroll(0, float64(actualStep))
 
-		delay := cfg.ScrollSpeedMin + rand.Intn(cfg.ScrollSpeedMax-cfg.ScrollSpeedMin+1)
+		delay := cfg.ScrollSpeedMin + rng.Intn(cfg.ScrollSpeedMax-cfg.ScrollSpeedMin+1)
 		time.Sleep(time.Duration(delay) * time.Millisecond)
 	}
 }
@@ -152,17 +151,17 @@ func simpleScroll(page *rod.Page, distance int, cfg *ScrollConfig) {
 // scrollBack performs a slight scroll back (natural human behavior)
 func scrollBack(page *rod.Page, lastDistance int, cfg *ScrollConfig) {
 	// Calculate scroll-back amount
-	backPercent := cfg.ScrollBackMin + rand.Float64()*(cfg.ScrollBackMax-cfg.ScrollBackMin)
+	backPercent := cfg.ScrollBackMin + rng.Float64()*(cfg.ScrollBackMax-cfg.ScrollBackMin)
 	backDistance := int(float64(lastDistance) * backPercent)
 
 	// Small delay before scrolling back
-	time.Sleep(time.Duration(100+rand.Intn(200)) * time.Millisecond)
+	time.Sleep(time.Duration(100+rng.Intn(200)) * time.Millisecond)
 
 	// Scroll up (negative Y)
 	page.Mouse.MustScroll(0, float64(-backDistance))
 
 	// Brief pause after scroll-back
-	time.Sleep(time.Duration(50+rand.Intn(100)) * time.Millisecond)
+	time.Sleep(time.Duration(50+rng.Intn(100)) * time.Millisecond)
 }
 
 // easeInOutSine provides smooth acceleration/deceleration curve
@@ -201,7 +200,7 @@ func ScrollToBottomWithConfig(page *rod.Page, maxScrolls int, cfg *ScrollConfig)
 		ScrollDownWithConfig(page, cfg)
 
 		// Random delay between scrolls (reading time)
-		readTime := 500 + rand.Intn(1500) // 0.5 to 2 seconds
+		readTime := 500 + rng.Intn(1500) // 0.5 to 2 seconds
 		time.Sleep(time.Duration(readTime) * time.Millisecond)
 	}
 
@@ -263,7 +262,7 @@ func scrollToPosition(page *rod.Page, distance int) {
 	}
 
 	// Break into chunks with acceleration
-	chunks := 4 + rand.Intn(4) // 4-7 chunks
+	chunks := 4 + rng.Intn(4) // 4-7 chunks
 	baseChunk := distance / chunks
 
 	for i := 0; i < chunks; i++ {
@@ -283,7 +282,7 @@ func scrollToPosition(page *rod.Page, distance int) {
 		page.Mouse.MustScroll(0, float64(chunkSize*direction))
 
 		// Variable delay
-		delay := 20 + rand.Intn(40)
+		delay := 20 + rng.Intn(40)
 		time.Sleep(time.Duration(delay) * time.Millisecond)
 	}
 }
@@ -291,7 +290,7 @@ func scrollToPosition(page *rod.Page, distance int) {
 // RandomScroll performs a random scroll (up or down) to simulate browsing
 func RandomScroll(page *rod.Page) error {
 	// 70% chance to scroll down, 30% up
-	if rand.Float64() < 0.7 {
+	if rng.Float64() < 0.7 {
 		return ScrollDown(page)
 	}
 	return ScrollUp(page)
@@ -299,17 +298,17 @@ func RandomScroll(page *rod.Page) error {
 
 // ScrollUp performs a human-like scroll up
 func ScrollUp(page *rod.Page) error {
-	distance := rand.Intn(ScrollCfg.BaseScrollMax-ScrollCfg.BaseScrollMin+1) + ScrollCfg.BaseScrollMin
+	distance := rng.Intn(ScrollCfg.BaseScrollMax-ScrollCfg.BaseScrollMin+1) + ScrollCfg.BaseScrollMin
 
 	// Smaller scroll up (feels more natural)
 	distance = int(float64(distance) * 0.6)
 
-	steps := 2 + rand.Intn(3)
+	steps := 2 + rng.Intn(3)
 	stepSize := distance / steps
 
 	for i := 0; i < steps; i++ {
 		page.Mouse.MustScroll(0, float64(-stepSize))
-		delay := ScrollCfg.ScrollSpeedMin + rand.Intn(ScrollCfg.ScrollSpeedMax-ScrollCfg.ScrollSpeedMin+1)
+		delay := ScrollCfg.ScrollSpeedMin + rng.Intn(ScrollCfg.ScrollSpeedMax-ScrollCfg.ScrollSpeedMin+1)
 		time.Sleep(time.Duration(delay) * time.Millisecond)
 	}
 
@@ -320,7 +319,7 @@ func ScrollUp(page *rod.Page) error {
 func BrowseScroll(page *rod.Page, iterations int) error {
 	for i := 0; i < iterations; i++ {
 		// Random action
-		action := rand.Float64()
+		action := rng.Float64()
 
 		switch {
 		case action < 0.5:
@@ -331,7 +330,7 @@ func BrowseScroll(page *rod.Page, iterations int) error {
 			ScrollUp(page)
 		case action < 0.85:
 			// 20% - Pause and "read"
-			readTime := 1000 + rand.Intn(3000)
+			readTime := 1000 + rng.Intn(3000)
 			time.Sleep(time.Duration(readTime) * time.Millisecond)
 		default:
 			// 15% - Quick scroll (impatient user)
@@ -339,7 +338,7 @@ func BrowseScroll(page *rod.Page, iterations int) error {
 		}
 
 		// Small delay between actions
-		time.Sleep(time.Duration(200+rand.Intn(400)) * time.Millisecond)
+		time.Sleep(time.Duration(200+rng.Intn(400)) * time.Millisecond)
 	}
 
 	return nil
@@ -347,13 +346,13 @@ func BrowseScroll(page *rod.Page, iterations int) error {
 
 // quickScroll simulates an impatient fast scroll
 func quickScroll(page *rod.Page) {
-	distance := 500 + rand.Intn(300) // Larger, faster scroll
+	distance := 500 + rng.Intn(300) // Larger, faster scroll
 
 	steps := 2
 	stepSize := distance / steps
 
 	for i := 0; i < steps; i++ {
 		page.Mouse.MustScroll(0, float64(stepSize))
-		time.Sleep(time.Duration(10+rand.Intn(20)) * time.Millisecond)
+		time.Sleep(time.Duration(10+rng.Intn(20)) * time.Millisecond)
 	}
 }
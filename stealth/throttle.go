@@ -0,0 +1,122 @@
+package stealth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Network throttle detection
+//
+// WHY WATCH RESPONSES:
+// - LinkedIn throttles the XHRs behind a page (HTTP 429) while the page renders fine
+// - No banner ever shows up for CheckPage to read
+// - One stray 429 happens; several within a minute means we're being slowed down
+var (
+	// ThrottleThreshold is how many 429 responses within ThrottleWindow count as throttling
+	ThrottleThreshold = 3
+	ThrottleWindow    = time.Minute
+)
+
+// networkWatch collects 429 responses seen by one page
+type networkWatch struct {
+	mu      sync.Mutex
+	hits    []time.Time
+	lastURL string
+	cancel  context.CancelFunc
+	clock   Clock
+}
+
+var (
+	networkWatchesMu sync.Mutex
+	networkWatches   = make(map[proto.TargetTargetID]*networkWatch)
+)
+
+// WatchNetwork starts listening to page's responses for HTTP 429s from
+// LinkedIn. Throttling found this way is reported by CheckPage as
+// ErrorTooManyRequests. Watching a page twice is a no-op
+func WatchNetwork(page *rod.Page) {
+	networkWatchesMu.Lock()
+	defer networkWatchesMu.Unlock()
+	if _, ok := networkWatches[page.TargetID]; ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watch := &networkWatch{cancel: cancel, clock: SystemClock}
+	networkWatches[page.TargetID] = watch
+
+	wait := page.Context(ctx).EachEvent(func(e *proto.NetworkResponseReceived) {
+		if e.Response == nil || e.Response.Status != 429 || !strings.Contains(e.Response.URL, "linkedin.com") {
+			return
+		}
+		watch.record(e.Response.URL)
+	})
+	go wait()
+}
+
+// UnwatchNetwork stops the page's network listener (call before closing the page)
+func UnwatchNetwork(page *rod.Page) {
+	networkWatchesMu.Lock()
+	defer networkWatchesMu.Unlock()
+	if watch, ok := networkWatches[page.TargetID]; ok {
+		watch.cancel()
+		delete(networkWatches, page.TargetID)
+	}
+}
+
+// record notes one 429 response
+func (w *networkWatch) record(url string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.hits = append(w.hits, w.clock.Now())
+	w.lastURL = url
+}
+
+// take reports throttling if ThrottleThreshold 429s arrived within
+// ThrottleWindow, clearing the hits so the same burst is reported once
+func (w *networkWatch) take() (int, string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	cutoff := w.clock.Now().Add(-ThrottleWindow)
+	recent := w.hits[:0]
+	for _, t := range w.hits {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	w.hits = recent
+
+	if len(w.hits) < ThrottleThreshold {
+		return 0, "", false
+	}
+	count, url := len(w.hits), w.lastURL
+	w.hits = nil
+	return count, url, true
+}
+
+// checkNetworkThrottle returns ErrorTooManyRequests if page's watcher saw
+// a burst of 429s since the last check, plus the last throttled URL
+func checkNetworkThrottle(page *rod.Page) (*LinkedInError, string) {
+	networkWatchesMu.Lock()
+	watch, ok := networkWatches[page.TargetID]
+	networkWatchesMu.Unlock()
+	if !ok {
+		return nil, ""
+	}
+
+	count, url, throttled := watch.take()
+	if !throttled {
+		return nil, ""
+	}
+
+	err := createError(ErrorTooManyRequests)
+	err.Message = fmt.Sprintf("LinkedIn answered %d requests with HTTP 429 in the last %v - slow down", count, ThrottleWindow)
+	return err, url
+}
@@ -0,0 +1,32 @@
+package stealth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNetworkWatchWindow(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, time.June, 3, 9, 0, 0, 0, time.Local))
+	w := &networkWatch{clock: clock}
+
+	// 429s spread wider than ThrottleWindow are not throttling
+	for i := 0; i < ThrottleThreshold; i++ {
+		w.record("https://www.linkedin.com/voyager/api/search")
+		clock.Advance(ThrottleWindow)
+	}
+	if _, _, throttled := w.take(); throttled {
+		t.Fatal("reported throttling for 429s outside the window")
+	}
+
+	for i := 0; i < ThrottleThreshold; i++ {
+		w.record("https://www.linkedin.com/voyager/api/search")
+		clock.Advance(time.Second)
+	}
+	count, url, throttled := w.take()
+	if !throttled || count != ThrottleThreshold || url == "" {
+		t.Fatalf("take() = %d, %q, %v; want %d 429s reported", count, url, throttled, ThrottleThreshold)
+	}
+	if _, _, throttled := w.take(); throttled {
+		t.Fatal("reported the same burst twice")
+	}
+}
@@ -0,0 +1,105 @@
+package stealth
+
+import (
+	"fmt"
+	"time"
+)
+
+// LimiterMode selects how the rate limiter spaces actions
+type LimiterMode string
+
+const (
+	// LimiterWindow counts actions in daily/hourly windows with burst cooldowns (default)
+	LimiterWindow LimiterMode = "window"
+
+	// LimiterTokenBucket refills send budget continuously at DailyLimit per 24h,
+	// holding at most a few tokens, so actions spread over the day instead of
+	// bunching at window edges. The daily limit still applies as a hard cap
+	LimiterTokenBucket LimiterMode = "token_bucket"
+)
+
+// DefaultTokenBucketBurst is how many actions the bucket holds when no burst is given
+const DefaultTokenBucketBurst = 2
+
+// tokenEpsilon absorbs float rounding so a bucket refilled to 0.9999999 counts as full
+const tokenEpsilon = 1e-6
+
+// SetMode switches the limiting algorithm. burst is the token bucket's
+// capacity (<= 0 = DefaultTokenBucketBurst); it is ignored in window mode
+func (rl *RateLimiter) SetMode(mode LimiterMode, burst int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if mode != LimiterTokenBucket {
+		mode = LimiterWindow
+	}
+	if burst <= 0 {
+		burst = DefaultTokenBucketBurst
+	}
+	rl.mode = mode
+	rl.bucketSize = burst
+}
+
+// Mode returns the limiting algorithm in use
+func (rl *RateLimiter) Mode() LimiterMode {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return rl.mode
+}
+
+// refillRate is how many tokens an action type gains per second
+func refillRate(cfg *RateLimitConfig) float64 {
+	return float64(cfg.DailyLimit) / (24 * time.Hour).Seconds()
+}
+
+// tokensAt returns the bucket level for action at now (callers hold rl.mu)
+// A bucket that was never used starts full
+func (rl *RateLimiter) tokensAt(action ActionType, cfg *RateLimitConfig, now time.Time) float64 {
+	capacity := float64(rl.bucketSize)
+	last, seen := rl.tokensUpdated[action]
+	if !seen {
+		return capacity
+	}
+	tokens := rl.tokens[action] + now.Sub(last).Seconds()*refillRate(cfg)
+	return min(tokens, capacity)
+}
+
+// canPerformTokenBucket is CanPerform's check in token bucket mode
+func (rl *RateLimiter) canPerformTokenBucket(action ActionType, cfg *RateLimitConfig, now time.Time) (bool, string) {
+	dailyCount := rl.countActionsSince(action, now.Add(-24*time.Hour))
+	if dailyCount >= cfg.DailyLimit {
+		return false, rateLimitBlocked(action, fmt.Sprintf("daily limit reached (%d/%d)", dailyCount, cfg.DailyLimit))
+	}
+
+	if tokens := rl.tokensAt(action, cfg, now); tokens < 1-tokenEpsilon {
+		wait := rl.tokenWait(action, cfg, now)
+		return false, rateLimitBlocked(action, fmt.Sprintf("pacing (next in %v)", wait.Round(time.Second)))
+	}
+
+	if lastTime, exists := rl.lastAction[action]; exists {
+		elapsed := now.Sub(lastTime)
+		minInterval := time.Duration(cfg.MinIntervalSeconds) * time.Second
+		if elapsed < minInterval {
+			wait := minInterval - elapsed
+			return false, rateLimitBlocked(action, fmt.Sprintf("too soon (wait %v)", wait.Round(time.Second)))
+		}
+	}
+
+	return true, ""
+}
+
+// takeToken spends one token for an action recorded at now (callers hold rl.mu)
+func (rl *RateLimiter) takeToken(action ActionType, cfg *RateLimitConfig, now time.Time) {
+	rl.tokens[action] = rl.tokensAt(action, cfg, now) - 1
+	rl.tokensUpdated[action] = now
+}
+
+// tokenWait is how long until the bucket holds a whole token again
+func (rl *RateLimiter) tokenWait(action ActionType, cfg *RateLimitConfig, now time.Time) time.Duration {
+	missing := 1 - rl.tokensAt(action, cfg, now)
+	rate := refillRate(cfg)
+	if missing <= tokenEpsilon || rate <= 0 {
+		return 0
+	}
+	return time.Duration(missing / rate * float64(time.Second))
+}
@@ -1,8 +1,8 @@
 package stealth
 
 import (
+	"errors"
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -81,11 +81,18 @@ func TypeText(page *rod.Page, selector string, text string, config *TypingConfig
 	}
 
 	// Clear existing content first
-	element.MustSelectAllText().MustInput("")
+	if err := element.SelectAllText(); err != nil {
+		return fmt.Errorf("failed to select text: %w", err)
+	}
+	if err := element.Input(""); err != nil {
+		return fmt.Errorf("failed to clear element: %w", err)
+	}
 	SleepMillis(100, 200)
 
 	// Focus the element
-	element.MustFocus()
+	if err := element.Focus(); err != nil {
+		return fmt.Errorf("failed to focus element: %w", err)
+	}
 	SleepMillis(50, 100)
 
 	// Type each character with human-like delays
@@ -94,7 +101,9 @@ func TypeText(page *rod.Page, selector string, text string, config *TypingConfig
 		delay := calculateKeystrokeDelay(char, config, i, len(text))
 
 		// Type the character
-		element.MustInput(string(char))
+		if err := element.Input(string(char)); err != nil {
+			return fmt.Errorf("failed to type character %d: %w", i, err)
+		}
 
 		// Wait before next character
 		time.Sleep(delay)
@@ -103,6 +112,53 @@ func TypeText(page *rod.Page, selector string, text string, config *TypingConfig
 	return nil
 }
 
+// ErrTypingTargetLost is returned when the element being typed into is removed
+// from the page mid-typing (e.g. the composer re-rendered), so the rest of the
+// text would land nowhere
+var ErrTypingTargetLost = errors.New("typing target removed from the page")
+
+// activeTypingTarget resolves the currently focused element, which the
+// typing helpers keep typing into even if the page steals focus
+func activeTypingTarget(page *rod.Page) (*rod.Element, error) {
+	target, err := page.ElementByJS(rod.Eval(`() => document.activeElement`))
+	if err != nil {
+		return nil, fmt.Errorf("no focused element to type into: %w", err)
+	}
+	return target, nil
+}
+
+// ensureFocused re-focuses target if something else took focus since the last
+// keystroke (tooltips, composer re-renders), moving the caret back to the end
+// of its content. Reports whether focus had to be restored
+func ensureFocused(target *rod.Element) (bool, error) {
+	res, err := target.Eval(`function() {
+		if (!this.isConnected) return 'detached';
+		if (document.activeElement === this) return 'focused';
+
+		this.focus();
+		if (this.isContentEditable) {
+			const range = document.createRange();
+			range.selectNodeContents(this);
+			range.collapse(false);
+			const selection = window.getSelection();
+			selection.removeAllRanges();
+			selection.addRange(range);
+		}
+		return 'refocused';
+	}`)
+	if err != nil {
+		return false, fmt.Errorf("failed to check focus: %w", err)
+	}
+
+	switch res.Value.Str() {
+	case "detached":
+		return false, ErrTypingTargetLost
+	case "refocused":
+		return true, nil
+	}
+	return false, nil
+}
+
 // TypeTextIntoActiveElement types into the currently focused element
 // Use this when the element is already focused
 func TypeTextIntoActiveElement(page *rod.Page, text string, config *TypingConfig) error {
@@ -110,20 +166,45 @@ func TypeTextIntoActiveElement(page *rod.Page, text string, config *TypingConfig
 		config = DefaultTypingConfig()
 	}
 
+	target, err := activeTypingTarget(page)
+	if err != nil {
+		return err
+	}
+
+	refocused := 0
 	for i, char := range text {
 		// Calculate delay for this character
 		delay := calculateKeystrokeDelay(char, config, i, len(text))
 
+		// InsertText goes to whatever has focus, so make sure that's still us
+		restored, err := ensureFocused(target)
+		if err != nil {
+			return fmt.Errorf("failed to type character %d: %w", i, err)
+		}
+		if restored {
+			refocused++
+		}
+
 		// Type using page.InsertText which simulates typing
-		page.InsertText(string(char))
+		if err := page.InsertText(string(char)); err != nil {
+			return fmt.Errorf("failed to type character %d: %w", i, err)
+		}
 
 		// Wait before next character
 		time.Sleep(delay)
 	}
 
+	logRefocused(refocused)
 	return nil
 }
 
+// logRefocused notes how often typing had to win focus back
+func logRefocused(count int) {
+	if count > 0 {
+		fmt.Printf("   🎯 Input lost focus while typing - re-focused %d time(s)\n", count)
+	}
+}
+
 // TypeTextWithElement types into a rod.Element with human-like timing
 func TypeTextWithElement(element *rod.Element, text string, config *TypingConfig) error {
 	if config == nil {
@@ -131,11 +212,15 @@ func TypeTextWithElement(element *rod.Element, text string, config *TypingConfig
 	}
 
 	// Clear existing content
-	element.MustSelectAllText()
+	if err := element.SelectAllText(); err != nil {
+		return fmt.Errorf("failed to select text: %w", err)
+	}
 	SleepMillis(50, 100)
 
 	// Focus the element
-	element.MustFocus()
+	if err := element.Focus(); err != nil {
+		return fmt.Errorf("failed to focus element: %w", err)
+	}
 	SleepMillis(50, 100)
 
 	// Type each character
@@ -143,7 +228,9 @@ func TypeTextWithElement(element *rod.Element, text string, config *TypingConfig
 		delay := calculateKeystrokeDelay(char, config, i, len(text))
 
 		// Input single character
-		element.MustInput(string(char))
+		if err := element.Input(string(char)); err != nil {
+			return fmt.Errorf("failed to type character %d: %w", i, err)
+		}
 
 		time.Sleep(delay)
 	}
@@ -188,7 +275,7 @@ func calculateKeystrokeDelay(char rune, config *TypingConfig, position, totalLen
 	}
 
 	// Add random variation
-	variation := rand.Intn(config.VariationMs*2) - config.VariationMs
+	variation := rng.Intn(config.VariationMs*2) - config.VariationMs
 	delay := baseDelay + variation
 
 	// Ensure minimum delay
@@ -197,8 +284,8 @@ func calculateKeystrokeDelay(char rune, config *TypingConfig, position, totalLen
 	}
 
 	// Random thinking pause
-	if rand.Intn(100) < config.ThinkPauseProbability {
-		thinkPause := rand.Intn(config.ThinkPauseMaxMs-config.ThinkPauseMinMs) + config.ThinkPauseMinMs
+	if rng.Intn(100) < config.ThinkPauseProbability {
+		thinkPause := rng.Intn(config.ThinkPauseMaxMs-config.ThinkPauseMinMs) + config.ThinkPauseMinMs
 		delay += thinkPause
 	}
 
@@ -240,56 +327,81 @@ func SimulateTypingDelay(textLength int, config *TypingConfig) {
 
 	// Add some variance
 	variance := totalDelay / 5 // ±20%
-	totalDelay += rand.Intn(variance*2) - variance
+	totalDelay += rng.Intn(variance*2) - variance
 
 	time.Sleep(time.Duration(totalDelay) * time.Millisecond)
 }
 
 // TypeTextJS types text using JavaScript-simulated keyboard events
 // This is an alternative approach that may work better with some input fields
+//
+// The element focused when typing starts stays the target: if LinkedIn steals
+// focus mid-message it is re-focused before the next character instead of the
+// remaining text being dispatched to whatever took focus
 func TypeTextJS(page *rod.Page, text string, config *TypingConfig) error {
 	if config == nil {
 		config = DefaultTypingConfig()
 	}
 
+	target, err := activeTypingTarget(page)
+	if err != nil {
+		return err
+	}
+
+	refocused := 0
 	for i, char := range text {
 		delay := calculateKeystrokeDelay(char, config, i, len(text))
 
-		// Simulate keydown, keypress, input, keyup events
-		page.MustEval(`(char) => {
-			const activeElement = document.activeElement;
-			if (!activeElement) return;
+		restored, err := ensureFocused(target)
+		if err != nil {
+			return fmt.Errorf("failed to type character %d: %w", i, err)
+		}
+		if restored {
+			refocused++
+		}
 
-			const keyCode = char.charCodeAt(0);
+		// Simulate keydown, keypress, input, keyup events
+		_, err = target.Eval(`function(char) {
+			const activeElement = this;
+
+			// Newlines are typed as Shift+Enter so chat inputs that
+			// send on Enter don't fire mid-message
+			const isNewline = char === '\n';
+			const key = isNewline ? 'Enter' : char;
+			const code = isNewline ? 'Enter' : 'Key' + char.toUpperCase();
+			const keyCode = isNewline ? 13 : char.charCodeAt(0);
 			
 			// Create and dispatch keyboard events
 			const keydownEvent = new KeyboardEvent('keydown', {
-				key: char,
-				code: 'Key' + char.toUpperCase(),
+				key: key,
+				code: code,
 				keyCode: keyCode,
 				which: keyCode,
+				shiftKey: isNewline,
 				bubbles: true
 			});
 			
 			const keypressEvent = new KeyboardEvent('keypress', {
-				key: char,
-				code: 'Key' + char.toUpperCase(),
+				key: key,
+				code: code,
 				keyCode: keyCode,
 				which: keyCode,
+				shiftKey: isNewline,
 				bubbles: true
 			});
 
 			const inputEvent = new InputEvent('input', {
-				data: char,
-				inputType: 'insertText',
+				data: isNewline ? null : char,
+				inputType: isNewline ? 'insertLineBreak' : 'insertText',
 				bubbles: true
 			});
 
 			const keyupEvent = new KeyboardEvent('keyup', {
-				key: char,
-				code: 'Key' + char.toUpperCase(),
+				key: key,
+				code: code,
 				keyCode: keyCode,
 				which: keyCode,
+				shiftKey: isNewline,
 				bubbles: true
 			});
 
@@ -300,15 +412,23 @@ func TypeTextJS(page *rod.Page, text string, config *TypingConfig) error {
 			if (activeElement.tagName === 'INPUT' || activeElement.tagName === 'TEXTAREA') {
 				activeElement.value += char;
 			} else if (activeElement.isContentEditable) {
-				document.execCommand('insertText', false, char);
+				if (isNewline) {
+					document.execCommand('insertLineBreak');
+				} else {
+					document.execCommand('insertText', false, char);
+				}
 			}
 			
 			activeElement.dispatchEvent(inputEvent);
 			activeElement.dispatchEvent(keyupEvent);
 		}`, string(char))
+		if err != nil {
+			return fmt.Errorf("failed to type character %d: %w", i, err)
+		}
 
 		time.Sleep(delay)
 	}
 
+	logRefocused(refocused)
 	return nil
 }
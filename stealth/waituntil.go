@@ -0,0 +1,48 @@
+package stealth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WaitUntil blocks until t, printing a countdown, or until ctx is cancelled
+// (e.g. SIGINT). Returns ctx.Err() when interrupted, nil once t is reached.
+func WaitUntil(ctx context.Context, t time.Time) error {
+	remaining := time.Until(t)
+	if remaining <= 0 {
+		return nil
+	}
+
+	fmt.Printf("🕰️ Waiting until %s to start (%v from now)\n", t.Format("Mon 2006-01-02 15:04"), remaining.Round(time.Second))
+
+	for {
+		remaining = time.Until(t)
+		if remaining <= 0 {
+			fmt.Println("⏰ Start time reached")
+			return nil
+		}
+
+		// Report often near the end, sparsely before that
+		step := 5 * time.Minute
+		if remaining <= 10*time.Minute {
+			step = time.Minute
+		}
+		if remaining < step {
+			step = remaining
+		}
+
+		timer := time.NewTimer(step)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			fmt.Println("🛑 Wait interrupted")
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if left := time.Until(t); left > 0 {
+			fmt.Printf("   ⏳ %v until start\n", left.Round(time.Second))
+		}
+	}
+}
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
 
 	"github.com/Nehilsa2/linkedin_automation/connect"
+	"github.com/Nehilsa2/linkedin_automation/linkedinurl"
 	"github.com/Nehilsa2/linkedin_automation/message"
 	"github.com/Nehilsa2/linkedin_automation/persistence"
 	"github.com/Nehilsa2/linkedin_automation/search"
@@ -29,44 +33,105 @@ func RunSearch(browser *rod.Browser) ([]string, []string) {
 			"keyword_people":    SearchKeywordPeople,
 			"keyword_companies": SearchKeywordCompanies,
 			"max_pages":         SearchMaxPages,
+			"max_results":       MaxResultsPerKeyword,
 		},
 	}
 
 	// Check for existing active workflow
+	// An in_progress workflow means the previous run died mid-crawl
+	resuming := false
 	existing, _ := store.GetActiveWorkflow(persistence.WorkflowTypeSearch)
-	if existing != nil && existing.Status == persistence.WorkflowStatusPaused {
-		fmt.Printf("📌 Resuming previous search workflow from page %d\n", existing.CurrentIndex)
+	if existing != nil {
+		fmt.Printf("📌 Resuming previous search workflow (%s)\n", existing.CurrentStep)
 		workflowState = existing
 		workflowState.Status = persistence.WorkflowStatusInProgress
+		if workflowState.Metadata == nil {
+			workflowState.Metadata = map[string]interface{}{}
+		}
+		resuming = true
 	}
 
 	store.SaveWorkflowState(workflowState)
 
-	// Search for people
-	fmt.Printf("\n👤 Searching for people: %s\n", SearchKeywordPeople)
-	people, err := search.FindPeople(browser, SearchKeywordPeople, SearchMaxPages)
-	if len(people) > 0 {
-		fmt.Printf("✅ Found %d profiles\n", len(people))
-		savePeopleResultsToDB(people, SearchKeywordPeople)
+	// Search for people (saved search URL takes precedence over keyword)
+	var people []string
+	var pagination *search.PaginationState
+	var err error
+	if SavedSearchURL != "" {
+		fmt.Printf("\n👤 Searching for people via saved search: %s\n", SavedSearchURL)
+		var cardStates map[string]string
+		people, cardStates, err = search.FindFromSearchURL(browser, SavedSearchURL, SearchMaxPages, MaxResultsPerKeyword)
+		if len(people) > 0 {
+			fmt.Printf("✅ Found %d profiles\n", len(people))
+			savePeopleResultsToDB(people, SearchKeywordPeople, cardStates)
+		}
+	} else if workflowState.CurrentStep == "searching_people" {
+		fmt.Printf("\n👤 Searching for people: %s\n", SearchKeywordPeople)
+
+		state := loadPaginationState(workflowState, SearchKeywordPeople, resuming)
+		state.MaxPages = SearchMaxPages
+		state.MaxResults = MaxResultsPerKeyword
+		if SkipKnownSearchPages {
+			state.Known, state.KnownThrough = knownPeopleResults(SearchKeywordPeople)
+		}
+		state.OnPage = func(ps *search.PaginationState, pageLinks []string) {
+			// Persist each page as soon as it is crawled so a crash loses at most one page
+			savePeopleResultsPageToDB(pageLinks, SearchKeywordPeople, ps.NextPage-1, ps.CardStates, ps.CardDetails)
+			workflowState.Metadata["pagination"] = ps
+			workflowState.CurrentIndex = ps.NextPage - 1
+			store.SaveWorkflowState(workflowState)
+		}
+
+		state.OnResults = searchCardConnector(SearchKeywordPeople)
+
+		people, err = search.FindPeopleResumable(browser, SearchKeywordPeople, state)
+		if len(people) > 0 {
+			fmt.Printf("✅ Found %d profiles\n", len(people))
+		}
+		pagination = state
 	}
 	if err != nil {
 		log.Printf("⚠️ People search error: %v\n", err)
 	}
 
+	// Selector drift: keep the workflow paused on the stored page so a build
+	// with fixed selectors re-crawls it instead of the page being lost
+	if errors.Is(err, search.ErrSelectorMismatch) {
+		fmt.Println("⏸️ Search paused - check the result selectors (see DumpSearchHTMLOnMismatch)")
+		store.PauseWorkflow(workflowState.ID)
+		return people, nil
+	}
+
+	// Monthly search limit: keep the workflow paused so next month picks up
+	// at the stored page instead of re-crawling from page 1
+	if pagination != nil && pagination.LimitActive() {
+		fmt.Printf("⏸️ Search paused at page %d until the monthly search limit resets\n", pagination.NextPage)
+		store.PauseWorkflow(workflowState.ID)
+		return people, nil
+	}
+
+	// Commercial-use throttle: same idea, but only until the cooldown ends
+	if pagination != nil && pagination.Throttled() {
+		fmt.Printf("⏸️ Search paused at page %d until %s (commercial use limit cooldown)\n",
+			pagination.NextPage, pagination.ThrottledUntil.Format("2006-01-02 15:04"))
+		store.PauseWorkflow(workflowState.ID)
+		return people, nil
+	}
+
 	workflowState.CurrentStep = "searching_companies"
 	workflowState.CurrentIndex = SearchMaxPages
 	store.SaveWorkflowState(workflowState)
 
 	// Search for companies
 	fmt.Printf("\n🏢 Searching for companies: %s\n", SearchKeywordCompanies)
-	companies, err := search.FindCompanies(browser, SearchKeywordCompanies, SearchMaxPages)
+	companies, companyCards, err := search.FindCompanies(browser, SearchKeywordCompanies, SearchMaxPages)
 	if err != nil {
 		log.Printf("⚠️ Company search error: %v\n", err)
 	} else {
 		fmt.Printf("✅ Found %d companies\n", len(companies))
 
 		// Save company search results
-		saveCompanyResultsToDB(companies, SearchKeywordCompanies)
+		saveCompanyResultsToDB(companies, companyCards, SearchKeywordCompanies)
 	}
 
 	// Mark workflow as complete
@@ -75,22 +140,535 @@ func RunSearch(browser *rod.Browser) ([]string, []string) {
 	return people, companies
 }
 
+// searchCardConnector returns an OnResults callback that sends invites from
+// the result cards of each crawled page (see SearchCardConnect), or nil when
+// card invites are off
+func searchCardConnector(keyword string) func(page *rod.Page, pageLinks []string) {
+	if !SearchCardConnect {
+		return nil
+	}
+	if RequireConnectNote {
+		fmt.Println("ℹ️ Card invites can't carry a note and RequireConnectNote is set - not sending from search cards")
+		return nil
+	}
+
+	tracker, err := connect.LoadTracker()
+	if err != nil {
+		log.Printf("⚠️ Failed to load tracker: %v\n", err)
+		return nil
+	}
+	tracker.SetDryRun(DryRunMode)
+	tracker.SetValidateDryRun(DryRunValidate)
+	tracker.SetDailyLimit(stealth.GetConnectionDailyLimit())
+	tracker.SetEmailLookup(leadEmail)
+
+	rateLimiter := stealth.GetRateLimiter()
+	stopped := false
+	return func(page *rod.Page, pageLinks []string) {
+		info, err := page.Info()
+		if err != nil {
+			return
+		}
+		resultsURL := info.URL
+
+		for _, targetURL := range pageLinks {
+			if stopped {
+				return
+			}
+			if !tracker.CanSendMore() {
+				fmt.Println("📊 Daily connection limit reached - no more card invites")
+				stopped = true
+				return
+			}
+			if can, reason := rateLimiter.CanPerform(stealth.ActionConnection); !can {
+				fmt.Printf("⏸️ Rate limited: %s\n", reason)
+				return
+			}
+			if pendingCeilingReached(page, tracker) {
+				fmt.Println("📬 Pending invite ceiling reached - no more card invites")
+				stopped = true
+				return
+			}
+			if done, _ := store.HasSentRequest(targetURL); done {
+				continue
+			}
+			if skipCardState(targetURL) || skipBlocked(targetURL) || skipCompanyQuota(targetURL, "") {
+				continue
+			}
+			if skipProfileFilter(page, targetURL) {
+				continue
+			}
+			// The profile filters open the profile; the card is on the results page
+			if err := returnToResults(page, resultsURL); err != nil {
+				fmt.Printf("⚠️ %v - no more card invites on this page\n", err)
+				return
+			}
+
+			connectErr := connect.ConnectFromSearchCard(page, targetURL, "", tracker)
+			if connectErr == nil {
+				rateLimiter.RecordAction(stealth.ActionConnection)
+				saveConnectionRequestFromSource(targetURL, "", false, "search", keyword)
+			} else {
+				fmt.Printf("❌ Card connect failed for %s: %v\n", targetURL, connectErr)
+				if stealth.IsCritical(connectErr) {
+					fmt.Println("🛑 Critical error detected - no more card invites")
+					stopped = true
+					return
+				}
+			}
+
+			// A card without an inline Connect falls back to the profile page
+			if err := returnToResults(page, resultsURL); err != nil {
+				fmt.Printf("⚠️ %v - no more card invites on this page\n", err)
+				return
+			}
+			if connectErr != nil {
+				continue
+			}
+
+			delay := stealth.GetRandomDelay(stealth.ActionConnection)
+			fmt.Printf("⏳ Waiting %v before the next card...\n", delay.Round(time.Second))
+			if err := stealth.WatchedSleep(page, delay); err != nil {
+				fmt.Println("🛑 Critical warning during the wait - no more card invites")
+				stopped = true
+				return
+			}
+		}
+	}
+}
+
+// returnToResults brings the tab back to the search results page at
+// resultsURL if it navigated away
+func returnToResults(page *rod.Page, resultsURL string) error {
+	if info, err := page.Info(); err == nil && info.URL == resultsURL {
+		return nil
+	}
+	if err := page.Navigate(resultsURL); err != nil {
+		return fmt.Errorf("failed to return to the results page: %w", err)
+	}
+	page.WaitLoad()
+	stealth.Sleep(2, 4)
+	return nil
+}
+
+// skipBlocked reports whether a target is on the blocklist, marking it processed if so
+func skipBlocked(profileURL string) bool {
+	if err := stealth.CheckBlocked(profileURL, ""); err == nil {
+		return false
+	}
+	store.MarkPersonProcessed(profileURL)
+	return true
+}
+
+// skipCardState reports whether the target's search card already showed
+// Pending or Message, marking it processed so no navigation is spent on it
+func skipCardState(profileURL string) bool {
+	result, err := store.GetPersonResult(profileURL)
+	if err != nil || result == nil {
+		return false
+	}
+
+	switch result.ConnectionState {
+	case search.CardStatePending:
+		fmt.Printf("⏭️ Skipping %s (search card shows an invite pending)\n", profileURL)
+	case search.CardStateConnected:
+		fmt.Printf("⏭️ Skipping %s (search card shows already connected)\n", profileURL)
+	default:
+		return false
+	}
+	store.MarkPersonProcessed(profileURL)
+	return true
+}
+
+// leadEmail returns the stored email for a lead, for members who only
+// accept invites from people who know it
+func leadEmail(profileURL string) string {
+	if person, _ := store.GetPersonResult(profileURL); person != nil {
+		return person.Email
+	}
+	return ""
+}
+
+// skipProfileFilter reports whether the target's profile fails the photo /
+// Open to Work filters, marking it processed if so. Opens the profile first
+// if the page isn't already on it; an unreadable profile is not skipped
+func skipProfileFilter(page *rod.Page, profileURL string) bool {
+	filter := connect.ProfileFilter{Photo: ConnectPhotoFilter, OpenToWork: ConnectOpenToWorkFilter}
+	if !filter.Active() {
+		return false
+	}
+
+	if info, err := page.Info(); err != nil || linkedinurl.Canonicalize(info.URL) != linkedinurl.Canonicalize(profileURL) {
+		if err := connect.NavigateToProfile(page, profileURL); err != nil {
+			return false
+		}
+	}
+
+	traits, err := connect.ReadProfileTraits(page)
+	if err != nil {
+		fmt.Printf("   ⚠️ Could not check profile filters: %v (connecting anyway)\n", err)
+		return false
+	}
+	reason := filter.Check(traits)
+	if reason == "" {
+		return false
+	}
+
+	fmt.Printf("⏭️ Skipping %s (profile %s)\n", profileURL, reason)
+	store.MarkPersonProcessed(profileURL)
+	return true
+}
+
+// skipCompanyQuota reports whether the target's company already received
+// MaxRequestsPerCompanyPerWeek requests this week, marking it processed if so
+// company may be empty, in which case the company recorded for the profile is used
+func skipCompanyQuota(profileURL, company string) bool {
+	if MaxRequestsPerCompanyPerWeek <= 0 {
+		return false
+	}
+	if company == "" {
+		company, _ = store.CompanyForProfile(profileURL)
+	}
+	if company == "" {
+		return false
+	}
+	sent, err := store.CountRequestsToCompany(company, time.Now().AddDate(0, 0, -7))
+	if err != nil || sent < MaxRequestsPerCompanyPerWeek {
+		return false
+	}
+
+	fmt.Printf("⏭️ Skipping %s (%d requests to %s this week)\n", profileURL, sent, company)
+	store.MarkPersonProcessed(profileURL)
+	return true
+}
+
+// targetCompany returns the target's company for the per-company policies
+// When none is recorded yet the profile is opened and its top card read
+// (and stored on the lead), so the quota and pacing see every target
+func targetCompany(page *rod.Page, profileURL string) string {
+	if company, _ := store.CompanyForProfile(profileURL); company != "" {
+		return company
+	}
+	if MaxRequestsPerCompanyPerWeek <= 0 && SameCompanyStreak <= 0 {
+		return ""
+	}
+
+	if info, err := page.Info(); err != nil || linkedinurl.Canonicalize(info.URL) != linkedinurl.Canonicalize(profileURL) {
+		if err := connect.NavigateToProfile(page, profileURL); err != nil {
+			return ""
+		}
+	}
+	return recordProfileCard(page, profileURL).Company
+}
+
+// recordProfileCard reads the top card of the profile the page is on and
+// stores its details on the lead
+func recordProfileCard(page *rod.Page, profileURL string) connect.ProfileCard {
+	card, err := connect.ReadProfileCard(page)
+	if err != nil {
+		fmt.Printf("   ⚠️ Could not read the profile card: %v\n", err)
+		return card
+	}
+	if err := store.UpdatePersonDetails(profileURL, card.Name, card.Headline, card.Company, card.Location); err != nil {
+		fmt.Printf("   ⚠️ %v\n", err)
+	}
+	return card
+}
+
+// interleaveByCompany reorders targets round-robin across their companies so
+// consecutive invites go to different companies where possible. Targets of
+// unknown company are treated as a company of their own
+func interleaveByCompany(profileURLs []string) []string {
+	if SameCompanyStreak <= 0 {
+		return profileURLs
+	}
+
+	var order []string
+	groups := make(map[string][]string)
+	for _, url := range profileURLs {
+		key, _ := store.CompanyForProfile(url)
+		if key == "" {
+			key = url
+		}
+		key = strings.ToLower(key)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], url)
+	}
+
+	interleaved := make([]string, 0, len(profileURLs))
+	for len(interleaved) < len(profileURLs) {
+		for _, key := range order {
+			if len(groups[key]) > 0 {
+				interleaved = append(interleaved, groups[key][0])
+				groups[key] = groups[key][1:]
+			}
+		}
+	}
+	return interleaved
+}
+
+// ProfileViewerPolicy values
+const (
+	ViewerPolicyPrioritize = "prioritize"
+	ViewerPolicySkip       = "skip"
+)
+
+// orderTargets applies ProfileViewerPolicy to the connect targets and spreads
+// each group across companies. With "prioritize", unprocessed leads from the
+// viewers workflow join the list and recent viewers go first
+func orderTargets(profileURLs []string) []string {
+	if ProfileViewerPolicy != ViewerPolicyPrioritize && ProfileViewerPolicy != ViewerPolicySkip {
+		return interleaveByCompany(profileURLs)
+	}
+
+	if ProfileViewerPolicy == ViewerPolicyPrioritize {
+		leads, err := store.GetUnprocessedSearchResults(search.ProfileViewersSource, 0, ProfileViewerWindowDays)
+		if err != nil {
+			fmt.Printf("⚠️ Could not load profile viewer leads: %v\n", err)
+		}
+		seen := make(map[string]bool, len(profileURLs))
+		for _, url := range profileURLs {
+			seen[url] = true
+		}
+		for _, r := range leads {
+			if seen[r.ProfileURL] {
+				continue
+			}
+			if sent, _ := store.HasSentRequest(r.ProfileURL); sent {
+				continue
+			}
+			seen[r.ProfileURL] = true
+			profileURLs = append(profileURLs, r.ProfileURL)
+		}
+	}
+
+	var viewers, others []string
+	for _, url := range profileURLs {
+		if recentProfileViewer(url) {
+			viewers = append(viewers, url)
+		} else {
+			others = append(others, url)
+		}
+	}
+
+	if ProfileViewerPolicy == ViewerPolicySkip {
+		if len(viewers) > 0 {
+			fmt.Printf("👀 Skipping %d profiles that recently viewed ours\n", len(viewers))
+		}
+		return interleaveByCompany(others)
+	}
+	if len(viewers) > 0 {
+		fmt.Printf("👀 Prioritizing %d profiles that recently viewed ours\n", len(viewers))
+	}
+	return append(interleaveByCompany(viewers), interleaveByCompany(others)...)
+}
+
+// recentProfileViewer reports whether the member viewed our profile in the
+// last ProfileViewerWindowDays days
+func recentProfileViewer(profileURL string) bool {
+	within := time.Duration(ProfileViewerWindowDays) * 24 * time.Hour
+	viewed, err := store.IsRecentProfileViewer(profileURL, within)
+	return err == nil && viewed
+}
+
+// companyPacer tracks the company the connect loop is working through and
+// how many invites in a row went to it
+type companyPacer struct {
+	company string
+	streak  int
+}
+
+// cooldown returns how long to wait before inviting someone at company
+// (zero unless SameCompanyStreak invites in a row already went there)
+func (p *companyPacer) cooldown(company string) time.Duration {
+	if SameCompanyStreak <= 0 || SameCompanyCooldownMins <= 0 || company == "" {
+		return 0
+	}
+	if !strings.EqualFold(company, p.company) || p.streak < SameCompanyStreak {
+		return 0
+	}
+	return time.Duration(SameCompanyCooldownMins) * time.Minute
+}
+
+// sent records an invite to company, extending or restarting the streak
+func (p *companyPacer) sent(company string) {
+	if company != "" && strings.EqualFold(company, p.company) {
+		p.streak++
+		return
+	}
+	p.company, p.streak = company, 1
+}
+
+// reset starts a fresh streak after a cooldown
+func (p *companyPacer) reset() {
+	p.streak = 0
+}
+
+// adaptiveDelay returns a time-of-day aware delay, using the workflow's
+// scheduler when one is running so delays follow the same work hours
+func adaptiveDelay(scheduler *stealth.Scheduler, action stealth.ActionType) time.Duration {
+	if scheduler != nil {
+		return scheduler.AdaptiveDelay(action)
+	}
+	return stealth.GetAdaptiveDelay(action)
+}
+
+// phaseIdleSeconds is the min/max idle between switching from connecting to
+// messaging (or back), per safety level
+var phaseIdleSeconds = map[stealth.SafetyLevel][2]int{
+	stealth.SafetyUltraConservative: {240, 480},
+	stealth.SafetyConservative:      {120, 300},
+	stealth.SafetyModerate:          {60, 180},
+	stealth.SafetyAggressive:        {30, 90},
+}
+
+// idleBetweenPhases bridges two differently shaped bursts of activity the
+// way a person switching tasks would: a pause, a look at the feed, another
+// pause. Returns an error if a critical warning appears while waiting
+func idleBetweenPhases(page *rod.Page, browser *stealth.OrganicBrowser) error {
+	bounds, ok := phaseIdleSeconds[stealth.GetConfig().SafetyLevel]
+	if !ok {
+		bounds = phaseIdleSeconds[stealth.SafetyConservative]
+	}
+	idle := stealth.RandomSeconds(bounds[0], bounds[1])
+
+	fmt.Printf("\n🧘 Switching tasks - idling about %v first...\n", idle.Round(time.Second))
+	if err := stealth.WatchedSleep(page, idle/2); err != nil {
+		return err
+	}
+	if err := browser.BrowseFeed(); err != nil {
+		fmt.Printf("   ⚠️ Feed browse failed: %v (continuing)\n", err)
+	}
+	return stealth.WatchedSleep(page, idle/2)
+}
+
+// loadPaginationState restores the people-search crawl state from workflow metadata
+// Falls back to the highest page already stored for the keyword when resuming
+// a workflow that predates pagination metadata
+func loadPaginationState(ws *persistence.WorkflowState, keyword string, resuming bool) *search.PaginationState {
+	state := search.NewPaginationState(keyword, SearchMaxPages)
+
+	if raw, ok := ws.Metadata["pagination"]; ok {
+		// Metadata round-trips through JSON, so the value is a generic map here
+		if data, err := json.Marshal(raw); err == nil {
+			var saved search.PaginationState
+			if json.Unmarshal(data, &saved) == nil && saved.Keyword == keyword {
+				return &saved
+			}
+		}
+	}
+
+	if resuming {
+		if lastPage, err := store.GetPeopleSearchProgress(keyword); err == nil && lastPage > 0 {
+			state.NextPage = lastPage + 1
+		}
+	}
+
+	return state
+}
+
+// knownPeopleResults returns the profiles already stored for a keyword and
+// the highest page they were found on
+func knownPeopleResults(keyword string) (map[string]bool, int) {
+	results, err := store.GetPeopleByKeyword(keyword)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to load stored results for %q: %v\n", keyword, err)
+		return nil, 0
+	}
+
+	known := make(map[string]bool, len(results))
+	through := 0
+	for _, r := range results {
+		known[r.ProfileURL] = true
+		through = max(through, r.PageNumber)
+	}
+	return known, through
+}
+
+// savePeopleResultsPageToDB saves one crawled page of people results with its
+// real page number and what each card shows (connection state, name, headline,
+// company), so the per-company policies know a lead's company before visiting it
+func savePeopleResultsPageToDB(urls []string, keyword string, pageNum int, cardStates map[string]string, cards map[string]search.PeopleCard) {
+	results := make([]persistence.PersonSearchResult, 0, len(urls))
+
+	for _, url := range urls {
+		card := cards[url]
+		company := cardCompany(card)
+
+		exists, _ := store.HasPersonResult(url)
+		if exists {
+			// Fill in what the card shows on leads stored before
+			store.UpdatePersonDetails(url, card.Name, card.Headline, company, card.Location)
+			updateCardState(url, cardStates)
+			continue
+		}
+
+		results = append(results, persistence.PersonSearchResult{
+			ProfileURL:      url,
+			Name:            card.Name,
+			Headline:        card.Headline,
+			Company:         company,
+			Location:        card.Location,
+			SearchKeyword:   keyword,
+			PageNumber:      pageNum,
+			DiscoveredAt:    time.Now(),
+			ConnectionState: cardStates[url],
+		})
+	}
+
+	if len(results) > 0 {
+		if err := store.SavePersonSearchResults(results); err != nil {
+			fmt.Printf("⚠️ Failed to save people search results: %v\n", err)
+		} else {
+			fmt.Printf("💾 Saved %d new people profiles from page %d\n", len(results), pageNum)
+		}
+	}
+}
+
+// cardCompany returns the current company a people result card names,
+// from its "Current: ..." line or else its headline
+func cardCompany(card search.PeopleCard) string {
+	if current, ok := strings.CutPrefix(card.Summary, "Current:"); ok {
+		if company := connect.CompanyFromHeadline(current); company != "" {
+			return company
+		}
+	}
+	return connect.CompanyFromHeadline(card.Headline)
+}
+
+// updateCardState records the connection state a re-crawled card shows on a
+// lead stored before (left alone when the card's button couldn't be read)
+func updateCardState(profileURL string, cardStates map[string]string) {
+	state, ok := cardStates[profileURL]
+	if !ok {
+		return
+	}
+	if err := store.SetConnectionState(profileURL, state); err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+	}
+}
+
 // savePeopleResultsToDB saves people search results to the database
-func savePeopleResultsToDB(urls []string, keyword string) {
+// with the connection state shown on each card
+func savePeopleResultsToDB(urls []string, keyword string, cardStates map[string]string) {
 	results := make([]persistence.PersonSearchResult, 0, len(urls))
 
 	for i, url := range urls {
 		// Check if already exists
 		exists, _ := store.HasPersonResult(url)
 		if exists {
+			updateCardState(url, cardStates)
 			continue
 		}
 
 		results = append(results, persistence.PersonSearchResult{
-			ProfileURL:    url,
-			SearchKeyword: keyword,
-			PageNumber:    (i / 10) + 1, // Estimate page number
-			DiscoveredAt:  time.Now(),
+			ProfileURL:      url,
+			SearchKeyword:   keyword,
+			PageNumber:      (i / 10) + 1, // Estimate page number
+			DiscoveredAt:    time.Now(),
+			ConnectionState: cardStates[url],
 		})
 	}
 
@@ -104,18 +682,28 @@ func savePeopleResultsToDB(urls []string, keyword string) {
 }
 
 // saveCompanyResultsToDB saves company search results to the database
-func saveCompanyResultsToDB(urls []string, keyword string) {
+// Companies seen before get the name, industry and location from their card
+// filled in, so notes can mention them
+func saveCompanyResultsToDB(urls []string, cards map[string]search.CompanyCard, keyword string) {
 	results := make([]persistence.CompanySearchResult, 0, len(urls))
+	newCount := 0
 
 	for i, url := range urls {
+		card := cards[url]
 		// Check if already exists
 		exists, _ := store.HasCompanyResult(url)
-		if exists {
+		if exists && card == (search.CompanyCard{}) {
 			continue
 		}
+		if !exists {
+			newCount++
+		}
 
 		results = append(results, persistence.CompanySearchResult{
 			CompanyURL:    url,
+			Name:          card.Name,
+			Industry:      card.Industry,
+			Location:      card.Location,
 			SearchKeyword: keyword,
 			PageNumber:    (i / 10) + 1, // Estimate page number
 			DiscoveredAt:  time.Now(),
@@ -126,18 +714,26 @@ func saveCompanyResultsToDB(urls []string, keyword string) {
 		if err := store.SaveCompanySearchResults(results); err != nil {
 			fmt.Printf("⚠️ Failed to save company search results: %v\n", err)
 		} else {
-			fmt.Printf("💾 Saved %d new companies to database\n", len(results))
+			fmt.Printf("💾 Saved %d new companies to database\n", newCount)
 		}
 	}
 }
 
 // RunConnections sends connection requests to found profiles with organic browsing
 // Flow: Browse random profile -> Feed -> Quick view target -> Connect
-func RunConnections(page *rod.Page, profileURLs []string) {
+func RunConnections(profileURLs []string) {
 	fmt.Println("\n==================================================")
 	fmt.Println("🔗 CONNECTION WORKFLOW (with organic browsing)")
 	fmt.Println("==================================================")
 
+	// Borrow the shared page (usually the feed tab) for all browsing
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		return
+	}
+	defer pagePool.Put(page)
+
 	// Create workflow state
 	workflowState := &persistence.WorkflowState{
 		WorkflowType: persistence.WorkflowTypeConnect,
@@ -147,16 +743,26 @@ func RunConnections(page *rod.Page, profileURLs []string) {
 	}
 
 	// Check for resumable workflow
+	// Progress is stored as an index into the original target list, so the
+	// list saved with the workflow is preferred over the one passed in
+	startIndex := 0
+	resuming := false
 	existing, _ := store.GetActiveWorkflow(persistence.WorkflowTypeConnect)
 	if existing != nil && existing.Status == persistence.WorkflowStatusPaused {
 		fmt.Printf("📌 Resuming connection workflow from index %d/%d\n",
 			existing.CurrentIndex, existing.TotalItems)
 		workflowState = existing
 		workflowState.Status = persistence.WorkflowStatusInProgress
+		resuming = true
+
+		if saved := workflowTargets(existing); len(saved) > 0 {
+			profileURLs = saved
+		}
 
 		// Adjust profileURLs to skip already processed
 		if existing.CurrentIndex < len(profileURLs) {
-			profileURLs = profileURLs[existing.CurrentIndex:]
+			startIndex = existing.CurrentIndex
+			profileURLs = profileURLs[startIndex:]
 		}
 	}
 
@@ -171,7 +777,22 @@ func RunConnections(page *rod.Page, profileURLs []string) {
 
 	// Set dry run mode and safe daily limit from central config
 	tracker.SetDryRun(DryRunMode)
+	tracker.SetValidateDryRun(DryRunValidate)
 	tracker.SetDailyLimit(1)
+	tracker.SetNoteOmissionRate(NoteOmissionRate)
+	tracker.SetRequireNote(RequireConnectNote)
+	tracker.SetEmailLookup(leadEmail)
+
+	// Settle the request a crash interrupted before picking up where we left off
+	if done := resolveInFlight(workflowState, tracker); resuming && done != "" && len(profileURLs) > 0 && profileURLs[0] == done {
+		startIndex++
+		profileURLs = profileURLs[1:]
+	}
+
+	if !autoWithdraw(page, tracker) {
+		store.PauseWorkflow(workflowState.ID)
+		return
+	}
 
 	// Print stats from database
 	connStats, err := store.GetConnectionRequestStats(1)
@@ -186,22 +807,31 @@ func RunConnections(page *rod.Page, profileURLs []string) {
 	if len(profileURLs) == 0 {
 		// Try to get unprocessed profiles from database
 		// Get extra profiles for browsing (3x the daily limit)
-		unprocessed, _ := store.GetUnprocessedSearchResults(SearchKeywordPeople, 1)
+		unprocessed, _ := store.GetUnprocessedSearchResults(SearchKeywordPeople, 1, MaxLeadAgeDays)
 		if len(unprocessed) > 0 {
 			fmt.Printf("📋 Found %d unprocessed profiles in database\n", len(unprocessed))
 			for _, r := range unprocessed {
 				profileURLs = append(profileURLs, r.ProfileURL)
 			}
-		} else {
-			fmt.Println("ℹ️ No profiles to connect with")
-			return
 		}
 	}
 
-	// Personalized note template
-	noteTemplate := "Hi! I came across your profile and would love to connect. Looking forward to learning from your experience!"
+	// Remember the targets so a later -resume continues the same list
+	if !resuming {
+		profileURLs = orderTargets(profileURLs)
+	}
+	if len(profileURLs) == 0 {
+		fmt.Println("ℹ️ No profiles to connect with")
+		return
+	}
+	if !resuming {
+		workflowState.TotalItems = len(profileURLs)
+		workflowState.Metadata = map[string]interface{}{"targets": profileURLs}
+		store.SaveWorkflowState(workflowState)
+	}
 
-	// Use the provided feed page for all browsing (do not open a new page)
+	// Personalized note template
+	noteTemplate := ConnectNoteTemplate
 
 	// Limit requests based on central config
 	maxRequests := 1
@@ -213,8 +843,12 @@ func RunConnections(page *rod.Page, profileURLs []string) {
 
 	successCount := 0
 	failCount := 0
+	viewOnlyCount := 0
 	browseIndex := maxRequests // Start browsing from profiles after targets
 
+	// Set by every pause that breaks out of the loop, so the workflow isn't completed
+	paused := false
+
 	// Create scheduler for break management
 	var scheduler *stealth.Scheduler
 	if EnforceSchedule {
@@ -229,26 +863,44 @@ func RunConnections(page *rod.Page, profileURLs []string) {
 	// Create organic browser for human-like behavior
 	organicBrowser := stealth.NewOrganicBrowser(page)
 
+	// Tracks which company the loop is currently inviting people from
+	pacer := &companyPacer{}
+
+	// Batches: a few requests, a long break, a few more
+	var batches *stealth.ActionBurst
+	if ConnectBatchSize > 0 {
+		batches = stealth.NewBatchBurst(ConnectBatchSize)
+	}
+
 	for i := 0; i < maxRequests; i++ {
 		targetURL := profileURLs[i]
 
 		// Check rate limits first
 		if can, reason := rateLimiter.CanPerform(stealth.ActionConnection); !can {
 			fmt.Printf("⏸️ Rate limited: %s\n", reason)
-			if !rateLimiter.WaitForAction(stealth.ActionConnection) {
+			ok, waitErr := rateLimiter.WaitForActionOn(page, stealth.ActionConnection)
+			if waitErr != nil {
+				fmt.Println("🛑 Critical warning during the wait - pausing workflow")
+				store.PauseWorkflow(workflowState.ID)
+				paused = true
+				break
+			}
+			if !ok {
 				fmt.Println("⏰ Rate limit wait too long - stopping workflow")
 				workflowState.Status = persistence.WorkflowStatusPaused
 				store.PauseWorkflow(workflowState.ID)
+				paused = true
 				break
 			}
 		}
 
 		// Check schedule before each action
 		if EnforceSchedule && scheduler != nil {
-			if !scheduler.CanOperate() {
-				fmt.Println("⏰ Work hours ended or on break - pausing workflow")
+			if !scheduler.CanOperate(stealth.ActionConnection) {
+				fmt.Println("⏰ Work hours or the connection window ended, or on break - pausing workflow")
 				workflowState.Status = persistence.WorkflowStatusPaused
 				store.PauseWorkflow(workflowState.ID)
+				paused = true
 				break
 			}
 
@@ -271,11 +923,46 @@ func RunConnections(page *rod.Page, profileURLs []string) {
 			continue
 		}
 
+		// Pending or connected on the search card: nothing to send
+		if skipCardState(targetURL) {
+			continue
+		}
+
+		// Keep the pending pile under the ceiling
+		if pendingCeilingReached(page, tracker) {
+			workflowState.Status = persistence.WorkflowStatusPaused
+			store.PauseWorkflow(workflowState.ID)
+			paused = true
+			break
+		}
+
+		// Skip do-not-contact targets before spending any browsing on them
+		if skipBlocked(targetURL) {
+			continue
+		}
+
+		// Don't flood one company with invites
+		company := targetCompany(page, targetURL)
+		if skipCompanyQuota(targetURL, company) {
+			continue
+		}
+
+		// Back off from a company we just sent several invites to
+		if wait := pacer.cooldown(company); wait > 0 {
+			fmt.Printf("🏢 %d invites in a row to %s - waiting %v before the next one\n", pacer.streak, company, wait)
+			if err := stealth.WatchedSleep(page, wait); err != nil {
+				fmt.Println("🛑 Critical warning during the wait - pausing workflow")
+				store.PauseWorkflow(workflowState.ID)
+				return
+			}
+			pacer.reset()
+		}
+
 		fmt.Printf("\n========== [%d/%d] Connection Cycle ==========\n", i+1, maxRequests)
 
 		// Update workflow progress
-		workflowState.CurrentIndex = i
-		store.UpdateWorkflowProgress(workflowState.ID, i, "organic_browsing")
+		workflowState.CurrentIndex = startIndex + i
+		store.UpdateWorkflowProgress(workflowState.ID, startIndex+i, "organic_browsing")
 
 		// ==================== ORGANIC BROWSING PHASE ====================
 		if EnableOrganicBrowsing {
@@ -306,10 +993,13 @@ func RunConnections(page *rod.Page, profileURLs []string) {
 		// Step 3: Quick view target profile (~5 sec) then connect
 		fmt.Printf("\n🎯 Step 3: Target profile: %s\n", targetURL)
 
-		store.UpdateWorkflowProgress(workflowState.ID, i, "connecting")
+		store.UpdateWorkflowProgress(workflowState.ID, startIndex+i, "connecting")
+
+		// Now and then just look at the profile and move on, like a person would
+		viewOnly := stealth.Rand().Float64() < ViewWithoutConnectChance
 
 		// Quick browse the target before connecting
-		if EnableOrganicBrowsing {
+		if EnableOrganicBrowsing || viewOnly {
 			if err := organicBrowser.BrowseProfileQuick(targetURL); err != nil {
 				fmt.Printf("   ⚠️ Target browse failed: %v\n", err)
 				// Check if critical error
@@ -317,83 +1007,483 @@ func RunConnections(page *rod.Page, profileURLs []string) {
 					fmt.Println("🛑 Critical error detected - stopping workflow")
 					workflowState.Status = persistence.WorkflowStatusPaused
 					store.PauseWorkflow(workflowState.ID)
+					paused = true
 					break
 				}
 			}
 		}
 
+		// Photo / Open to Work filters, read from the profile we're on
+		if !viewOnly && skipProfileFilter(page, targetURL) {
+			continue
+		}
+
 		// Now send the connection request (page is already on target profile)
-		err := connect.ConnectWithTracking(page, targetURL, "", noteTemplate, tracker)
-		if err != nil {
-			fmt.Printf("❌ Connection failed: %v\n", err)
-			failCount++
+		batchDone := false
+		if viewOnly {
+			viewOnlyCount++
+			fmt.Println("👀 Viewed the profile and moved on without connecting - it stays queued for the next run")
+		} else {
+			note := noteForTarget(page, targetURL, noteTemplate)
+			setInFlight(workflowState, targetURL)
+			err := connect.ConnectWithTracking(page, targetURL, "", note, tracker)
+			if err != nil {
+				fmt.Printf("❌ Connection failed: %v\n", err)
+				failCount++
+				recordFailure(persistence.QueueActionConnect, targetURL, note, err)
+
+				// Check if this is a critical LinkedIn error (or the note limit when notes are required)
+				if stealth.IsCritical(err) || stealth.IsNoteLimit(err) {
+					fmt.Println("🛑 Critical error detected - stopping workflow")
+					workflowState.Status = persistence.WorkflowStatusPaused
+					store.PauseWorkflow(workflowState.ID)
+					paused = true
+					break
+				}
 
-			// Check if this is a critical LinkedIn error
-			if stealth.IsCritical(err) {
-				fmt.Println("🛑 Critical error detected - stopping workflow")
-				workflowState.Status = persistence.WorkflowStatusPaused
-				store.PauseWorkflow(workflowState.ID)
-				break
-			}
-
-			// For non-recoverable errors, may need longer cooldown
-			if !stealth.IsRecoverable(err) {
-				fmt.Println("⏸️ Non-recoverable error - taking extended break...")
-				stealth.Sleep(60, 120) // 1-2 minute break
-			}
-		} else {
-			successCount++
-			fmt.Printf("✅ Connection request sent!\n")
-
-			// Record action for rate limiting
-			rateLimiter.RecordAction(stealth.ActionConnection)
-
-			// Save to database (track stats even in dry run mode)
-			req := &persistence.ConnectionRequest{
-				ProfileURL:    targetURL,
-				Note:          noteTemplate,
-				Status:        persistence.StatusPending,
-				SentAt:        time.Now(),
-				Source:        "search",
-				SearchKeyword: SearchKeywordPeople,
-			}
-
-			if DryRunMode {
-				fmt.Println("   📝 [DRY RUN] Would save connection request to database")
-				// Still increment the daily stat for tracking purposes
-				store.IncrementConnectionsSent()
+				// For non-recoverable errors, may need longer cooldown
+				if !stealth.IsRecoverable(err) {
+					fmt.Println("⏸️ Non-recoverable error - taking extended break...")
+					stealth.Sleep(60, 120) // 1-2 minute break
+				}
 			} else {
-				store.SaveConnectionRequest(req)
-			}
+				successCount++
+				fmt.Printf("✅ Connection request sent!\n")
 
-			// Mark search result as processed
-			store.MarkSearchResultProcessed(targetURL)
+				// Record action for rate limiting
+				rateLimiter.RecordAction(stealth.ActionConnection)
+				pacer.sent(company)
+				batchDone = batches != nil && batches.Record()
+
+				saveConnectionRequestToDB(targetURL, sentNote(tracker, targetURL, note), tracker.NoteSkipped(targetURL))
+			}
+			setInFlight(workflowState, "")
 		}
 
 		// ==================== DELAY BEFORE NEXT CYCLE ====================
-		if i < maxRequests-1 {
-			// Use centralized delay configuration
-			delay := stealth.GetRandomDelay(stealth.ActionConnection)
+		if i < maxRequests-1 && batchDone {
+			// Save progress first so a crash during the break resumes after this target
+			store.UpdateWorkflowProgress(workflowState.ID, startIndex+i+1, "batch_break")
+
+			pause := batches.BreakDuration()
+			fmt.Printf("\n☕ Batch done - taking a %v break before the next batch...\n", pause.Round(time.Second))
+			if err := stealth.WatchedSleep(page, pause); err != nil {
+				fmt.Println("🛑 Critical warning during the break - pausing workflow")
+				store.PauseWorkflow(workflowState.ID)
+				return
+			}
+		} else if i < maxRequests-1 {
+			// Use centralized delay configuration, adjusted for time of day
+			delay := adaptiveDelay(scheduler, stealth.ActionConnection)
 
 			fmt.Printf("\n⏳ Waiting %v before next connection cycle...\n", delay.Round(time.Second))
-			time.Sleep(delay)
+			if err := stealth.WatchedSleep(page, delay); err != nil {
+				fmt.Println("🛑 Critical warning during the wait - pausing workflow")
+				store.PauseWorkflow(workflowState.ID)
+				return
+			}
 		}
 	}
 
 	// Print final rate limit stats
 	rateLimiter.PrintStats(stealth.ActionConnection)
 
-	// Mark workflow complete
-	store.CompleteWorkflow(workflowState.ID)
+	// A paused workflow stays paused so -resume connect can pick it up
+	if paused {
+		fmt.Println("⏸️ Workflow paused - continue later with -resume connect")
+	} else {
+		store.CompleteWorkflow(workflowState.ID)
+	}
 
 	fmt.Printf("\n✅ Connection Results: %d sent, %d failed\n", successCount, failCount)
+	if viewOnlyCount > 0 {
+		fmt.Printf("   👀 %d profiles viewed without connecting (retried next run)\n", viewOnlyCount)
+	}
 	if EnableOrganicBrowsing {
 		fmt.Println("   (Organic browsing was enabled for stealth)")
 	}
 }
 
+// setInFlight records the profile a connect is about to be sent to in the
+// workflow's metadata ("" clears it), so a resume after a crash knows
+// exactly which item was interrupted
+func setInFlight(state *persistence.WorkflowState, profileURL string) {
+	if state.Metadata == nil {
+		state.Metadata = make(map[string]interface{})
+	}
+	if profileURL == "" {
+		delete(state.Metadata, "in_flight")
+	} else {
+		state.Metadata["in_flight"] = profileURL
+	}
+	store.SaveWorkflowState(state)
+}
+
+// resolveInFlight checks whether the connect a crash interrupted went out
+// and returns its profile URL if it did, so the resume moves past it instead
+// of sending it again. An invite the tracker saw but the database missed is
+// recorded now; one that never went out is retried ("" is returned)
+func resolveInFlight(state *persistence.WorkflowState, tracker *connect.ConnectionTracker) string {
+	profileURL, _ := state.Metadata["in_flight"].(string)
+	if profileURL == "" {
+		return ""
+	}
+	defer setInFlight(state, "")
+
+	sent, err := store.HasSentRequest(profileURL)
+	switch {
+	case err != nil:
+		fmt.Printf("⚠️ Could not check the interrupted request to %s: %v - retrying it\n", profileURL, err)
+		return ""
+	case sent:
+		fmt.Printf("📌 Interrupted request to %s had gone through - moving on\n", profileURL)
+		return profileURL
+	case tracker.AlreadySent(profileURL):
+		fmt.Printf("📌 Interrupted request to %s went out but wasn't saved - recording it\n", profileURL)
+		saveConnectionRequestToDB(profileURL, sentNote(tracker, profileURL, ""), tracker.NoteSkipped(profileURL))
+		return profileURL
+	}
+
+	fmt.Printf("📌 Interrupted request to %s never went out - retrying it\n", profileURL)
+	return ""
+}
+
+// workflowTargets returns the target profile URLs saved in a workflow's metadata
+func workflowTargets(state *persistence.WorkflowState) []string {
+	raw, _ := state.Metadata["targets"].([]interface{})
+	var targets []string
+	for _, v := range raw {
+		if url, ok := v.(string); ok && url != "" {
+			targets = append(targets, url)
+		}
+	}
+	return targets
+}
+
+// ResumeWorkflow continues a specific paused workflow from a fresh process
+// Search picks up at its stored page, connect at its stored index in the
+// saved target list, and messaging re-reads unmessaged connections from the
+// database. Sessions are re-planned from the current database state.
+func ResumeWorkflow(browser *rod.Browser, wfType string) {
+	state, err := store.GetActiveWorkflow(wfType)
+	if err != nil || state == nil || state.Status != persistence.WorkflowStatusPaused {
+		fmt.Printf("ℹ️ No paused %s workflow to resume\n", wfType)
+		return
+	}
+
+	fmt.Printf("\n▶️ Resuming %s workflow #%d (%d/%d, step: %s)\n",
+		wfType, state.ID, state.CurrentIndex, state.TotalItems, state.CurrentStep)
+	if state.ErrorMessage != "" {
+		fmt.Printf("   Last error: %s\n", state.ErrorMessage)
+	}
+
+	switch wfType {
+	case persistence.WorkflowTypeSearch:
+		people, companies := RunSearch(browser)
+		fmt.Printf("\n📋 Search Summary: %d people, %d companies\n", len(people), len(companies))
+	case persistence.WorkflowTypeConnect:
+		targets := workflowTargets(state)
+		if len(targets) == 0 {
+			// Older workflows didn't save their targets - rebuild from the database
+			unprocessed, _ := store.GetUnprocessedSearchResults(SearchKeywordPeople, stealth.GetConnectionDailyLimit(), MaxLeadAgeDays)
+			for _, r := range unprocessed {
+				targets = append(targets, r.ProfileURL)
+			}
+			// Processed profiles are already gone from that list
+			state.CurrentIndex = 0
+			store.SaveWorkflowState(state)
+		}
+		RunConnections(targets)
+	case persistence.WorkflowTypeMessage:
+		RunMessaging()
+	case persistence.WorkflowTypeSession:
+		// A session plan is random per run; close the old one and plan afresh
+		store.CompleteWorkflow(state.ID)
+		RunSession()
+	default:
+		fmt.Printf("❌ Cannot resume %s workflows\n", wfType)
+	}
+}
+
+// initiatedByUs reports whether the connection accepted one of our recent requests
+func initiatedByUs(profileURL string) bool {
+	ours, err := store.InitiatedByUs(profileURL, ourRequestMaxAge())
+	return err == nil && ours
+}
+
+// configureSegments auto-tags connections on sync and, with MessageSegment set,
+// limits follow-ups to that segment
+func configureSegments(msgService *message.MessagingService) {
+	if AutoTagConnections {
+		msgService.SetOnSync(autoTagConnections)
+	}
+	if MessageSegment != "" {
+		msgService.SetSegmentFilter(inMessageSegment)
+	}
+}
+
+// autoTagConnections tags untagged connections from their headlines
+// Connections that already have tags are left alone, so hand-made tags
+// (and removed auto tags) stick
+func autoTagConnections(connections []message.Connection) {
+	tagged := 0
+	for _, conn := range connections {
+		if conn.Headline == "" {
+			continue
+		}
+		if tags, err := store.GetConnectionTags(conn.ProfileURL); err != nil || len(tags) > 0 {
+			continue
+		}
+		tags, err := store.AutoTagConnection(conn.ProfileURL, conn.Headline)
+		if err != nil {
+			fmt.Printf("⚠️ Failed to tag %s: %v\n", conn.ProfileURL, err)
+			continue
+		}
+		if len(tags) > 0 {
+			tagged++
+		}
+	}
+	if tagged > 0 {
+		fmt.Printf("🏷️ Auto-tagged %d connections\n", tagged)
+	}
+}
+
+// inMessageSegment reports whether the connection is tagged with MessageSegment
+func inMessageSegment(profileURL string) bool {
+	ok, err := store.HasTag(profileURL, MessageSegment)
+	return err == nil && ok
+}
+
+// followUpTemplate is the template follow-ups use: the segment's own template
+// when targeting a segment, MessageTemplate otherwise
+func followUpTemplate() string {
+	if MessageSegment != "" && MessageSegmentTemplate != "" {
+		return MessageSegmentTemplate
+	}
+	return MessageTemplate
+}
+
+// ourRequestMaxAge converts OurRequestMaxAgeDays to a duration (0 = any age)
+func ourRequestMaxAge() time.Duration {
+	return time.Duration(OurRequestMaxAgeDays) * 24 * time.Hour
+}
+
+// What to do when MaxPendingInvites invites are pending
+const (
+	PendingCeilingWithdraw = "withdraw" // Withdraw the oldest invites to make room
+	PendingCeilingPause    = "pause"    // Stop sending new invites
+)
+
+// pendingCeilingReached reports whether MaxPendingInvites or more invites are
+// pending and no room could be made, so no new invite should go out.
+// With PendingCeilingWithdraw the oldest invites are withdrawn first
+func pendingCeilingReached(page *rod.Page, tracker *connect.ConnectionTracker) bool {
+	if MaxPendingInvites <= 0 {
+		return false
+	}
+	pending, err := store.CountPendingRequests()
+	if err != nil {
+		fmt.Printf("⚠️ Failed to count pending invites: %v\n", err)
+		return false
+	}
+	if pending < MaxPendingInvites {
+		return false
+	}
+
+	fmt.Printf("📬 %d invites pending (ceiling %d)\n", pending, MaxPendingInvites)
+	if PendingCeilingPolicy == PendingCeilingWithdraw {
+		policy := connect.AutoWithdrawPolicy{
+			EnabledAfterDays: PendingCeilingMinAgeDays,
+			MaxPerRun:        pending - MaxPendingInvites + 1,
+			PendingThreshold: MaxPendingInvites - 1,
+		}
+		if _, err := policy.Apply(page, store, tracker); err != nil {
+			fmt.Printf("⚠️ Withdrawing to make room stopped: %v\n", err)
+		}
+		if tracker.DryRun {
+			return false
+		}
+		if pending, err = store.CountPendingRequests(); err == nil && pending < MaxPendingInvites {
+			return false
+		}
+	}
+
+	fmt.Printf("⏸️ Pending invites at the ceiling (%d/%d) - not sending new invites\n", pending, MaxPendingInvites)
+	return true
+}
+
+// autoWithdraw clears old pending invites per the AutoWithdraw* settings
+// Returns false when LinkedIn flagged the account and the run should stop
+func autoWithdraw(page *rod.Page, tracker *connect.ConnectionTracker) bool {
+	policy := connect.AutoWithdrawPolicy{
+		EnabledAfterDays: AutoWithdrawAfterDays,
+		MaxPerRun:        AutoWithdrawMaxPerRun,
+		PendingThreshold: AutoWithdrawPendingAbove,
+	}
+	if _, err := policy.Apply(page, store, tracker); err != nil {
+		log.Printf("⚠️ Auto-withdraw stopped: %v\n", err)
+		return !stealth.IsCritical(err)
+	}
+	return true
+}
+
+// noteForTarget fills the placeholders in the note template for one target
+// Name, title and company come from the search result, or from the profile's
+// top card when the page is already on that profile. The activity page is only
+// loaded when RecentPostNotes is on; otherwise (or when the member hasn't posted)
+// the sentence with the placeholder is dropped, like any placeholder left unknown.
+// Company details come from a company search result matching the member's company.
+// The template is swapped for a localized one when the target's profile points to another language.
+func noteForTarget(page *rod.Page, targetURL, noteTemplate string) string {
+	language := targetLanguage(page, targetURL)
+	if language != connect.DefaultNoteLanguage {
+		fmt.Printf("   🌐 Using the %s connection note\n", language)
+	}
+	noteTemplate = connect.NoteTemplateFor(noteTemplate, language)
+
+	wantsPost := strings.Contains(noteTemplate, connect.RecentPostPlaceholder)
+	wantsLead := containsAny(noteTemplate, connect.LeadPlaceholders)
+	wantsCompany := containsAny(noteTemplate, connect.CompanyPlaceholders)
+	if !wantsPost && !wantsLead && !wantsCompany {
+		return noteTemplate
+	}
+
+	lead := leadDetails(page, targetURL)
+
+	var companyInfo *persistence.CompanySearchResult
+	if wantsCompany {
+		companyInfo, _ = store.GetCompanyForPerson(targetURL)
+	}
+	company := lead.Company
+	if company == "" && companyInfo != nil {
+		company = companyInfo.Name
+	}
+
+	snippet := ""
+	if wantsPost && RecentPostNotes {
+		var err error
+		snippet, err = search.GetLatestPostSnippet(page, targetURL)
+		if err != nil {
+			fmt.Printf("   ⚠️ Could not read recent activity: %v\n", err)
+		}
+	}
+	return connect.GeneratePersonalizedNote(noteTemplate, connect.NoteFields{
+		Name:        lead.Name,
+		Company:     company,
+		Title:       connect.TitleFromHeadline(lead.Headline),
+		RecentPost:  snippet,
+		CompanyInfo: companyInfo,
+	})
+}
+
+// leadDetails returns what is known about a target: the stored search result,
+// or the profile's top card (recorded on the lead) if the page is already on it
+func leadDetails(page *rod.Page, targetURL string) connect.ProfileCard {
+	var lead connect.ProfileCard
+	if person, _ := store.GetPersonResult(targetURL); person != nil {
+		lead = connect.ProfileCard{
+			Name:     person.Name,
+			Headline: person.Headline,
+			Location: person.Location,
+			Company:  person.Company,
+		}
+	}
+	if lead.Name != "" {
+		return lead
+	}
+	if info, err := page.Info(); err == nil && linkedinurl.Canonicalize(info.URL) == linkedinurl.Canonicalize(targetURL) {
+		return recordProfileCard(page, targetURL)
+	}
+	return lead
+}
+
+// containsAny reports whether text contains any of the placeholders
+func containsAny(text string, placeholders []string) bool {
+	for _, p := range placeholders {
+		if strings.Contains(text, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// targetLanguage detects the language to write a target's note in
+// The search result's headline/location are used when scraped; otherwise the
+// profile's top card, if the page is already on that profile
+func targetLanguage(page *rod.Page, targetURL string) string {
+	if len(connect.NoteTemplatesByLanguage) == 0 {
+		return connect.DefaultNoteLanguage
+	}
+
+	headline, location := "", ""
+	if person, _ := store.GetPersonResult(targetURL); person != nil {
+		headline, location = person.Headline, person.Location
+	}
+	if headline == "" && location == "" {
+		if info, err := page.Info(); err == nil && linkedinurl.Canonicalize(info.URL) == linkedinurl.Canonicalize(targetURL) {
+			headline, location = connect.ReadProfileHints(page)
+		}
+	}
+
+	return connect.DetectProfileLanguage(location, headline)
+}
+
+// sentNote returns the note that actually went out with a request
+// (empty when it was omitted or skipped, the template in dry run)
+func sentNote(tracker *connect.ConnectionTracker, targetURL, noteTemplate string) string {
+	if req := tracker.GetRequest(targetURL); req != nil {
+		return req.Note
+	}
+	return noteTemplate
+}
+
+// saveConnectionRequestToDB records a sent connection request and marks the search result processed
+// Recent profile viewers are recorded with the profile_viewers source
+func saveConnectionRequestToDB(targetURL, note string, noteSkipped bool) {
+	source := "search"
+	if recentProfileViewer(targetURL) {
+		source = search.ProfileViewersSource
+	}
+	saveConnectionRequestFromSource(targetURL, note, noteSkipped, source, SearchKeywordPeople)
+}
+
+// saveConnectionRequestFromSource is saveConnectionRequestToDB with an explicit lead source
+func saveConnectionRequestFromSource(targetURL, note string, noteSkipped bool, source, keyword string) {
+	if noteSkipped {
+		// Invite went out without its note (personalized-note limit)
+		note = ""
+		if !DryRunMode || CountDryRunStats {
+			store.IncrementNotesSkipped()
+		}
+	}
+
+	// Save to database (dry runs only count in daily_stats, see CountDryRunStats)
+	company, _ := store.CompanyForProfile(targetURL)
+	req := &persistence.ConnectionRequest{
+		ProfileURL:    targetURL,
+		Company:       company,
+		Note:          note,
+		Status:        persistence.StatusPending,
+		SentAt:        time.Now(),
+		Source:        source,
+		SearchKeyword: keyword,
+	}
+
+	if DryRunMode {
+		fmt.Println("   📝 [DRY RUN] Would save connection request to database")
+		if CountDryRunStats {
+			store.IncrementConnectionsSent()
+		}
+	} else {
+		store.SaveConnectionRequest(req)
+	}
+
+	// Mark search result as processed
+	store.MarkSearchResultProcessed(targetURL)
+}
+
 // RunMessaging sends follow-up messages to connections
-func RunMessaging(browser *rod.Browser) {
+func RunMessaging() {
 	fmt.Println("\n==================================================")
 	fmt.Println("📬 MESSAGING WORKFLOW")
 	fmt.Println("==================================================")
@@ -416,9 +1506,21 @@ func RunMessaging(browser *rod.Browser) {
 
 	store.SaveWorkflowState(workflowState)
 
-	// Get a page to work with
-	page := browser.MustPage()
-	defer page.Close()
+	// Follow-ups only go out inside the message window
+	if EnforceSchedule && !stealth.NewScheduler().CanOperate(stealth.ActionMessage) {
+		fmt.Println("⏰ Outside work hours or the message window, or on break - pausing messaging")
+		store.PauseWorkflow(workflowState.ID)
+		return
+	}
+
+	// Borrow a page from the pool
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		store.FailWorkflow(workflowState.ID, err.Error())
+		return
+	}
+	defer pagePool.Put(page)
 
 	// Create messaging service
 	msgService, err := message.NewMessagingService(page)
@@ -432,6 +1534,13 @@ func RunMessaging(browser *rod.Browser) {
 	// Set dry run mode and use central config for limits
 	msgService.SetDryRun(DryRunMode)
 	msgService.SetDailyLimit(stealth.GetMessageDailyLimit())
+	msgService.SetMinHoursSinceConnected(MinHoursSinceConnected)
+	msgService.SetShuffleOrder(ShuffleContactOrder)
+	configureSegments(msgService)
+	msgService.SetOnFailure(recordMessageFailure)
+	if OnlyMessageOurConnections {
+		msgService.SetInitiatedFilter(initiatedByUs)
+	}
 
 	// Show available templates
 	msgService.ListTemplates()
@@ -445,8 +1554,12 @@ func RunMessaging(browser *rod.Browser) {
 		fmt.Printf("   Follow-ups: %d\n", msgStats.FollowUpsSent)
 	}
 
-	// Get unmessaged connections from database (no age criteria)
-	unmessaged, err := store.GetUnmessagedConnections()
+	// Get unmessaged connections from database that are old enough to message
+	minAge := time.Duration(MinHoursSinceConnected) * time.Hour
+	unmessaged, err := store.GetUnmessagedConnections(minAge)
+	if OnlyMessageOurConnections {
+		unmessaged, err = store.GetUnmessagedInitiatedConnections(minAge, ourRequestMaxAge())
+	}
 	if err == nil && len(unmessaged) > 0 {
 		fmt.Printf("\n📋 Found %d unmessaged connections in database\n", len(unmessaged))
 		workflowState.TotalItems = len(unmessaged)
@@ -458,7 +1571,7 @@ func RunMessaging(browser *rod.Browser) {
 	// Run full workflow (detect connections + send follow-ups)
 	// Use centralized delay config
 	err = msgService.FullWorkflow(
-		MessageTemplate,
+		followUpTemplate(),
 		MaxFollowUpMessages,
 		stealth.GetMessageDelayMin(),
 		stealth.GetMessageDelayMax(),
@@ -474,3 +1587,1046 @@ func RunMessaging(browser *rod.Browser) {
 	fmt.Println("\n📊 Final Messaging Statistics:")
 	msgService.PrintStats()
 }
+
+// RunSession runs an interleaved session of connects, messages and browsing
+// Instead of search -> all connects -> all messages, the planner mixes steps
+// by weight so each session looks like a person juggling a few tasks
+func RunSession() {
+	fmt.Println("\n==================================================")
+	fmt.Println("🎲 INTERLEAVED SESSION WORKFLOW")
+	fmt.Println("==================================================")
+
+	workflowState := &persistence.WorkflowState{
+		WorkflowType: persistence.WorkflowTypeSession,
+		Status:       persistence.WorkflowStatusInProgress,
+		CurrentStep:  "planning",
+	}
+	store.SaveWorkflowState(workflowState)
+
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		store.FailWorkflow(workflowState.ID, err.Error())
+		return
+	}
+	defer pagePool.Put(page)
+
+	// Connection targets from the database
+	var targets []string
+	unprocessed, _ := store.GetUnprocessedSearchResults(SearchKeywordPeople, stealth.GetConnectionDailyLimit(), MaxLeadAgeDays)
+	for _, r := range unprocessed {
+		targets = append(targets, r.ProfileURL)
+	}
+	targets = orderTargets(targets)
+
+	tracker, err := connect.LoadTracker()
+	if err != nil {
+		log.Printf("⚠️ Failed to load tracker: %v\n", err)
+		store.FailWorkflow(workflowState.ID, err.Error())
+		return
+	}
+	tracker.SetDryRun(DryRunMode)
+	tracker.SetValidateDryRun(DryRunValidate)
+	tracker.SetDailyLimit(stealth.GetConnectionDailyLimit())
+	tracker.SetNoteOmissionRate(NoteOmissionRate)
+	tracker.SetRequireNote(RequireConnectNote)
+	tracker.SetEmailLookup(leadEmail)
+
+	if !autoWithdraw(page, tracker) {
+		store.PauseWorkflow(workflowState.ID)
+		return
+	}
+
+	msgService, err := message.NewMessagingService(page)
+	if err != nil {
+		log.Printf("⚠️ Failed to create messaging service: %v\n", err)
+		store.FailWorkflow(workflowState.ID, err.Error())
+		return
+	}
+	defer msgService.Close()
+	msgService.SetDryRun(DryRunMode)
+	msgService.SetDailyLimit(stealth.GetMessageDailyLimit())
+	msgService.SetMinHoursSinceConnected(MinHoursSinceConnected)
+	msgService.SetShuffleOrder(ShuffleContactOrder)
+	configureSegments(msgService)
+	msgService.SetOnFailure(recordMessageFailure)
+	if OnlyMessageOurConnections {
+		msgService.SetInitiatedFilter(initiatedByUs)
+	}
+
+	var scheduler *stealth.Scheduler
+	if EnforceSchedule {
+		scheduler = stealth.NewScheduler()
+		scheduler.StartBurst()
+	}
+
+	rateLimiter := stealth.GetRateLimiter()
+	planner := stealth.NewSessionPlanner(rateLimiter, scheduler)
+	plan := planner.Plan(len(targets), MaxFollowUpMessages)
+	if len(plan) == 0 {
+		fmt.Println("ℹ️ Nothing to do this session (no budget or no targets)")
+		store.CompleteWorkflow(workflowState.ID)
+		return
+	}
+
+	fmt.Printf("🗺️ Session plan: %s\n", stealth.DescribePlan(plan))
+	workflowState.TotalItems = len(plan)
+	store.SaveWorkflowState(workflowState)
+
+	organicBrowser := stealth.NewOrganicBrowser(page)
+	noteTemplate := ConnectNoteTemplate
+
+	var followUps []message.Connection
+	synced := false
+	connectsSent, messagesSent := 0, 0
+	var lastAction stealth.ActionType
+	deferred := 0 // out-of-window steps moved to the end since the last step that ran
+
+	for i := 0; i < len(plan); i++ {
+		step := plan[i]
+		if !planner.CanRun() {
+			fmt.Println("⏰ Work hours ended or on break - pausing session")
+			store.PauseWorkflow(workflowState.ID)
+			return
+		}
+
+		// Out-of-window steps go to the end of the plan, since their window may
+		// open while the other steps run; once nothing left can run, stop
+		if !planner.CanRunStep(step) {
+			if left := len(plan) - i; deferred >= left {
+				fmt.Printf("⏰ %d steps outside their windows - leaving them for a later session\n", left)
+				break
+			}
+			fmt.Printf("⏰ Outside the %s window - moving step %d to the end of the session\n", step, i+1)
+			plan = append(plan, step)
+			deferred++
+			continue
+		}
+		deferred = 0
+
+		fmt.Printf("\n========== [%d/%d] Session step: %s ==========\n", i+1, len(plan), step)
+		store.UpdateWorkflowProgress(workflowState.ID, i, string(step))
+
+		// Don't jump straight from connecting to messaging (or back)
+		if IdleBetweenPhases && step != stealth.StepBrowse && lastAction != "" &&
+			(step == stealth.StepConnect) != (lastAction == stealth.ActionConnection) {
+			if err := idleBetweenPhases(page, organicBrowser); err != nil {
+				fmt.Println("🛑 Critical warning while idling - stopping session")
+				store.PauseWorkflow(workflowState.ID)
+				return
+			}
+		}
+
+		var stepErr error
+		var action stealth.ActionType
+
+		switch step {
+		case stealth.StepConnect:
+			if len(targets) == 0 {
+				fmt.Println("ℹ️ No connection targets left - skipping")
+				continue
+			}
+			targetURL := targets[0]
+			targets = targets[1:]
+
+			if sent, _ := store.HasSentRequest(targetURL); sent {
+				fmt.Printf("⏭️ Skipping %s (already sent)\n", targetURL)
+				continue
+			}
+			if skipBlocked(targetURL) {
+				continue
+			}
+			if can, reason := rateLimiter.CanPerform(stealth.ActionConnection); !can {
+				fmt.Printf("⏸️ Rate limited: %s - skipping connect\n", reason)
+				continue
+			}
+			if pendingCeilingReached(page, tracker) {
+				continue
+			}
+
+			if EnableOrganicBrowsing {
+				if err := organicBrowser.BrowseProfileQuick(targetURL); err != nil {
+					fmt.Printf("   ⚠️ Target browse failed: %v\n", err)
+				}
+			}
+			if skipProfileFilter(page, targetURL) {
+				continue
+			}
+
+			note := noteForTarget(page, targetURL, noteTemplate)
+			stepErr = connect.ConnectWithTracking(page, targetURL, "", note, tracker)
+			if stepErr == nil {
+				connectsSent++
+				rateLimiter.RecordAction(stealth.ActionConnection)
+				saveConnectionRequestToDB(targetURL, sentNote(tracker, targetURL, note), tracker.NoteSkipped(targetURL))
+			} else {
+				recordFailure(persistence.QueueActionConnect, targetURL, note, stepErr)
+			}
+			action = stealth.ActionConnection
+
+		case stealth.StepMessage:
+			// Sync accepted connections lazily, the first time a message is planned
+			if !synced {
+				synced = true
+				if _, err := msgService.SyncConnections(50); err != nil {
+					fmt.Printf("⚠️ Error syncing connections: %v\n", err)
+				}
+				followUps = msgService.GetUnmessagedConnections()
+			}
+			if len(followUps) == 0 {
+				fmt.Println("ℹ️ No unmessaged connections left - skipping")
+				continue
+			}
+			conn := followUps[0]
+			followUps = followUps[1:]
+
+			if can, reason := rateLimiter.CanPerform(stealth.ActionMessage); !can {
+				fmt.Printf("⏸️ Rate limited: %s - skipping message\n", reason)
+				continue
+			}
+
+			stepErr = msgService.SendFollowUp(conn, followUpTemplate())
+			if stepErr == nil {
+				messagesSent++
+				rateLimiter.RecordAction(stealth.ActionMessage)
+			}
+			action = stealth.ActionMessage
+
+		case stealth.StepBrowse:
+			if err := organicBrowser.BrowseFeed(); err != nil {
+				fmt.Printf("   ⚠️ Feed browse failed: %v (continuing)\n", err)
+			}
+			organicBrowser.RandomDelay()
+			continue
+		}
+
+		lastAction = action
+
+		if stepErr != nil {
+			fmt.Printf("❌ Step failed: %v\n", stepErr)
+			if stealth.IsCritical(stepErr) || stealth.IsNoteLimit(stepErr) {
+				fmt.Println("🛑 Critical error detected - stopping session")
+				store.PauseWorkflow(workflowState.ID)
+				return
+			}
+		}
+
+		if i < len(plan)-1 {
+			delay := adaptiveDelay(scheduler, action)
+			fmt.Printf("\n⏳ Waiting %v before next step...\n", delay.Round(time.Second))
+			if err := stealth.WatchedSleep(page, delay); err != nil {
+				fmt.Println("🛑 Critical warning during the wait - stopping session")
+				store.PauseWorkflow(workflowState.ID)
+				return
+			}
+		}
+	}
+
+	store.CompleteWorkflow(workflowState.ID)
+	fmt.Printf("\n✅ Session Results: %d connection requests, %d messages\n", connectsSent, messagesSent)
+}
+
+// PlanQueue plans today's connects and messages into the action queue
+// Nothing is sent; run the "queue" workflow to execute the plan.
+// Review or reorder it first with Store.GetQueuedActions / RescheduleAction.
+func PlanQueue() {
+	fmt.Println("\n==================================================")
+	fmt.Println("🗓️ PLAN ACTION QUEUE")
+	fmt.Println("==================================================")
+
+	queued, _ := store.GetQueuedActions(0)
+	queuedURLs := make(map[string]bool, len(queued))
+	for _, a := range queued {
+		queuedURLs[a.ActionType+"|"+a.ProfileURL] = true
+	}
+
+	// Connection targets not already sent or queued
+	var targets []string
+	unprocessed, _ := store.GetUnprocessedSearchResults(SearchKeywordPeople, stealth.GetConnectionDailyLimit(), MaxLeadAgeDays)
+	for _, r := range unprocessed {
+		if sent, _ := store.HasSentRequest(r.ProfileURL); sent {
+			continue
+		}
+		if queuedURLs[persistence.QueueActionConnect+"|"+r.ProfileURL] {
+			continue
+		}
+		if err := stealth.CheckBlocked(r.ProfileURL, r.Company); err != nil {
+			continue
+		}
+		targets = append(targets, r.ProfileURL)
+	}
+
+	// Follow-up targets from the connections synced so far
+	var followUps []message.Connection
+	msgTracker, err := message.LoadTracker()
+	if err != nil {
+		fmt.Printf("⚠️ Failed to load message tracker: %v\n", err)
+	} else {
+		for _, conn := range msgTracker.GetUnmessagedConnectionsOlderThan(time.Duration(MinHoursSinceConnected) * time.Hour) {
+			if OnlyMessageOurConnections && !initiatedByUs(conn.ProfileURL) {
+				continue
+			}
+			if MessageSegment != "" && !inMessageSegment(conn.ProfileURL) {
+				continue
+			}
+			if !queuedURLs[persistence.QueueActionMessage+"|"+conn.ProfileURL] {
+				followUps = append(followUps, conn)
+			}
+		}
+	}
+
+	if ShuffleContactOrder {
+		followUps = message.ShuffleConnections(followUps)
+	}
+
+	// Budgets are the list lengths, so an empty list plans no steps of its kind
+	planner := stealth.NewSessionPlanner(stealth.GetRateLimiter(), nil)
+	plan := planner.Plan(len(targets), len(followUps))
+	if len(plan) == 0 {
+		fmt.Println("ℹ️ Nothing to plan (no budget or no targets)")
+		return
+	}
+
+	// Space actions out the way the executor would, starting now
+	at := time.Now()
+	planned := 0
+	for _, step := range plan {
+		var action *persistence.QueuedAction
+
+		switch step {
+		case stealth.StepConnect:
+			if len(targets) == 0 {
+				continue
+			}
+			at = at.Add(stealth.GetAdaptiveDelay(stealth.ActionConnection))
+			action = &persistence.QueuedAction{
+				ActionType: persistence.QueueActionConnect,
+				ProfileURL: targets[0],
+				Payload:    ConnectNoteTemplate,
+			}
+			targets = targets[1:]
+		case stealth.StepMessage:
+			if len(followUps) == 0 {
+				continue
+			}
+			at = at.Add(stealth.GetAdaptiveDelay(stealth.ActionMessage))
+			action = &persistence.QueuedAction{
+				ActionType: persistence.QueueActionMessage,
+				ProfileURL: followUps[0].ProfileURL,
+				Payload:    followUpTemplate(),
+			}
+			followUps = followUps[1:]
+		case stealth.StepBrowse:
+			// Leave a browsing-sized gap between writes
+			at = at.Add(stealth.RandomSeconds(30, 90))
+			continue
+		}
+
+		action.ScheduledAt = at
+		if err := store.EnqueueAction(action); err != nil {
+			fmt.Printf("⚠️ Failed to queue %s %s: %v\n", action.ActionType, action.ProfileURL, err)
+			continue
+		}
+		planned++
+	}
+
+	fmt.Printf("✅ Queued %d actions\n", planned)
+	printQueue()
+}
+
+// printQueue lists the queued actions in execution order
+func printQueue() {
+	actions, err := store.GetQueuedActions(0)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to read queue: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\n📋 Action queue (%d pending):\n", len(actions))
+	for _, a := range actions {
+		fmt.Printf("   #%d %s  %-7s %s\n", a.ID, a.ScheduledAt.Format("15:04:05"), a.ActionType, a.ProfileURL)
+	}
+}
+
+// RunQueue executes due actions from the queue until it is empty
+// Rate limits and the work schedule are checked before every action;
+// when either blocks, the remaining actions stay queued for the next run.
+func RunQueue() {
+	fmt.Println("\n==================================================")
+	fmt.Println("▶️ EXECUTE ACTION QUEUE")
+	fmt.Println("==================================================")
+
+	executeQueue(false)
+}
+
+// executeQueue runs queued actions in order
+// With dueOnly, it stops at the first action scheduled in the future
+// instead of waiting for it
+func executeQueue(dueOnly bool) {
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		return
+	}
+	defer pagePool.Put(page)
+
+	tracker, err := connect.LoadTracker()
+	if err != nil {
+		log.Printf("⚠️ Failed to load tracker: %v\n", err)
+		return
+	}
+	tracker.SetDryRun(DryRunMode)
+	tracker.SetValidateDryRun(DryRunValidate)
+	tracker.SetDailyLimit(stealth.GetConnectionDailyLimit())
+	tracker.SetNoteOmissionRate(NoteOmissionRate)
+	tracker.SetRequireNote(RequireConnectNote)
+	tracker.SetEmailLookup(leadEmail)
+
+	msgService, err := message.NewMessagingService(page)
+	if err != nil {
+		log.Printf("⚠️ Failed to create messaging service: %v\n", err)
+		return
+	}
+	defer msgService.Close()
+	msgService.SetDryRun(DryRunMode)
+	msgService.SetDailyLimit(stealth.GetMessageDailyLimit())
+	msgService.SetMinHoursSinceConnected(MinHoursSinceConnected)
+	msgService.SetShuffleOrder(ShuffleContactOrder)
+	configureSegments(msgService)
+	if OnlyMessageOurConnections {
+		msgService.SetInitiatedFilter(initiatedByUs)
+	}
+
+	var scheduler *stealth.Scheduler
+	if EnforceSchedule {
+		scheduler = stealth.NewScheduler()
+	}
+	rateLimiter := stealth.GetRateLimiter()
+
+	done, failed := 0, 0
+	for {
+		action, err := store.NextQueuedAction()
+		if err != nil {
+			fmt.Printf("⚠️ Failed to read queue: %v\n", err)
+			break
+		}
+		if action == nil {
+			fmt.Println("✅ Queue is empty")
+			break
+		}
+
+		actionType := stealth.ActionConnection
+		if action.ActionType == persistence.QueueActionMessage {
+			actionType = stealth.ActionMessage
+		}
+
+		if scheduler != nil && !scheduler.CanOperate(actionType) {
+			fmt.Printf("⏰ Outside work hours or the %s window - leaving the rest of the queue for later\n", actionType)
+			break
+		}
+		if can, reason := rateLimiter.CanPerform(actionType); !can {
+			fmt.Printf("⏸️ Rate limited: %s - leaving the rest of the queue for later\n", reason)
+			break
+		}
+
+		if wait := time.Until(action.ScheduledAt); wait > 0 {
+			if dueOnly {
+				fmt.Printf("📅 Next action #%d is due at %s - leaving it for a later run\n", action.ID, action.ScheduledAt.Format("2006-01-02 15:04"))
+				break
+			}
+			fmt.Printf("\n⏳ Next action #%d at %s (waiting %v)\n", action.ID, action.ScheduledAt.Format("15:04:05"), wait.Round(time.Second))
+			if err := stealth.WatchedSleep(page, wait); err != nil {
+				fmt.Println("🛑 Critical warning during the wait - leaving the rest of the queue for later")
+				break
+			}
+		}
+
+		if action.ActionType == persistence.QueueActionConnect && pendingCeilingReached(page, tracker) {
+			fmt.Println("   Leaving the rest of the queue for later")
+			break
+		}
+
+		fmt.Printf("\n========== Queue #%d: %s %s ==========\n", action.ID, action.ActionType, action.ProfileURL)
+		store.MarkActionRunning(action.ID)
+
+		var execErr error
+		switch action.ActionType {
+		case persistence.QueueActionConnect:
+			if sent, _ := store.HasSentRequest(action.ProfileURL); sent {
+				execErr = fmt.Errorf("connection request already sent")
+				break
+			}
+			execErr = connect.ConnectWithTracking(page, action.ProfileURL, "", action.Payload, tracker)
+			if execErr == nil {
+				saveConnectionRequestToDB(action.ProfileURL, sentNote(tracker, action.ProfileURL, action.Payload), tracker.NoteSkipped(action.ProfileURL))
+			}
+		case persistence.QueueActionMessage:
+			conn := msgService.Tracker.GetConnection(action.ProfileURL)
+			if conn == nil {
+				execErr = fmt.Errorf("connection not found in message tracker")
+				break
+			}
+			execErr = msgService.SendFollowUp(*conn, action.Payload)
+		default:
+			execErr = fmt.Errorf("unknown action type %q", action.ActionType)
+		}
+
+		if execErr != nil {
+			fmt.Printf("❌ Action failed: %v\n", execErr)
+			store.MarkActionFailed(action.ID, execErr.Error())
+			recordFailure(action.ActionType, action.ProfileURL, action.Payload, execErr)
+			failed++
+			if stealth.IsCritical(execErr) || stealth.IsNoteLimit(execErr) {
+				fmt.Println("🛑 Critical error detected - stopping queue")
+				break
+			}
+			continue
+		}
+
+		store.MarkActionDone(action.ID)
+		rateLimiter.RecordAction(actionType)
+		done++
+	}
+
+	fmt.Printf("\n✅ Queue Results: %d done, %d failed\n", done, failed)
+}
+
+// RetryFailedActions re-attempts connects and messages that failed for a
+// transient reason (timeouts, overlays, rate limits). Each failure is tried
+// at most MaxActionRetries times; permanent failures are never retried
+func RetryFailedActions() {
+	fmt.Println("\n==================================================")
+	fmt.Println("🔁 RETRY FAILED ACTIONS")
+	fmt.Println("==================================================")
+
+	if n, err := store.GiveUpExhaustedActions(MaxActionRetries); err == nil && n > 0 {
+		fmt.Printf("🪦 Gave up on %d actions after %d attempts\n", n, MaxActionRetries)
+	}
+
+	actions, err := store.GetRetryableActions(MaxActionRetries, 0)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to read failed actions: %v\n", err)
+		return
+	}
+	if len(actions) == 0 {
+		fmt.Println("✅ Nothing to retry")
+		return
+	}
+	fmt.Printf("📋 %d failed actions to retry\n", len(actions))
+
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		return
+	}
+	defer pagePool.Put(page)
+
+	tracker, err := connect.LoadTracker()
+	if err != nil {
+		log.Printf("⚠️ Failed to load tracker: %v\n", err)
+		return
+	}
+	tracker.SetDryRun(DryRunMode)
+	tracker.SetValidateDryRun(DryRunValidate)
+	tracker.SetDailyLimit(stealth.GetConnectionDailyLimit())
+	tracker.SetNoteOmissionRate(NoteOmissionRate)
+	tracker.SetRequireNote(RequireConnectNote)
+	tracker.SetEmailLookup(leadEmail)
+
+	msgService, err := message.NewMessagingService(page)
+	if err != nil {
+		log.Printf("⚠️ Failed to create messaging service: %v\n", err)
+		return
+	}
+	defer msgService.Close()
+	msgService.SetDryRun(DryRunMode)
+	msgService.SetDailyLimit(stealth.GetMessageDailyLimit())
+
+	var scheduler *stealth.Scheduler
+	if EnforceSchedule {
+		scheduler = stealth.NewScheduler()
+	}
+	rateLimiter := stealth.GetRateLimiter()
+
+	retried, resolved := 0, 0
+	for _, action := range actions {
+		actionType := stealth.ActionConnection
+		if action.ActionType == persistence.QueueActionMessage {
+			actionType = stealth.ActionMessage
+		}
+
+		if scheduler != nil && !scheduler.CanOperate(actionType) {
+			fmt.Printf("⏰ Outside work hours or the %s window - leaving the rest for later\n", actionType)
+			break
+		}
+		if can, reason := rateLimiter.CanPerform(actionType); !can {
+			fmt.Printf("⏸️ Rate limited: %s - leaving the rest for later\n", reason)
+			break
+		}
+		if skipBlocked(action.ProfileURL) {
+			continue
+		}
+		if action.ActionType == persistence.QueueActionConnect && pendingCeilingReached(page, tracker) {
+			continue
+		}
+
+		fmt.Printf("\n🔁 Retry %d/%d: %s %s (last failure: %s)\n", action.Attempts+1, MaxActionRetries, action.ActionType, action.ProfileURL, action.Reason)
+
+		var retryErr error
+		switch action.ActionType {
+		case persistence.QueueActionConnect:
+			if sent, _ := store.HasSentRequest(action.ProfileURL); sent {
+				fmt.Println("⏭️ Request went out in the meantime")
+				store.ResolveFailedAction(action.ActionType, action.ProfileURL)
+				continue
+			}
+			retryErr = connect.ConnectWithTracking(page, action.ProfileURL, "", action.Payload, tracker)
+			if retryErr == nil {
+				saveConnectionRequestToDB(action.ProfileURL, sentNote(tracker, action.ProfileURL, action.Payload), tracker.NoteSkipped(action.ProfileURL))
+			}
+		case persistence.QueueActionMessage:
+			if msgService.Tracker.HasMessaged(action.ProfileURL) {
+				fmt.Println("⏭️ Message went out in the meantime")
+				store.ResolveFailedAction(action.ActionType, action.ProfileURL)
+				continue
+			}
+			conn := msgService.Tracker.GetConnection(action.ProfileURL)
+			if conn == nil {
+				retryErr = fmt.Errorf("connection not found in message tracker")
+				break
+			}
+			retryErr = msgService.SendFollowUp(*conn, action.Payload)
+		default:
+			retryErr = fmt.Errorf("unknown action type %q", action.ActionType)
+		}
+		retried++
+
+		if retryErr != nil {
+			fmt.Printf("❌ Retry failed: %v\n", retryErr)
+			recordFailure(action.ActionType, action.ProfileURL, action.Payload, retryErr)
+			if stealth.IsCritical(retryErr) || stealth.IsNoteLimit(retryErr) {
+				fmt.Println("🛑 Critical error detected - stopping retries")
+				break
+			}
+		} else {
+			store.ResolveFailedAction(action.ActionType, action.ProfileURL)
+			rateLimiter.RecordAction(actionType)
+			resolved++
+		}
+
+		delay := stealth.GetRandomDelay(actionType)
+		fmt.Printf("⏳ Waiting %v before the next retry...\n", delay.Round(time.Second))
+		if err := stealth.WatchedSleep(page, delay); err != nil {
+			fmt.Println("🛑 Critical warning during the wait - stopping retries")
+			break
+		}
+	}
+
+	fmt.Printf("\n✅ Retry Results: %d retried, %d succeeded\n", retried, resolved)
+}
+
+// recordFailure stores a failed connect or message for RetryFailedActions
+func recordFailure(actionType, profileURL, payload string, err error) {
+	if err == nil || DryRunMode {
+		return
+	}
+	retryable := retryableFailure(err)
+	if rerr := store.RecordFailedAction(actionType, profileURL, payload, err.Error(), retryable); rerr != nil {
+		fmt.Printf("⚠️ %v\n", rerr)
+		return
+	}
+	if !retryable {
+		fmt.Println("   🚫 Permanent failure - won't be retried")
+	}
+}
+
+// recordMessageFailure is the messaging service's OnFailure hook
+func recordMessageFailure(conn message.Connection, templateName string, err error) {
+	recordFailure(persistence.QueueActionMessage, conn.ProfileURL, templateName, err)
+}
+
+// retryableFailure reports whether a failed action could succeed on a later
+// try. Skips (profile gone, already connected, can't message), account-level
+// stops, blocklist and approval rejections fail the same way every time
+func retryableFailure(err error) bool {
+	var linkedInErr *stealth.LinkedInError
+	if errors.As(err, &linkedInErr) {
+		switch linkedInErr.Action {
+		case stealth.ActionSkip, stealth.ActionStop, stealth.ActionManual:
+			return false
+		}
+		return true
+	}
+
+	permanent := []error{
+		stealth.ErrBlocked, stealth.ErrNotApproved, stealth.ErrReadOnly,
+		connect.ErrHowDoYouKnow, connect.ErrInviteNotPending,
+	}
+	for _, target := range permanent {
+		if errors.Is(err, target) {
+			return false
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	return !strings.Contains(msg, "already") && !strings.Contains(msg, "not found in message tracker")
+}
+
+// RunNurture ties connecting and messaging together: accepted invites found
+// by the connection sync are queued for a follow-up NurtureDelayHours after
+// they were accepted, then every follow-up that is already due is sent
+func RunNurture() {
+	fmt.Println("\n==================================================")
+	fmt.Println("🌱 NURTURE WORKFLOW (CONNECT → MESSAGE)")
+	fmt.Println("==================================================")
+
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		return
+	}
+
+	msgService, err := message.NewMessagingService(page)
+	if err != nil {
+		log.Printf("⚠️ Failed to create messaging service: %v\n", err)
+		pagePool.Put(page)
+		return
+	}
+	configureSegments(msgService)
+	if _, err := msgService.SyncConnections(NurtureSyncMaxToScan); err != nil {
+		fmt.Printf("⚠️ Error syncing connections: %v\n", err)
+	}
+	connections := msgService.Tracker.GetUnmessagedConnections()
+	msgService.Close()
+	pagePool.Put(page)
+
+	delay := time.Duration(NurtureDelayHours) * time.Hour
+	queued := 0
+	for _, conn := range connections {
+		// Only people who accepted one of our own invites enter the pipeline
+		if !initiatedByUs(conn.ProfileURL) {
+			continue
+		}
+		if MessageSegment != "" && !inMessageSegment(conn.ProfileURL) {
+			continue
+		}
+		if req, err := store.GetConnectionRequest(conn.ProfileURL); err == nil && req != nil && req.Status == persistence.StatusPending {
+			store.UpdateRequestStatus(conn.ProfileURL, persistence.StatusAccepted)
+			fmt.Printf("✅ Invite accepted: %s\n", conn.ProfileURL)
+		}
+		if skipBlocked(conn.ProfileURL) {
+			continue
+		}
+
+		action := &persistence.QueuedAction{
+			ActionType:  persistence.QueueActionMessage,
+			ProfileURL:  conn.ProfileURL,
+			Payload:     followUpTemplate(),
+			ScheduledAt: conn.ConnectedAt.Add(delay),
+		}
+		if err := store.EnqueueAction(action); err != nil {
+			fmt.Printf("⚠️ Failed to queue follow-up for %s: %v\n", conn.ProfileURL, err)
+			continue
+		}
+		queued++
+	}
+	fmt.Printf("📥 %d accepted connections in the follow-up queue (sent %dh after acceptance)\n", queued, NurtureDelayHours)
+	printQueue()
+
+	executeQueue(true)
+}
+
+// RunReconcile re-checks pending connection requests against LinkedIn's
+// sent-invitations manager so declined invites stop counting as pending
+func RunReconcile() {
+	fmt.Println("\n==================================================")
+	fmt.Println("🔄 RECONCILE PENDING REQUESTS")
+	fmt.Println("==================================================")
+
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		return
+	}
+	defer pagePool.Put(page)
+
+	if _, err := connect.ReconcilePendingRequests(page, store); err != nil {
+		log.Printf("⚠️ Reconcile stopped: %v\n", err)
+	}
+
+	if stats, err := store.GetConnectionRequestStats(stealth.GetConnectionDailyLimit()); err == nil {
+		fmt.Printf("\n📊 Requests: %d pending, %d accepted, %d declined\n",
+			stats.Pending, stats.Accepted, stats.Declined)
+	}
+}
+
+// RunSuggestions scrapes "People you may know" as an extra lead source
+// With SuggestionsGridConnect, invites are sent straight from the cards
+func RunSuggestions() {
+	fmt.Println("\n==================================================")
+	fmt.Println("👥 SUGGESTIONS WORKFLOW")
+	fmt.Println("==================================================")
+
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		return
+	}
+	defer pagePool.Put(page)
+
+	suggestions, err := search.ScrapeSuggestions(page, SuggestionsMaxPages)
+	if err != nil {
+		fmt.Printf("⚠️ Suggestions scrape stopped: %v\n", err)
+	}
+
+	var results []persistence.PersonSearchResult
+	for _, s := range suggestions {
+		if exists, _ := store.HasPersonResult(s.ProfileURL); exists {
+			continue
+		}
+		results = append(results, persistence.PersonSearchResult{
+			ProfileURL:    s.ProfileURL,
+			Name:          s.Name,
+			Headline:      s.Headline,
+			SearchKeyword: search.SuggestionsSource,
+			DiscoveredAt:  time.Now(),
+		})
+	}
+	if len(results) > 0 {
+		if err := store.SavePersonSearchResults(results); err != nil {
+			fmt.Printf("⚠️ Failed to save suggestions: %v\n", err)
+		} else {
+			fmt.Printf("💾 Saved %d new suggested profiles\n", len(results))
+		}
+	}
+
+	if !SuggestionsGridConnect || len(suggestions) == 0 {
+		return
+	}
+	if RequireConnectNote {
+		fmt.Println("ℹ️ Grid invites can't carry a note and RequireConnectNote is set - not sending from the grid")
+		return
+	}
+
+	tracker, err := connect.LoadTracker()
+	if err != nil {
+		log.Printf("⚠️ Failed to load tracker: %v\n", err)
+		return
+	}
+	tracker.SetDryRun(DryRunMode)
+	tracker.SetValidateDryRun(DryRunValidate)
+	tracker.SetDailyLimit(stealth.GetConnectionDailyLimit())
+
+	rateLimiter := stealth.GetRateLimiter()
+	sent := 0
+	for _, s := range suggestions {
+		if !tracker.CanSendMore() {
+			fmt.Println("📊 Daily connection limit reached")
+			break
+		}
+		if can, reason := rateLimiter.CanPerform(stealth.ActionConnection); !can {
+			fmt.Printf("⏸️ Rate limited: %s\n", reason)
+			break
+		}
+		if pendingCeilingReached(page, tracker) {
+			fmt.Println("📬 Pending invite ceiling reached - not sending more from the grid")
+			break
+		}
+		if done, _ := store.HasSentRequest(s.ProfileURL); done {
+			continue
+		}
+		if skipBlocked(s.ProfileURL) {
+			continue
+		}
+		if skipCompanyQuota(s.ProfileURL, "") {
+			continue
+		}
+		if skipProfileFilter(page, s.ProfileURL) {
+			continue
+		}
+
+		// The profile filters open the profile, so connect from there;
+		// otherwise use the card, reopening the grid if a check left it
+		var err error
+		if info, infoErr := page.Info(); infoErr == nil && linkedinurl.Canonicalize(info.URL) == linkedinurl.Canonicalize(s.ProfileURL) {
+			err = connect.ConnectWithTracking(page, s.ProfileURL, s.Name, "", tracker)
+		} else if err = returnToSuggestions(page, s.ProfileURL); err == nil {
+			err = connect.ConnectFromSuggestionCard(page, s.ProfileURL, s.Name, tracker)
+		}
+		if err != nil {
+			fmt.Printf("❌ Card connect failed for %s: %v\n", s.ProfileURL, err)
+			if stealth.IsCritical(err) {
+				fmt.Println("🛑 Critical error detected - stopping workflow")
+				break
+			}
+			continue
+		}
+
+		sent++
+		rateLimiter.RecordAction(stealth.ActionConnection)
+		saveConnectionRequestFromSource(s.ProfileURL, "", false, search.SuggestionsSource, search.SuggestionsSource)
+
+		delay := stealth.GetRandomDelay(stealth.ActionConnection)
+		fmt.Printf("⏳ Waiting %v before the next card...\n", delay.Round(time.Second))
+		if err := stealth.WatchedSleep(page, delay); err != nil {
+			fmt.Println("🛑 Critical warning during the wait - stopping workflow")
+			break
+		}
+	}
+
+	fmt.Printf("\n✅ Suggestions: %d profiles found, %d invites sent from the grid\n", len(suggestions), sent)
+}
+
+// returnToSuggestions reopens the suggestions grid if the page has left it and
+// expands it again until the card linking to profileURL is back on the page
+func returnToSuggestions(page *rod.Page, profileURL string) error {
+	if info, err := page.Info(); err == nil && strings.HasPrefix(info.URL, search.SuggestionsURL) {
+		return nil
+	}
+	if err := page.Navigate(search.SuggestionsURL); err != nil {
+		return fmt.Errorf("failed to reopen suggestions page: %w", err)
+	}
+	page.WaitLoad()
+	stealth.Sleep(2, 4)
+
+	if !search.ExpandSuggestionsTo(page, profileURL, SuggestionsMaxPages) {
+		return fmt.Errorf("card not found after reopening the suggestions grid")
+	}
+	return nil
+}
+
+// RunProfileViewers scrapes "Who viewed your profile" as a lead source
+// Named viewers are recorded for ProfileViewerPolicy and saved as leads
+// under the profile_viewers keyword for the connect workflow
+func RunProfileViewers() {
+	fmt.Println("\n==================================================")
+	fmt.Println("👀 PROFILE VIEWERS WORKFLOW")
+	fmt.Println("==================================================")
+
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		return
+	}
+	defer pagePool.Put(page)
+
+	viewers, err := search.ScrapeProfileViewers(page, ProfileViewersMaxPages)
+	if err != nil {
+		fmt.Printf("⚠️ Profile viewers scrape stopped: %v\n", err)
+	}
+
+	var results []persistence.PersonSearchResult
+	for _, v := range viewers {
+		if err := store.SaveProfileViewer(&persistence.ProfileViewer{
+			ProfileURL: v.ProfileURL,
+			Name:       v.Name,
+			Headline:   v.Headline,
+			ViewedAt:   v.ViewedAt,
+		}); err != nil {
+			fmt.Printf("⚠️ %v\n", err)
+		}
+
+		if exists, _ := store.HasPersonResult(v.ProfileURL); exists {
+			continue
+		}
+		if sent, _ := store.HasSentRequest(v.ProfileURL); sent {
+			continue
+		}
+		results = append(results, persistence.PersonSearchResult{
+			ProfileURL:    v.ProfileURL,
+			Name:          v.Name,
+			Headline:      v.Headline,
+			SearchKeyword: search.ProfileViewersSource,
+			DiscoveredAt:  time.Now(),
+		})
+	}
+	if len(results) > 0 {
+		if err := store.SavePersonSearchResults(results); err != nil {
+			fmt.Printf("⚠️ Failed to save profile viewers: %v\n", err)
+		} else {
+			fmt.Printf("💾 Saved %d new profile viewer leads\n", len(results))
+		}
+	}
+
+	fmt.Printf("\n✅ Profile viewers: %d named viewers found\n", len(viewers))
+}
+
+// RunWarmup only looks at profiles: count random stored leads are opened and
+// read with BrowseProfile's dwell times, with no connects, messages or likes.
+// Meant for the first days of a new or recovered account, before any connects.
+// Visits are recorded so later warmups pick profiles not seen for WarmupRevisitDays
+func RunWarmup(count int) {
+	fmt.Println("\n==================================================")
+	fmt.Println("🌤️ WARMUP (VIEW PROFILES ONLY)")
+	fmt.Println("==================================================")
+
+	// Read-only for the whole run: nothing in here may write, whatever a helper does
+	if !stealth.IsReadOnly() {
+		stealth.SetReadOnly(true)
+		defer stealth.SetReadOnly(false)
+	}
+
+	revisitAfter := time.Duration(WarmupRevisitDays) * 24 * time.Hour
+	targets, err := store.GetWarmupProfiles(count, revisitAfter)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to pick profiles: %v\n", err)
+		return
+	}
+	if len(targets) == 0 {
+		fmt.Printf("ℹ️ No stored profiles left that weren't viewed in the last %d days - run a search first\n", WarmupRevisitDays)
+		return
+	}
+
+	page, err := pagePool.Get()
+	if err != nil {
+		log.Printf("⚠️ Failed to get page: %v\n", err)
+		return
+	}
+	defer pagePool.Put(page)
+
+	var scheduler *stealth.Scheduler
+	if EnforceSchedule {
+		scheduler = stealth.NewScheduler()
+	}
+	organicBrowser := stealth.NewOrganicBrowser(page)
+
+	viewed := 0
+	for i, profileURL := range targets {
+		if scheduler != nil && !(scheduler.IsWorkDay() && scheduler.IsWorkHours()) {
+			fmt.Println("⏰ Outside work hours - stopping warmup")
+			break
+		}
+
+		fmt.Printf("\n[%d/%d] ", i+1, len(targets))
+		err := organicBrowser.BrowseProfile(profileURL)
+		if err != nil {
+			fmt.Printf("   ⚠️ View failed: %v\n", err)
+			if stealth.IsCritical(err) {
+				fmt.Println("🛑 Critical error detected - stopping warmup")
+				break
+			}
+		} else {
+			viewed++
+		}
+		if err := store.RecordProfileVisit(profileURL, persistence.VisitPurposeWarmup); err != nil {
+			fmt.Printf("⚠️ %v\n", err)
+		}
+
+		if i < len(targets)-1 {
+			// Now and then glance at the feed between profiles
+			if stealth.Rand().Float64() < 0.3 {
+				if err := organicBrowser.BrowseFeed(); err != nil {
+					fmt.Printf("   ⚠️ Feed browse failed: %v (continuing)\n", err)
+				}
+			}
+			delay := adaptiveDelay(scheduler, stealth.ActionSearch)
+			fmt.Printf("⏳ Waiting %v before the next profile...\n", delay.Round(time.Second))
+			if err := stealth.WatchedSleep(page, delay); err != nil {
+				fmt.Println("🛑 Critical warning during the wait - stopping warmup")
+				break
+			}
+		}
+	}
+
+	fmt.Printf("\n✅ Warmup: viewed %d/%d profiles\n", viewed, len(targets))
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Nehilsa2/linkedin_automation/persistence"
+	"github.com/Nehilsa2/linkedin_automation/search"
+)
+
+// useTestStore points the workflows at a fresh database for one test
+func useTestStore(t *testing.T) {
+	t.Helper()
+	s, err := persistence.NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := store
+	store = s
+	t.Cleanup(func() {
+		store = prev
+		s.Close()
+	})
+}
+
+func TestSkipCompanyQuota(t *testing.T) {
+	useTestStore(t)
+
+	target := "https://www.linkedin.com/in/next-at-acme"
+	if err := store.SavePersonSearchResult(&persistence.PersonSearchResult{
+		ProfileURL: target, Company: "Acme", SearchKeyword: "test",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	sendTo := func(slug, company string) {
+		t.Helper()
+		err := store.SaveConnectionRequest(&persistence.ConnectionRequest{
+			ProfileURL: "https://www.linkedin.com/in/" + slug,
+			Company:    company,
+			SentAt:     time.Now().Add(-time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 1; i < MaxRequestsPerCompanyPerWeek; i++ {
+		sendTo("acme-"+string(rune('a'+i)), "ACME")
+	}
+	sendTo("other", "Globex")
+	if skipCompanyQuota(target, "") {
+		t.Fatalf("skipped below the cap of %d", MaxRequestsPerCompanyPerWeek)
+	}
+
+	sendTo("acme-last", "acme ")
+	if !skipCompanyQuota(target, "") {
+		t.Fatalf("did not skip after %d requests to the company", MaxRequestsPerCompanyPerWeek)
+	}
+	if person, _ := store.GetPersonResult(target); person == nil || !person.Processed {
+		t.Error("skipped target was not marked processed")
+	}
+
+	// A company read from the profile counts even when the lead has none stored
+	if !skipCompanyQuota("https://www.linkedin.com/in/not-a-lead", "Acme") {
+		t.Error("did not skip a target whose company was passed in")
+	}
+	if skipCompanyQuota("https://www.linkedin.com/in/unknown", "") {
+		t.Error("skipped a target of unknown company")
+	}
+}
+
+func TestInterleaveByCompany(t *testing.T) {
+	useTestStore(t)
+
+	leads := []struct{ slug, company string }{
+		{"a1", "Acme"}, {"a2", "Acme"}, {"a3", "acme"},
+		{"g1", "Globex"}, {"g2", "Globex"},
+		{"u1", ""},
+	}
+	var urls []string
+	for _, l := range leads {
+		url := "https://www.linkedin.com/in/" + l.slug
+		urls = append(urls, url)
+		if err := store.SavePersonSearchResult(&persistence.PersonSearchResult{
+			ProfileURL: url, Company: l.company, SearchKeyword: "test",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := interleaveByCompany(urls)
+	if len(got) != len(urls) {
+		t.Fatalf("got %d targets, want %d", len(got), len(urls))
+	}
+	var order []string
+	for _, url := range got {
+		order = append(order, url[len("https://www.linkedin.com/in/"):])
+	}
+	want := []string{"a1", "g1", "u1", "a2", "g2", "a3"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("interleaveByCompany order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestCompanyPacer(t *testing.T) {
+	var p companyPacer
+	for i := 0; i < SameCompanyStreak; i++ {
+		if wait := p.cooldown("Acme"); wait != 0 {
+			t.Fatalf("cooldown after %d invites = %v, want 0", i, wait)
+		}
+		p.sent("Acme")
+	}
+	if p.cooldown("ACME") == 0 {
+		t.Errorf("no cooldown after %d invites in a row to one company", SameCompanyStreak)
+	}
+	if p.cooldown("Globex") != 0 {
+		t.Error("cooldown applied to a different company")
+	}
+	p.reset()
+	if p.cooldown("Acme") != 0 {
+		t.Error("cooldown still applied after reset")
+	}
+}
+
+func TestNoteForTargetUsesStoredLead(t *testing.T) {
+	useTestStore(t)
+
+	target := "https://www.linkedin.com/in/ada"
+	if err := store.SavePersonSearchResult(&persistence.PersonSearchResult{
+		ProfileURL: target, Name: "Ada", Headline: "Engineer at Acme | Speaker",
+		Company: "Acme", SearchKeyword: "test",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	saveCompanyResultsToDB([]string{"https://www.linkedin.com/company/acme"}, map[string]search.CompanyCard{
+		"https://www.linkedin.com/company/acme": {Name: "ACME", Industry: "Software Development", Location: "Berlin"},
+	}, "test")
+
+	// No page load happens: the lead is known and the template needs no post
+	got := noteForTarget(nil, target, "Hi {name}! Fellow {title} here. How is {company_industry} at {company}?")
+	want := "Hi Ada! Fellow Engineer here. How is Software Development at Acme?"
+	if got != want {
+		t.Errorf("noteForTarget = %q, want %q", got, want)
+	}
+}
+
+func TestSavePeopleResultsUpdatesCardState(t *testing.T) {
+	useTestStore(t)
+
+	url := "https://www.linkedin.com/in/ada"
+	state := func() string {
+		t.Helper()
+		person, err := store.GetPersonResult(url)
+		if err != nil || person == nil {
+			t.Fatalf("GetPersonResult: %v, %v", person, err)
+		}
+		return person.ConnectionState
+	}
+
+	savePeopleResultsToDB([]string{url}, "test", nil)
+	if got := state(); got != "" {
+		t.Fatalf("new lead state = %q, want empty", got)
+	}
+
+	// A re-crawl shows the invite pending, then the card offering Connect again
+	savePeopleResultsPageToDB([]string{url}, "test", 1, map[string]string{url: search.CardStatePending}, nil)
+	if got := state(); got != search.CardStatePending {
+		t.Fatalf("state after re-crawl = %q, want %q", got, search.CardStatePending)
+	}
+	savePeopleResultsToDB([]string{url}, "test", map[string]string{})
+	if got := state(); got != search.CardStatePending {
+		t.Fatalf("state after a crawl that couldn't read the card = %q, want it kept", got)
+	}
+	savePeopleResultsToDB([]string{url}, "test", map[string]string{url: search.CardStateConnectable})
+	if got := state(); got != "" {
+		t.Fatalf("state after the card offers Connect = %q, want empty", got)
+	}
+}
+
+func TestRecentProfileViewerMatchesAnyURLForm(t *testing.T) {
+	useTestStore(t)
+
+	if err := store.SaveProfileViewer(&persistence.ProfileViewer{
+		ProfileURL: "https://de.linkedin.com/in/Ada-Lovelace/?miniProfileUrn=x",
+		Name:       "Ada Lovelace",
+		ViewedAt:   time.Now().Add(-time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, url := range []string{
+		"https://www.linkedin.com/in/ada-lovelace",
+		"https://www.linkedin.com/in/ada-lovelace/",
+		"linkedin.com/in/Ada-Lovelace",
+	} {
+		if !recentProfileViewer(url) {
+			t.Errorf("recentProfileViewer(%q) = false, want true", url)
+		}
+	}
+	if recentProfileViewer("https://www.linkedin.com/in/someone-else") {
+		t.Error("recentProfileViewer matched a member who never viewed the profile")
+	}
+}